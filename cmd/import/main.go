@@ -0,0 +1,90 @@
+// Command import loads IEBC ward and polling station register files into
+// the database.
+//
+//	import -wards wards.csv -stations polling_stations.csv [-dry-run]
+//
+// Rejected rows are printed as tab-separated line, code and reason. The
+// exit status is 1 on failure and 2 if any row was rejected.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"jalada/internal/config"
+	"jalada/internal/database"
+	"jalada/internal/importer"
+)
+
+func main() {
+	wardsPath := flag.String("wards", "", "ward register CSV")
+	stationsPath := flag.String("stations", "", "polling station register CSV")
+	dryRun := flag.Bool("dry-run", false, "validate without writing")
+	flag.Parse()
+
+	if *wardsPath == "" && *stationsPath == "" {
+		fmt.Fprintln(os.Stderr, "usage: import -wards FILE -stations FILE [-dry-run]")
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load config")
+	}
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if err := database.RunMigrations(cfg.Database.URL); err != nil {
+		log.Fatal().Err(err).Msg("failed to run migrations")
+	}
+	pool, err := database.NewPool(ctx, cfg.Database.URL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to connect to database")
+	}
+	defer pool.Close()
+
+	var files importer.Files
+	if files.Wards, err = open(*wardsPath); err != nil {
+		log.Fatal().Err(err).Msg("failed to open ward file")
+	}
+	if files.Stations, err = open(*stationsPath); err != nil {
+		log.Fatal().Err(err).Msg("failed to open polling station file")
+	}
+
+	reports, err := importer.New(pool).Run(ctx, files, *dryRun)
+	if err != nil {
+		log.Fatal().Err(err).Msg("import failed")
+	}
+
+	rejected := 0
+	for _, r := range reports {
+		for _, rej := range r.Rejected {
+			fmt.Printf("%s\t%d\t%s\t%s\n", r.Kind, rej.Line, rej.Code, rej.Reason)
+		}
+		rejected += len(r.Rejected)
+		log.Info().Str("kind", r.Kind).Int("rows", r.Rows).Int("inserted", r.Inserted).
+			Int("updated", r.Updated).Int("rejected", len(r.Rejected)).Bool("dry_run", *dryRun).Msg("imported")
+	}
+	if rejected > 0 {
+		os.Exit(2)
+	}
+}
+
+// open returns nil for an empty path so the file is skipped. The file is
+// left for the process exit to close.
+func open(path string) (io.Reader, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return os.Open(path)
+}
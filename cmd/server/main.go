@@ -15,6 +15,7 @@ import (
 	"jalada/internal/config"
 	"jalada/internal/database"
 	"jalada/internal/handlers"
+	"jalada/internal/middleware"
 	"jalada/internal/repository"
 	"jalada/internal/scraper"
 	"jalada/internal/seeder"
@@ -30,6 +31,9 @@ func main() {
 	setupLogger(cfg)
 
 	log.Info().Str("env", cfg.Server.Env).Msg("starting Jalada")
+	if len(cfg.Auth.EditorTokens) == 0 {
+		log.Warn().Msg("EDITOR_TOKENS is empty; write endpoints will reject every request")
+	}
 
 	if err := database.RunMigrations(cfg.Database.URL); err != nil {
 		log.Fatal().Err(err).Msg("failed to run migrations")
@@ -47,39 +51,84 @@ func main() {
 	if err := seeder.Seed(ctx, pool); err != nil {
 		log.Fatal().Err(err).Msg("failed to seed data")
 	}
+	if err := seeder.SeedBoundaries(ctx, pool, cfg.Geo.BoundariesDir); err != nil {
+		log.Fatal().Err(err).Msg("failed to seed boundaries")
+	}
 
 	// Repositories
 	politicianRepo := repository.NewPoliticianRepo(pool)
 	partyRepo := repository.NewPartyRepo(pool)
 	electionRepo := repository.NewElectionRepo(pool)
+	resultsRepo := repository.NewResultsRepo(pool)
+	anomalyRepo := repository.NewAnomalyRepo(pool)
+	candidacyRepo := repository.NewCandidacyRepo(pool)
 	geographyRepo := repository.NewGeographyRepo(pool)
 	newsRepo := repository.NewNewsRepo(pool)
 	eventRepo := repository.NewEventRepo(pool)
 	sentimentRepo := repository.NewSentimentRepo(pool)
 	analyticsRepo := repository.NewAnalyticsRepo(pool)
+	apiKeyRepo := repository.NewAPIKeyRepo(pool)
+	auditRepo := repository.NewAuditRepo(pool)
+	sourceRepo := repository.NewSourceRepo(pool)
+	submissionRepo := repository.NewSubmissionRepo(pool)
+	searchRepo := repository.NewSearchRepo(pool)
+	autocompleteRepo := repository.NewAutocompleteRepo(pool)
+	representativeRepo := repository.NewRepresentativeRepo(pool)
+	pollRepo := repository.NewPollRepo(pool)
+	storyRepo := repository.NewStoryRepo(pool)
 
 	// Services
-	politicianSvc := services.NewPoliticianService(politicianRepo, newsRepo, sentimentRepo, eventRepo)
-	electionSvc := services.NewElectionService(electionRepo)
+	politicianSvc := services.NewPoliticianService(politicianRepo, newsRepo, sentimentRepo, eventRepo, auditRepo)
+	electionSvc := services.NewElectionService(electionRepo, resultsRepo, anomalyRepo, candidacyRepo)
 	timelineSvc := services.NewTimelineService(eventRepo)
-	analyticsSvc := services.NewAnalyticsService(analyticsRepo, sentimentRepo)
+	analyticsSvc := services.NewAnalyticsService(analyticsRepo, sentimentRepo, electionRepo)
+	apiKeySvc := services.NewAPIKeyService(apiKeyRepo)
+	submissionSvc := services.NewSubmissionService(submissionRepo, sourceRepo, politicianSvc)
+	autocompleteSvc := services.NewAutocompleteService(autocompleteRepo, cfg.Search.AutocompleteRefresh)
+	geoSvc := services.NewGeoService(geographyRepo)
+	if err := geoSvc.Load(ctx); err != nil {
+		log.Error().Err(err).Msg("failed to load boundaries")
+	}
+	representativeSvc := services.NewRepresentativeService(representativeRepo, geoSvc)
+	resultStream := services.NewResultStream(resultsRepo)
+	pollSvc := services.NewPollService(pollRepo, electionRepo)
+	newsSourceSvc := services.NewNewsSourceService(newsRepo)
 
 	// Handlers
 	h := &handlers.Handlers{
-		Health:     handlers.NewHealthHandler(pool),
-		Politician: handlers.NewPoliticianHandler(politicianSvc),
-		Party:      handlers.NewPartyHandler(partyRepo),
-		Election:   handlers.NewElectionHandler(electionSvc),
-		News:       handlers.NewNewsHandler(newsRepo),
-		Geography:  handlers.NewGeographyHandler(geographyRepo),
-		Analytics:  handlers.NewAnalyticsHandler(analyticsSvc),
-		Timeline:   handlers.NewTimelineHandler(timelineSvc),
+		Health:         handlers.NewHealthHandler(pool),
+		Politician:     handlers.NewPoliticianHandler(politicianSvc),
+		Party:          handlers.NewPartyHandler(partyRepo),
+		Election:       handlers.NewElectionHandler(electionSvc),
+		News:           handlers.NewNewsHandler(newsRepo),
+		Geography:      handlers.NewGeographyHandler(geographyRepo, geoSvc),
+		Analytics:      handlers.NewAnalyticsHandler(analyticsSvc),
+		Timeline:       handlers.NewTimelineHandler(timelineSvc),
+		APIKey:         handlers.NewAPIKeyHandler(apiKeySvc),
+		Submission:     handlers.NewSubmissionHandler(submissionSvc, politicianSvc),
+		Search:         handlers.NewSearchHandler(searchRepo),
+		Autocomplete:   handlers.NewAutocompleteHandler(autocompleteSvc),
+		Representative: handlers.NewRepresentativeHandler(representativeSvc),
+		ResultStream:   handlers.NewResultStreamHandler(resultStream),
+		Poll:           handlers.NewPollHandler(pollSvc),
+		NewsSource:     handlers.NewNewsSourceHandler(newsSourceSvc),
+		Story:          handlers.NewStoryHandler(storyRepo),
 	}
 
-	router := handlers.NewRouter(h)
+	tiers := middleware.WithAnonymous(middleware.DefaultTiers, middleware.Tier{
+		Rate:       cfg.RateLimit.AnonymousRate,
+		Burst:      cfg.RateLimit.AnonymousBurst,
+		DailyQuota: cfg.RateLimit.AnonymousDailyQuota,
+	})
+	limiter := middleware.NewRateLimiter(apiKeyRepo, tiers)
+	go limiter.Start(ctx)
+
+	router := handlers.NewRouter(h, cfg, limiter)
 
-	newsScheduler := scraper.NewScheduler(newsRepo, cfg.Aggregation)
+	newsScheduler := scraper.NewScheduler(newsRepo, storyRepo, cfg.Aggregation)
 	go newsScheduler.Start(ctx)
+	go autocompleteSvc.Start(ctx)
+	go resultStream.Start(ctx)
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.Server.Port),
@@ -0,0 +1,42 @@
+// Package audit carries the attribution for a write through the request
+// context so the repository can hand it to the audit triggers.
+package audit
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type contextKey string
+
+const (
+	actorKey  contextKey = "audit_actor"
+	sourceKey contextKey = "audit_source"
+)
+
+// SystemActor is recorded for changes made outside an authenticated
+// request, such as seeding.
+const SystemActor = "system"
+
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// Actor returns the actor recorded for writes made with ctx.
+func Actor(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey).(string); ok && actor != "" {
+		return actor
+	}
+	return SystemActor
+}
+
+// WithSource records the sources.id that justifies writes made with ctx.
+func WithSource(ctx context.Context, sourceID uuid.UUID) context.Context {
+	return context.WithValue(ctx, sourceKey, sourceID)
+}
+
+func Source(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(sourceKey).(uuid.UUID)
+	return id, ok
+}
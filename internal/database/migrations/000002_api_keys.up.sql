@@ -0,0 +1,26 @@
+-- ============================================================
+-- API keys, tiers and daily usage
+-- ============================================================
+CREATE TABLE api_keys (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name            TEXT NOT NULL,
+    owner_email     TEXT,
+    key_prefix      TEXT NOT NULL,
+    key_hash        TEXT NOT NULL UNIQUE,
+    tier            TEXT NOT NULL DEFAULT 'registered' CHECK (tier IN ('registered','partner')),
+    rate_limit      DOUBLE PRECISION CHECK (rate_limit > 0),
+    burst           INT CHECK (burst > 0),
+    daily_quota     INT CHECK (daily_quota >= 0),
+    created_by      TEXT,
+    last_used_at    TIMESTAMPTZ,
+    revoked_at      TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE api_key_usage (
+    api_key_id  UUID NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    day         DATE NOT NULL,
+    requests    INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (api_key_id, day)
+);
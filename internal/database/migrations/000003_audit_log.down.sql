@@ -0,0 +1,14 @@
+DROP TRIGGER IF EXISTS trg_politicians_audit ON politicians;
+DROP TRIGGER IF EXISTS trg_party_memberships_audit ON party_memberships;
+DROP TRIGGER IF EXISTS trg_promises_audit ON promises;
+DROP TRIGGER IF EXISTS trg_court_cases_audit ON court_cases;
+DROP TRIGGER IF EXISTS trg_asset_declarations_audit ON asset_declarations;
+DROP TRIGGER IF EXISTS trg_integrity_flags_audit ON integrity_flags;
+DROP TRIGGER IF EXISTS trg_voting_records_audit ON voting_records;
+DROP TRIGGER IF EXISTS trg_achievements_audit ON achievements;
+DROP TRIGGER IF EXISTS trg_controversies_audit ON controversies;
+DROP TRIGGER IF EXISTS trg_affiliations_audit ON affiliations;
+DROP FUNCTION IF EXISTS audit_dossier_change();
+
+DROP TABLE IF EXISTS audit_log;
+DROP FUNCTION IF EXISTS audit_log_immutable();
@@ -0,0 +1,96 @@
+-- ============================================================
+-- Append-only audit log of dossier changes
+-- ============================================================
+CREATE TABLE audit_log (
+    id              BIGSERIAL PRIMARY KEY,
+    table_name      TEXT NOT NULL,
+    record_id       UUID NOT NULL,
+    politician_id   UUID,
+    action          TEXT NOT NULL CHECK (action IN ('insert','update','delete')),
+    actor           TEXT NOT NULL,
+    source_id       UUID,
+    changed_fields  TEXT[] NOT NULL DEFAULT '{}',
+    old_data        JSONB,
+    new_data        JSONB,
+    changed_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_politician ON audit_log(politician_id, changed_at DESC);
+CREATE INDEX idx_audit_log_record ON audit_log(table_name, record_id, changed_at DESC);
+
+-- The writer sets jalada.actor and jalada.source_id with set_config(..., true)
+-- in the same transaction. Rows that carry their own source_id fall back to it.
+CREATE OR REPLACE FUNCTION audit_dossier_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    v_old     JSONB;
+    v_new     JSONB;
+    v_row     JSONB;
+    v_changed TEXT[];
+    v_source  UUID;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        v_old := to_jsonb(OLD);
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        v_new := to_jsonb(NEW);
+    END IF;
+    v_row := COALESCE(v_new, v_old);
+
+    SELECT COALESCE(array_agg(k ORDER BY k), '{}') INTO v_changed
+    FROM (
+        SELECT jsonb_object_keys(v_row) AS k
+    ) keys
+    WHERE k NOT IN ('created_at', 'updated_at')
+      AND (v_old -> k) IS DISTINCT FROM (v_new -> k);
+
+    IF TG_OP = 'UPDATE' AND cardinality(v_changed) = 0 THEN
+        RETURN NULL;
+    END IF;
+
+    v_source := COALESCE(
+        NULLIF(current_setting('jalada.source_id', true), '')::UUID,
+        NULLIF(v_row ->> 'source_id', '')::UUID
+    );
+    IF v_source IS NOT NULL AND NOT EXISTS (SELECT 1 FROM sources WHERE id = v_source) THEN
+        RAISE EXCEPTION 'source % does not exist', v_source USING ERRCODE = 'foreign_key_violation';
+    END IF;
+
+    INSERT INTO audit_log (table_name, record_id, politician_id, action, actor, source_id,
+                           changed_fields, old_data, new_data)
+    VALUES (
+        TG_TABLE_NAME,
+        (v_row ->> 'id')::UUID,
+        CASE WHEN TG_TABLE_NAME = 'politicians' THEN (v_row ->> 'id')::UUID
+             ELSE (v_row ->> 'politician_id')::UUID END,
+        lower(TG_OP),
+        COALESCE(NULLIF(current_setting('jalada.actor', true), ''), 'system'),
+        v_source,
+        v_changed,
+        v_old,
+        v_new
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_politicians_audit AFTER INSERT OR UPDATE OR DELETE ON politicians FOR EACH ROW EXECUTE FUNCTION audit_dossier_change();
+CREATE TRIGGER trg_party_memberships_audit AFTER INSERT OR UPDATE OR DELETE ON party_memberships FOR EACH ROW EXECUTE FUNCTION audit_dossier_change();
+CREATE TRIGGER trg_promises_audit AFTER INSERT OR UPDATE OR DELETE ON promises FOR EACH ROW EXECUTE FUNCTION audit_dossier_change();
+CREATE TRIGGER trg_court_cases_audit AFTER INSERT OR UPDATE OR DELETE ON court_cases FOR EACH ROW EXECUTE FUNCTION audit_dossier_change();
+CREATE TRIGGER trg_asset_declarations_audit AFTER INSERT OR UPDATE OR DELETE ON asset_declarations FOR EACH ROW EXECUTE FUNCTION audit_dossier_change();
+CREATE TRIGGER trg_integrity_flags_audit AFTER INSERT OR UPDATE OR DELETE ON integrity_flags FOR EACH ROW EXECUTE FUNCTION audit_dossier_change();
+CREATE TRIGGER trg_voting_records_audit AFTER INSERT OR UPDATE OR DELETE ON voting_records FOR EACH ROW EXECUTE FUNCTION audit_dossier_change();
+CREATE TRIGGER trg_achievements_audit AFTER INSERT OR UPDATE OR DELETE ON achievements FOR EACH ROW EXECUTE FUNCTION audit_dossier_change();
+CREATE TRIGGER trg_controversies_audit AFTER INSERT OR UPDATE OR DELETE ON controversies FOR EACH ROW EXECUTE FUNCTION audit_dossier_change();
+CREATE TRIGGER trg_affiliations_audit AFTER INSERT OR UPDATE OR DELETE ON affiliations FOR EACH ROW EXECUTE FUNCTION audit_dossier_change();
+
+CREATE OR REPLACE FUNCTION audit_log_immutable()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_audit_log_immutable BEFORE UPDATE OR DELETE ON audit_log FOR EACH ROW EXECUTE FUNCTION audit_log_immutable();
+CREATE TRIGGER trg_audit_log_no_truncate BEFORE TRUNCATE ON audit_log FOR EACH STATEMENT EXECUTE FUNCTION audit_log_immutable();
@@ -0,0 +1,31 @@
+-- ============================================================
+-- Editorial review queue for proposed dossier edits
+-- ============================================================
+CREATE TABLE edit_submissions (
+    id                  UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    politician_id       UUID NOT NULL REFERENCES politicians(id) ON DELETE CASCADE,
+    collection          TEXT NOT NULL CHECK (collection IN ('politician','party-memberships','court-cases','promises','achievements','controversies','assets','integrity-flags')),
+    operation           TEXT NOT NULL CHECK (operation IN ('create','update','delete')),
+    record_id           UUID,
+    payload             JSONB NOT NULL DEFAULT '{}',
+    summary             TEXT NOT NULL,
+    evidence_url        TEXT NOT NULL,
+    evidence_name       TEXT,
+    evidence_type       TEXT NOT NULL DEFAULT 'other' CHECK (evidence_type IN ('iebc','eacc','gazette','hansard','news','social','court','other')),
+    submitter_name      TEXT,
+    submitter_email     TEXT,
+    status              TEXT NOT NULL DEFAULT 'submitted' CHECK (status IN ('submitted','under_review','approved','rejected')),
+    reviewer            TEXT,
+    review_comment      TEXT,
+    reviewed_at         TIMESTAMPTZ,
+    source_id           UUID REFERENCES sources(id) ON DELETE SET NULL,
+    applied_record_id   UUID,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT submission_record_required CHECK (operation = 'create' OR record_id IS NOT NULL)
+);
+
+CREATE INDEX idx_submissions_status ON edit_submissions(status, created_at);
+CREATE INDEX idx_submissions_politician ON edit_submissions(politician_id);
+
+CREATE TRIGGER trg_submissions_updated BEFORE UPDATE ON edit_submissions FOR EACH ROW EXECUTE FUNCTION update_updated_at();
@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS idx_politicians_fts;
+DROP INDEX IF EXISTS idx_parties_fts;
+DROP INDEX IF EXISTS idx_coalitions_fts;
+DROP INDEX IF EXISTS idx_counties_fts;
+DROP INDEX IF EXISTS idx_constituencies_fts;
+DROP INDEX IF EXISTS idx_articles_fts;
+DROP INDEX IF EXISTS idx_court_cases_fts;
+DROP INDEX IF EXISTS idx_promises_fts;
+
+ALTER TABLE politicians DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE political_parties DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE coalitions DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE counties DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE constituencies DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE news_articles DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE court_cases DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE promises DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,117 @@
+-- ============================================================
+-- Full-text search
+-- ============================================================
+-- Each searchable table gets a stored tsvector. Free text is indexed with
+-- both the english config (stemming) and the simple config, which keeps
+-- Swahili and other non-English words intact since Postgres ships no
+-- Swahili stemmer. Names and places are only indexed with simple.
+
+ALTER TABLE politicians ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', first_name || ' ' || last_name || ' ' || COALESCE(other_names, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(bio, '')), 'B') ||
+    setweight(to_tsvector('simple', COALESCE(bio, '')), 'C')
+) STORED;
+
+ALTER TABLE political_parties ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', name || ' ' || COALESCE(abbreviation, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(ideology, '')), 'B') ||
+    setweight(to_tsvector('simple', COALESCE(ideology, '')), 'C')
+) STORED;
+
+ALTER TABLE coalitions ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', name), 'A')
+) STORED;
+
+ALTER TABLE counties ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', name), 'A')
+) STORED;
+
+ALTER TABLE constituencies ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', name), 'A')
+) STORED;
+
+ALTER TABLE news_articles ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', title), 'A') ||
+    setweight(to_tsvector('simple', title), 'A') ||
+    setweight(to_tsvector('english', COALESCE(summary, '')), 'B') ||
+    setweight(to_tsvector('simple', COALESCE(summary, '')), 'C') ||
+    setweight(to_tsvector('english', COALESCE(content, '')), 'D')
+) STORED;
+
+ALTER TABLE court_cases ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', title), 'A') ||
+    setweight(to_tsvector('simple', title || ' ' || COALESCE(case_number, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(description, '') || ' ' || COALESCE(outcome, '')), 'B') ||
+    setweight(to_tsvector('simple', COALESCE(court_name, '')), 'C')
+) STORED;
+
+ALTER TABLE promises ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', description), 'A') ||
+    setweight(to_tsvector('simple', description), 'B') ||
+    setweight(to_tsvector('simple', COALESCE(sector, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(evidence, '')), 'C')
+) STORED;
+
+CREATE INDEX idx_politicians_fts ON politicians USING gin (search_vector);
+CREATE INDEX idx_parties_fts ON political_parties USING gin (search_vector);
+CREATE INDEX idx_coalitions_fts ON coalitions USING gin (search_vector);
+CREATE INDEX idx_counties_fts ON counties USING gin (search_vector);
+CREATE INDEX idx_constituencies_fts ON constituencies USING gin (search_vector);
+CREATE INDEX idx_articles_fts ON news_articles USING gin (search_vector);
+CREATE INDEX idx_court_cases_fts ON court_cases USING gin (search_vector);
+CREATE INDEX idx_promises_fts ON promises USING gin (search_vector);
+
+-- Keep the derived search column out of audit snapshots.
+CREATE OR REPLACE FUNCTION audit_dossier_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    v_old     JSONB;
+    v_new     JSONB;
+    v_row     JSONB;
+    v_changed TEXT[];
+    v_source  UUID;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        v_old := to_jsonb(OLD) - 'search_vector';
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        v_new := to_jsonb(NEW) - 'search_vector';
+    END IF;
+    v_row := COALESCE(v_new, v_old);
+
+    SELECT COALESCE(array_agg(k ORDER BY k), '{}') INTO v_changed
+    FROM (
+        SELECT jsonb_object_keys(v_row) AS k
+    ) keys
+    WHERE k NOT IN ('created_at', 'updated_at')
+      AND (v_old -> k) IS DISTINCT FROM (v_new -> k);
+
+    IF TG_OP = 'UPDATE' AND cardinality(v_changed) = 0 THEN
+        RETURN NULL;
+    END IF;
+
+    v_source := COALESCE(
+        NULLIF(current_setting('jalada.source_id', true), '')::UUID,
+        NULLIF(v_row ->> 'source_id', '')::UUID
+    );
+    IF v_source IS NOT NULL AND NOT EXISTS (SELECT 1 FROM sources WHERE id = v_source) THEN
+        RAISE EXCEPTION 'source % does not exist', v_source USING ERRCODE = 'foreign_key_violation';
+    END IF;
+
+    INSERT INTO audit_log (table_name, record_id, politician_id, action, actor, source_id,
+                           changed_fields, old_data, new_data)
+    VALUES (
+        TG_TABLE_NAME,
+        (v_row ->> 'id')::UUID,
+        CASE WHEN TG_TABLE_NAME = 'politicians' THEN (v_row ->> 'id')::UUID
+             ELSE (v_row ->> 'politician_id')::UUID END,
+        lower(TG_OP),
+        COALESCE(NULLIF(current_setting('jalada.actor', true), ''), 'system'),
+        v_source,
+        v_changed,
+        v_old,
+        v_new
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
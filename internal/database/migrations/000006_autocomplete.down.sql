@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_parties_name_trgm;
+DROP INDEX IF EXISTS idx_counties_name_trgm;
+DROP INDEX IF EXISTS idx_constituencies_name_trgm;
+DROP INDEX IF EXISTS idx_wards_name_trgm;
+DROP INDEX IF EXISTS idx_mentions_politician;
@@ -0,0 +1,6 @@
+-- Trigram indexes backing the autocomplete fallback query.
+CREATE INDEX idx_parties_name_trgm ON political_parties USING gin (name gin_trgm_ops);
+CREATE INDEX idx_counties_name_trgm ON counties USING gin (name gin_trgm_ops);
+CREATE INDEX idx_constituencies_name_trgm ON constituencies USING gin (name gin_trgm_ops);
+CREATE INDEX idx_wards_name_trgm ON wards USING gin (name gin_trgm_ops);
+CREATE INDEX idx_mentions_politician ON article_politician_mentions(politician_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_politicians_dob;
+DROP INDEX IF EXISTS idx_candidacies_elected;
+DROP VIEW IF EXISTS current_office_holders;
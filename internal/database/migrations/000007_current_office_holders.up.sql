@@ -0,0 +1,27 @@
+-- The sitting holder of each seat is the candidate most recently elected to
+-- it. Geography is resolved up the ward -> constituency -> county chain so
+-- every seat below national level has a county.
+CREATE VIEW current_office_holders AS
+SELECT DISTINCT ON (c.position_id)
+    c.politician_id,
+    c.position_id,
+    c.id AS candidacy_id,
+    c.election_id,
+    c.party_id,
+    ep.title,
+    ep.level,
+    ep.ward_id,
+    COALESCE(ep.constituency_id, w.constituency_id) AS constituency_id,
+    COALESCE(ep.county_id, cn.county_id, wcn.county_id) AS county_id,
+    e.election_date
+FROM candidacies c
+JOIN elections e ON e.id = c.election_id
+JOIN elective_positions ep ON ep.id = c.position_id
+LEFT JOIN constituencies cn ON cn.id = ep.constituency_id
+LEFT JOIN wards w ON w.id = ep.ward_id
+LEFT JOIN constituencies wcn ON wcn.id = w.constituency_id
+WHERE c.status = 'elected'
+ORDER BY c.position_id, e.election_date DESC NULLS LAST, c.updated_at DESC;
+
+CREATE INDEX idx_candidacies_elected ON candidacies(position_id) WHERE status = 'elected';
+CREATE INDEX idx_politicians_dob ON politicians(date_of_birth);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_polling_stations_location;
@@ -0,0 +1,4 @@
+-- Coordinate lookups prefilter polling stations on a lat/lng bounding box
+-- before computing great-circle distances.
+CREATE INDEX idx_polling_stations_location ON polling_stations(latitude, longitude)
+    WHERE latitude IS NOT NULL AND longitude IS NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE wards DROP COLUMN IF EXISTS boundary;
+ALTER TABLE constituencies DROP COLUMN IF EXISTS boundary;
+ALTER TABLE counties DROP COLUMN IF EXISTS boundary;
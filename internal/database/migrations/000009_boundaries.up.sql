@@ -0,0 +1,6 @@
+-- Boundary polygons are stored as GeoJSON Polygon or MultiPolygon
+-- geometries in WGS84. Point lookups run in the API process, so PostGIS is
+-- not required.
+ALTER TABLE counties ADD COLUMN boundary JSONB;
+ALTER TABLE constituencies ADD COLUMN boundary JSONB;
+ALTER TABLE wards ADD COLUMN boundary JSONB;
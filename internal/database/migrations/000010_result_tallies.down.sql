@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_results_tally;
+ALTER TABLE election_results DROP COLUMN IF EXISTS tally_id;
+DROP TABLE IF EXISTS result_tallies;
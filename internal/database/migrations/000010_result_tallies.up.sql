@@ -0,0 +1,43 @@
+-- ============================================================
+-- Results ingestion
+-- ============================================================
+-- A tally is one results form for one seat at one reporting area: Form 34A
+-- from a polling station, 34B from a constituency tallying centre, 34C for
+-- the national presidential result, and the 35-39 series for the other
+-- seats. Its per-candidate votes are rows in election_results.
+--
+-- Forms are often resubmitted with corrections. A resubmission supersedes
+-- the current tally for the same seat and area instead of overwriting it,
+-- so every version stays available to the reconciler.
+CREATE TABLE result_tallies (
+    id                  UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    election_id         UUID NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    position_id         UUID NOT NULL REFERENCES elective_positions(id) ON DELETE CASCADE,
+    level               TEXT NOT NULL CHECK (level IN ('station','ward','constituency','county','national')),
+    form                TEXT,
+    polling_station_id  UUID REFERENCES polling_stations(id) ON DELETE CASCADE,
+    ward_id             UUID REFERENCES wards(id) ON DELETE CASCADE,
+    constituency_id     UUID REFERENCES constituencies(id) ON DELETE CASCADE,
+    county_id           UUID REFERENCES counties(id) ON DELETE CASCADE,
+    registered_voters   INT NOT NULL CHECK (registered_voters >= 0),
+    rejected_votes      INT NOT NULL DEFAULT 0 CHECK (rejected_votes >= 0),
+    is_final            BOOLEAN NOT NULL DEFAULT false,
+    is_current          BOOLEAN NOT NULL DEFAULT true,
+    reported_by         TEXT NOT NULL,
+    source_id           UUID REFERENCES sources(id) ON DELETE SET NULL,
+    reported_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((level = 'station') = (polling_station_id IS NOT NULL)),
+    CHECK ((level = 'ward') = (ward_id IS NOT NULL)),
+    CHECK ((level = 'constituency') = (constituency_id IS NOT NULL)),
+    CHECK ((level = 'county') = (county_id IS NOT NULL))
+);
+
+CREATE UNIQUE INDEX idx_result_tallies_current
+    ON result_tallies(election_id, position_id, level, polling_station_id, ward_id, constituency_id, county_id)
+    NULLS NOT DISTINCT
+    WHERE is_current;
+CREATE INDEX idx_result_tallies_election ON result_tallies(election_id, level) WHERE is_current;
+CREATE INDEX idx_result_tallies_station ON result_tallies(polling_station_id);
+
+ALTER TABLE election_results ADD COLUMN tally_id UUID REFERENCES result_tallies(id) ON DELETE CASCADE;
+CREATE INDEX idx_results_tally ON election_results(tally_id);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trg_election_results_notify ON election_results;
+DROP FUNCTION IF EXISTS notify_election_result();
+DROP INDEX IF EXISTS idx_result_tallies_seq;
+ALTER TABLE result_tallies DROP COLUMN IF EXISTS seq;
@@ -0,0 +1,23 @@
+-- ============================================================
+-- Live results
+-- ============================================================
+-- seq orders tallies as they arrive so a stream client can resume from the
+-- last event it saw.
+ALTER TABLE result_tallies ADD COLUMN seq BIGINT GENERATED ALWAYS AS IDENTITY;
+CREATE INDEX idx_result_tallies_seq ON result_tallies(election_id, seq);
+
+-- Announce each tally once its votes are committed. Notifications with the
+-- same payload are folded within a transaction, so a tally's candidate rows
+-- produce a single notification.
+CREATE OR REPLACE FUNCTION notify_election_result()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.tally_id IS NOT NULL THEN
+        PERFORM pg_notify('election_results', NEW.tally_id::text);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_election_results_notify AFTER INSERT ON election_results
+    FOR EACH ROW EXECUTE FUNCTION notify_election_result();
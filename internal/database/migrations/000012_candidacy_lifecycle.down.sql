@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_candidacies_seat_politician;
+DROP INDEX IF EXISTS idx_candidacies_election_status;
+DROP TABLE IF EXISTS party_primaries;
+DROP TABLE IF EXISTS candidacy_events;
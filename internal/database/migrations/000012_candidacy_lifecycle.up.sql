@@ -0,0 +1,67 @@
+-- ============================================================
+-- Candidacy lifecycle
+-- ============================================================
+-- candidacies.status only holds where a candidacy stands now. Every change
+-- is also recorded here with its reason and source, so the path from
+-- declaration through party primaries and IEBC clearance to the result
+-- can be reported on afterwards.
+CREATE TABLE candidacy_events (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    candidacy_id    UUID NOT NULL REFERENCES candidacies(id) ON DELETE CASCADE,
+    stage           TEXT NOT NULL CHECK (stage IN ('declaration','party_primary','iebc_clearance','dispute','withdrawal','result')),
+    from_status     TEXT CHECK (from_status IN ('declared','cleared','disqualified','withdrew','elected','lost')),
+    to_status       TEXT NOT NULL CHECK (to_status IN ('declared','cleared','disqualified','withdrew','elected','lost')),
+    reason          TEXT,
+    source_url      TEXT,
+    source_id       UUID REFERENCES sources(id) ON DELETE SET NULL,
+    occurred_on     DATE NOT NULL,
+    recorded_by     TEXT NOT NULL,
+    recorded_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_candidacy_events_candidacy ON candidacy_events(candidacy_id, occurred_on, recorded_at);
+
+-- Party primaries run before candidacies are submitted to the IEBC, so an
+-- aspirant who loses has no candidacy for the party. Outcomes are kept per
+-- aspirant and linked to a candidacy through election, seat and politician.
+CREATE TABLE party_primaries (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    election_id     UUID NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    position_id     UUID NOT NULL REFERENCES elective_positions(id) ON DELETE CASCADE,
+    party_id        UUID NOT NULL REFERENCES political_parties(id) ON DELETE CASCADE,
+    politician_id   UUID NOT NULL REFERENCES politicians(id) ON DELETE CASCADE,
+    method          TEXT NOT NULL DEFAULT 'vote' CHECK (method IN ('vote','consensus','direct_nomination')),
+    outcome         TEXT NOT NULL CHECK (outcome IN ('won','lost','stepped_down','nullified')),
+    votes           INT CHECK (votes >= 0),
+    held_on         DATE,
+    notes           TEXT,
+    source_url      TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (election_id, position_id, party_id, politician_id)
+);
+
+CREATE INDEX idx_party_primaries_politician ON party_primaries(politician_id);
+
+CREATE TRIGGER trg_party_primaries_updated BEFORE UPDATE ON party_primaries FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+CREATE INDEX idx_candidacies_election_status ON candidacies(election_id, status);
+CREATE UNIQUE INDEX idx_candidacies_seat_politician ON candidacies(election_id, position_id, politician_id);
+
+-- Existing candidacies start their history with the declaration and, where
+-- they have moved on since, one event to their current status.
+INSERT INTO candidacy_events (candidacy_id, stage, to_status, occurred_on, recorded_by, recorded_at)
+SELECT id, 'declaration', 'declared', COALESCE(declaration_date, created_at::date), 'system', created_at
+FROM candidacies;
+
+INSERT INTO candidacy_events (candidacy_id, stage, from_status, to_status, occurred_on, recorded_by, recorded_at)
+SELECT id,
+       CASE status WHEN 'withdrew' THEN 'withdrawal'
+                   WHEN 'elected' THEN 'result'
+                   WHEN 'lost' THEN 'result'
+                   ELSE 'iebc_clearance' END,
+       'declared', status,
+       COALESCE(CASE WHEN status = 'cleared' THEN clearance_date END, updated_at::date),
+       'system', updated_at
+FROM candidacies
+WHERE status <> 'declared';
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS joint_tickets;
+
+DELETE FROM elective_positions WHERE title = 'deputy_governor';
+ALTER TABLE elective_positions DROP CONSTRAINT elective_positions_title_check;
+ALTER TABLE elective_positions ADD CONSTRAINT elective_positions_title_check
+    CHECK (title IN ('president','deputy_president','governor','senator','mp','woman_rep','mca'));
@@ -0,0 +1,34 @@
+-- ============================================================
+-- Joint tickets
+-- ============================================================
+-- Presidential and gubernatorial candidates stand with a running mate who
+-- is not on the ballot. A ticket pairs the principal's candidacy with the
+-- deputy's, and the ticket's votes are the principal's.
+ALTER TABLE elective_positions DROP CONSTRAINT elective_positions_title_check;
+ALTER TABLE elective_positions ADD CONSTRAINT elective_positions_title_check
+    CHECK (title IN ('president','deputy_president','governor','deputy_governor','senator','mp','woman_rep','mca'));
+
+INSERT INTO elective_positions (title, level, county_id)
+SELECT 'deputy_governor', 'county', g.county_id
+FROM elective_positions g
+WHERE g.title = 'governor'
+  AND NOT EXISTS (SELECT 1 FROM elective_positions d
+                  WHERE d.title = 'deputy_governor' AND d.county_id IS NOT DISTINCT FROM g.county_id);
+
+INSERT INTO elective_positions (title, level)
+SELECT 'deputy_president', 'national'
+WHERE EXISTS (SELECT 1 FROM elective_positions WHERE title = 'president')
+  AND NOT EXISTS (SELECT 1 FROM elective_positions WHERE title = 'deputy_president');
+
+CREATE TABLE joint_tickets (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    election_id             UUID NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    principal_candidacy_id  UUID NOT NULL UNIQUE REFERENCES candidacies(id) ON DELETE CASCADE,
+    deputy_candidacy_id     UUID NOT NULL UNIQUE REFERENCES candidacies(id) ON DELETE CASCADE,
+    nominated_on            DATE,
+    source_url              TEXT,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (principal_candidacy_id <> deputy_candidacy_id)
+);
+
+CREATE INDEX idx_joint_tickets_election ON joint_tickets(election_id);
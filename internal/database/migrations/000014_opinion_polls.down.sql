@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS poll_shares;
+DROP TABLE IF EXISTS polls;
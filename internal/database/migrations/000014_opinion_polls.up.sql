@@ -0,0 +1,47 @@
+-- ============================================================
+-- Opinion polls
+-- ============================================================
+-- A poll is one pollster's published release for one race: a seat for
+-- candidate polls, or national party preference. Shares are percentages
+-- of all respondents, so they and the undecided share sum to at most 100.
+CREATE TABLE polls (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    election_id     UUID NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    scope           TEXT NOT NULL CHECK (scope IN ('candidate','party')),
+    position_id     UUID REFERENCES elective_positions(id) ON DELETE CASCADE,
+    pollster        TEXT NOT NULL,
+    commissioned_by TEXT,
+    fieldwork_start DATE NOT NULL,
+    fieldwork_end   DATE NOT NULL,
+    published_on    DATE,
+    sample_size     INT NOT NULL CHECK (sample_size > 0),
+    margin_of_error NUMERIC(4,2) CHECK (margin_of_error > 0),
+    methodology     TEXT NOT NULL CHECK (methodology IN ('face_to_face','telephone','online','mixed')),
+    undecided       NUMERIC(5,2) CHECK (undecided BETWEEN 0 AND 100),
+    source_url      TEXT,
+    notes           TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (fieldwork_end >= fieldwork_start),
+    CHECK ((scope = 'candidate') = (position_id IS NOT NULL))
+);
+
+CREATE INDEX idx_polls_election ON polls(election_id, fieldwork_end DESC);
+CREATE INDEX idx_polls_position ON polls(position_id);
+
+CREATE TRIGGER trg_polls_updated BEFORE UPDATE ON polls FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+-- Candidate polls name politicians rather than candidacies, since most
+-- are published before nominations close.
+CREATE TABLE poll_shares (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    poll_id         UUID NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    politician_id   UUID REFERENCES politicians(id) ON DELETE CASCADE,
+    party_id        UUID REFERENCES political_parties(id) ON DELETE CASCADE,
+    share           NUMERIC(5,2) NOT NULL CHECK (share BETWEEN 0 AND 100),
+    CHECK ((politician_id IS NULL) <> (party_id IS NULL))
+);
+
+CREATE UNIQUE INDEX idx_poll_shares_politician ON poll_shares(poll_id, politician_id) WHERE politician_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_poll_shares_party ON poll_shares(poll_id, party_id) WHERE party_id IS NOT NULL;
+CREATE INDEX idx_poll_shares_politician_id ON poll_shares(politician_id);
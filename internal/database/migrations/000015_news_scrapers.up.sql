@@ -0,0 +1,24 @@
+-- ============================================================
+-- HTML scraper configuration
+-- ============================================================
+-- Sources of type 'scraper' have no feed. Their articles are found by
+-- following links on a listing page, and each field is read from the
+-- article page with a CSS selector. date_attribute reads the date from an
+-- attribute such as datetime instead of the element's text; date_layout
+-- is a Go time layout for dates the scraper cannot parse on its own.
+CREATE TABLE news_source_scrapers (
+    source_id       UUID PRIMARY KEY REFERENCES news_sources(id) ON DELETE CASCADE,
+    list_url        TEXT NOT NULL,
+    link_selector   TEXT NOT NULL,
+    title_selector  TEXT NOT NULL,
+    body_selector   TEXT NOT NULL,
+    date_selector   TEXT,
+    date_attribute  TEXT,
+    date_layout     TEXT,
+    author_selector TEXT,
+    max_articles    INT NOT NULL DEFAULT 20 CHECK (max_articles BETWEEN 1 AND 100),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER trg_news_source_scrapers_updated BEFORE UPDATE ON news_source_scrapers FOR EACH ROW EXECUTE FUNCTION update_updated_at();
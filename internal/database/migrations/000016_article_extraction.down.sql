@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_articles_canonical_url;
+ALTER TABLE news_articles DROP COLUMN IF EXISTS canonical_url;
+ALTER TABLE news_sources DROP COLUMN IF EXISTS extract_full_text;
@@ -0,0 +1,9 @@
+-- Most feeds only carry a teaser. Sources with extract_full_text have each
+-- new article page fetched and its main text extracted into content.
+ALTER TABLE news_sources ADD COLUMN extract_full_text BOOLEAN NOT NULL DEFAULT false;
+
+-- The page's canonical URL catches the same story reached through
+-- different feed links.
+ALTER TABLE news_articles ADD COLUMN canonical_url TEXT;
+
+CREATE INDEX idx_articles_canonical_url ON news_articles(canonical_url) WHERE canonical_url IS NOT NULL;
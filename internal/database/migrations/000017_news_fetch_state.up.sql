@@ -0,0 +1,15 @@
+-- ============================================================
+-- News source fetch state
+-- ============================================================
+-- What the aggregator remembers between cycles. etag and last_modified
+-- are the validators of the last feed or listing page response, sent back
+-- so an unchanged page costs a 304. A source that keeps failing is left
+-- alone until next_fetch_at, backing off exponentially.
+CREATE TABLE news_source_fetch_state (
+    source_id            UUID PRIMARY KEY REFERENCES news_sources(id) ON DELETE CASCADE,
+    etag                 TEXT,
+    last_modified        TEXT,
+    last_fetched_at      TIMESTAMPTZ NOT NULL,
+    consecutive_failures INT NOT NULL DEFAULT 0 CHECK (consecutive_failures >= 0),
+    next_fetch_at        TIMESTAMPTZ
+);
@@ -0,0 +1,7 @@
+ALTER TABLE news_source_fetch_state
+    DROP COLUMN IF EXISTS deactivated_at,
+    DROP COLUMN IF EXISTS last_error,
+    DROP COLUMN IF EXISTS last_status_code,
+    DROP COLUMN IF EXISTS last_success_at;
+
+DROP TABLE IF EXISTS news_fetch_log;
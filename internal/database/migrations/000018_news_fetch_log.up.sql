@@ -0,0 +1,29 @@
+-- ============================================================
+-- News fetch log and source health
+-- ============================================================
+-- One row per attempt to fetch a news source. status_code is the feed or
+-- listing page response (304 when unchanged) and is empty when no response
+-- came back. item_errors counts articles that could not be read or stored;
+-- error is set when the attempt failed as a whole.
+CREATE TABLE news_fetch_log (
+    id          BIGSERIAL PRIMARY KEY,
+    source_id   UUID NOT NULL REFERENCES news_sources(id) ON DELETE CASCADE,
+    started_at  TIMESTAMPTZ NOT NULL,
+    duration_ms INT NOT NULL CHECK (duration_ms >= 0),
+    status_code INT,
+    items_seen  INT NOT NULL DEFAULT 0,
+    new_items   INT NOT NULL DEFAULT 0,
+    item_errors INT NOT NULL DEFAULT 0,
+    error       TEXT
+);
+
+CREATE INDEX idx_news_fetch_log_source ON news_fetch_log(source_id, started_at DESC);
+CREATE INDEX idx_news_fetch_log_started ON news_fetch_log(started_at);
+
+-- deactivated_at is set when the aggregator switches a source off after
+-- too many failures in a row.
+ALTER TABLE news_source_fetch_state
+    ADD COLUMN last_success_at  TIMESTAMPTZ,
+    ADD COLUMN last_status_code INT,
+    ADD COLUMN last_error       TEXT,
+    ADD COLUMN deactivated_at   TIMESTAMPTZ;
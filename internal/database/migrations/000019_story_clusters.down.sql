@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_articles_story_time;
+DROP INDEX IF EXISTS idx_articles_unclustered;
+DROP INDEX IF EXISTS idx_articles_cluster;
+ALTER TABLE news_articles
+    DROP COLUMN IF EXISTS minhash,
+    DROP COLUMN IF EXISTS cluster_id;
+
+DROP TABLE IF EXISTS story_clusters;
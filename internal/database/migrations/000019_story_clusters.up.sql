@@ -0,0 +1,33 @@
+-- ============================================================
+-- Story clusters
+-- ============================================================
+-- Articles about the same story, from one outlet or several, share a
+-- cluster. The clusterer compares MinHash signatures of each article's
+-- title and lead, so syndicated copies and rewrites of the same event land
+-- together. The lead article is the earliest one, and its title is the
+-- cluster's headline.
+CREATE TABLE story_clusters (
+    id                  UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    lead_article_id     UUID REFERENCES news_articles(id) ON DELETE SET NULL,
+    headline            TEXT NOT NULL,
+    first_seen_at       TIMESTAMPTZ NOT NULL,
+    last_seen_at        TIMESTAMPTZ NOT NULL,
+    article_count       INT NOT NULL DEFAULT 1,
+    outlet_count        INT NOT NULL DEFAULT 1,
+    is_election_related BOOLEAN NOT NULL DEFAULT false,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_story_clusters_last_seen ON story_clusters(last_seen_at DESC);
+
+CREATE TRIGGER trg_story_clusters_updated BEFORE UPDATE ON story_clusters FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+-- Articles without a cluster are waiting for the clusterer.
+ALTER TABLE news_articles
+    ADD COLUMN cluster_id UUID REFERENCES story_clusters(id) ON DELETE SET NULL,
+    ADD COLUMN minhash    BIGINT[];
+
+CREATE INDEX idx_articles_cluster ON news_articles(cluster_id);
+CREATE INDEX idx_articles_unclustered ON news_articles(scraped_at) WHERE cluster_id IS NULL;
+CREATE INDEX idx_articles_story_time ON news_articles((COALESCE(published_at, scraped_at))) WHERE cluster_id IS NOT NULL;
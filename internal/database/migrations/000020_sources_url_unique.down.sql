@@ -0,0 +1,5 @@
+-- The merged duplicates come back, but rows that referenced them keep
+-- pointing at the kept copy; the audit log has their original source_id.
+DROP INDEX IF EXISTS idx_sources_url;
+INSERT INTO sources SELECT (m.source).* FROM source_merges m;
+DROP TABLE IF EXISTS source_merges;
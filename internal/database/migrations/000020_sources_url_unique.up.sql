@@ -0,0 +1,57 @@
+-- ============================================================
+-- One source per URL
+-- ============================================================
+-- Approving a submission finds or creates the source for its evidence
+-- URL. A unique URL lets that be a single INSERT ... ON CONFLICT, so two
+-- approvals citing the same URL cannot both create it. Duplicates already
+-- created are merged into the earliest copy first.
+--
+-- The merge repoints every single-column foreign key on sources at the
+-- kept copy. Those updates go through the audit triggers under the
+-- migration actor, so the audit log records each row's old source_id.
+-- The removed rows are kept in source_merges so the down migration can
+-- put them back.
+CREATE TABLE source_merges (
+    keep_id   UUID NOT NULL,
+    source    sources NOT NULL,
+    merged_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+DO $$
+DECLARE
+    fk RECORD;
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM pg_constraint c
+        WHERE c.contype = 'f' AND c.confrelid = 'sources'::regclass AND array_length(c.conkey, 1) <> 1
+    ) THEN
+        RAISE EXCEPTION 'sources has a multi-column foreign key; merge its duplicates by hand';
+    END IF;
+
+    PERFORM set_config('jalada.actor', 'migration:000020_sources_url_unique', true);
+
+    INSERT INTO source_merges (keep_id, source)
+    SELECT d.keep_id, s
+    FROM sources s
+    JOIN (
+        SELECT id, first_value(id) OVER (PARTITION BY url ORDER BY created_at, id) AS keep_id
+        FROM sources
+        WHERE url IS NOT NULL
+    ) d ON d.id = s.id
+    WHERE d.id <> d.keep_id;
+
+    FOR fk IN
+        SELECT c.conrelid::regclass AS tbl, a.attname AS col
+        FROM pg_constraint c
+        JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = c.conkey[1]
+        WHERE c.contype = 'f' AND c.confrelid = 'sources'::regclass
+          AND array_length(c.conkey, 1) = 1
+    LOOP
+        EXECUTE format('UPDATE %s t SET %I = m.keep_id FROM source_merges m WHERE t.%I = (m.source).id',
+                       fk.tbl, fk.col, fk.col);
+    END LOOP;
+
+    DELETE FROM sources s USING source_merges m WHERE s.id = (m.source).id;
+END $$;
+
+CREATE UNIQUE INDEX idx_sources_url ON sources(url);
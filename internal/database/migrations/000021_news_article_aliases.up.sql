@@ -0,0 +1,10 @@
+-- A feed link whose page turns out to be an article already stored under
+-- another URL is kept as an alias, so the page is not downloaded again on
+-- every cycle just to find that out.
+CREATE TABLE news_article_aliases (
+    url        TEXT PRIMARY KEY,
+    article_id UUID NOT NULL REFERENCES news_articles(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_news_article_aliases_article ON news_article_aliases(article_id);
@@ -0,0 +1,22 @@
+package geo
+
+import "math"
+
+const earthRadiusKM = 6371.0
+
+// BoundsAround returns a box containing every point within radiusKM of p,
+// for prefiltering before exact distances are computed. Longitude degrees
+// shrink away from the equator, so the box widens with latitude.
+func BoundsAround(p Point, radiusKM float64) Bounds {
+	dLat := radiusKM / (earthRadiusKM * math.Pi / 180)
+	cos := math.Cos(radians(p.Lat))
+	dLng := 180.0
+	if cos > 1e-6 {
+		dLng = math.Min(dLat/cos, 180)
+	}
+	return Bounds{MinLat: p.Lat - dLat, MaxLat: p.Lat + dLat, MinLng: p.Lng - dLng, MaxLng: p.Lng + dLng}
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
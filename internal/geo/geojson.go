@@ -0,0 +1,90 @@
+// Package geo stores electoral boundaries as GeoJSON and resolves
+// coordinates to the areas containing them without PostGIS.
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Geometry is a GeoJSON geometry object. Coordinates are kept raw so the
+// original document can be stored and served unchanged.
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+type Feature struct {
+	Type       string                 `json:"type"`
+	Properties map[string]interface{} `json:"properties"`
+	Geometry   json.RawMessage        `json:"geometry"`
+}
+
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// ParseGeometry decodes a Polygon or MultiPolygon geometry. Other geometry
+// types cannot bound an area and are rejected.
+func ParseGeometry(raw json.RawMessage) (MultiPolygon, error) {
+	var g Geometry
+	if err := json.Unmarshal(raw, &g); err != nil {
+		return nil, fmt.Errorf("parse geometry: %w", err)
+	}
+
+	switch g.Type {
+	case "Polygon":
+		var coords [][][]float64
+		if err := json.Unmarshal(g.Coordinates, &coords); err != nil {
+			return nil, fmt.Errorf("parse polygon: %w", err)
+		}
+		p, err := toPolygon(coords)
+		if err != nil {
+			return nil, err
+		}
+		return MultiPolygon{p}, nil
+	case "MultiPolygon":
+		var coords [][][][]float64
+		if err := json.Unmarshal(g.Coordinates, &coords); err != nil {
+			return nil, fmt.Errorf("parse multipolygon: %w", err)
+		}
+		mp := make(MultiPolygon, 0, len(coords))
+		for _, c := range coords {
+			p, err := toPolygon(c)
+			if err != nil {
+				return nil, err
+			}
+			mp = append(mp, p)
+		}
+		if len(mp) == 0 {
+			return nil, fmt.Errorf("multipolygon has no polygons")
+		}
+		return mp, nil
+	default:
+		return nil, fmt.Errorf("unsupported geometry type %q", g.Type)
+	}
+}
+
+// toPolygon converts GeoJSON [lng, lat] rings. The first ring is the outer
+// boundary and any others are holes.
+func toPolygon(coords [][][]float64) (Polygon, error) {
+	if len(coords) == 0 {
+		return nil, fmt.Errorf("polygon has no rings")
+	}
+	p := make(Polygon, 0, len(coords))
+	for _, ring := range coords {
+		if len(ring) < 4 {
+			return nil, fmt.Errorf("polygon ring has %d positions, need at least 4", len(ring))
+		}
+		r := make(Ring, len(ring))
+		for i, pos := range ring {
+			if len(pos) < 2 {
+				return nil, fmt.Errorf("position has %d coordinates, need 2", len(pos))
+			}
+			r[i] = Point{Lng: pos[0], Lat: pos[1]}
+		}
+		p = append(p, r)
+	}
+	return p, nil
+}
@@ -0,0 +1,64 @@
+package geo
+
+import "math"
+
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+type Ring []Point
+
+// Polygon is an outer ring followed by zero or more holes.
+type Polygon []Ring
+
+type MultiPolygon []Polygon
+
+type Bounds struct {
+	MinLat, MinLng, MaxLat, MaxLng float64
+}
+
+func (b Bounds) Contains(p Point) bool {
+	return p.Lat >= b.MinLat && p.Lat <= b.MaxLat && p.Lng >= b.MinLng && p.Lng <= b.MaxLng
+}
+
+// Bounds returns the bounding box of the outer rings.
+func (mp MultiPolygon) Bounds() Bounds {
+	b := Bounds{MinLat: math.Inf(1), MinLng: math.Inf(1), MaxLat: math.Inf(-1), MaxLng: math.Inf(-1)}
+	for _, poly := range mp {
+		for _, pt := range poly[0] {
+			b.MinLat = math.Min(b.MinLat, pt.Lat)
+			b.MaxLat = math.Max(b.MaxLat, pt.Lat)
+			b.MinLng = math.Min(b.MinLng, pt.Lng)
+			b.MaxLng = math.Max(b.MaxLng, pt.Lng)
+		}
+	}
+	return b
+}
+
+func (mp MultiPolygon) Contains(p Point) bool {
+	for _, poly := range mp {
+		if poly.Contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether p is inside the polygon by ray casting across
+// every ring, so points inside a hole cross an even number of edges and
+// fall outside. Points exactly on an edge may land on either side.
+func (poly Polygon) Contains(p Point) bool {
+	inside := false
+	for _, ring := range poly {
+		n := len(ring)
+		for i, j := 0, n-1; i < n; j, i = i, i+1 {
+			a, b := ring[i], ring[j]
+			if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+				p.Lng < (b.Lng-a.Lng)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
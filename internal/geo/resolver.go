@@ -0,0 +1,112 @@
+package geo
+
+import "github.com/google/uuid"
+
+const (
+	LevelCounty       = "county"
+	LevelConstituency = "constituency"
+	LevelWard         = "ward"
+)
+
+// Area is a county, constituency or ward with its boundary. ParentCode is
+// the code of the constituency for a ward and of the county for a
+// constituency.
+type Area struct {
+	Level      string
+	ID         uuid.UUID
+	Code       string
+	Name       string
+	ParentCode string
+	Shape      MultiPolygon
+
+	bounds Bounds
+	parent *Area
+}
+
+func (a *Area) Parent() *Area {
+	return a.parent
+}
+
+// Match is the chain of areas containing a point. Levels without loaded
+// boundaries, or that the point falls outside of, are nil.
+type Match struct {
+	County       *Area
+	Constituency *Area
+	Ward         *Area
+}
+
+// Resolver maps coordinates to areas by scanning bounding boxes and then
+// testing the few candidate polygons. It is immutable once built.
+type Resolver struct {
+	counties       []*Area
+	constituencies []*Area
+	wards          []*Area
+}
+
+// NewResolver links each area to its parent by code and precomputes
+// bounding boxes.
+func NewResolver(counties, constituencies, wards []Area) *Resolver {
+	r := &Resolver{
+		counties:       prepare(counties),
+		constituencies: prepare(constituencies),
+		wards:          prepare(wards),
+	}
+	link(r.constituencies, r.counties)
+	link(r.wards, r.constituencies)
+	return r
+}
+
+func prepare(areas []Area) []*Area {
+	out := make([]*Area, len(areas))
+	for i := range areas {
+		a := areas[i]
+		a.bounds = a.Shape.Bounds()
+		out[i] = &a
+	}
+	return out
+}
+
+func link(children, parents []*Area) {
+	byCode := make(map[string]*Area, len(parents))
+	for _, p := range parents {
+		byCode[p.Code] = p
+	}
+	for _, c := range children {
+		c.parent = byCode[c.ParentCode]
+	}
+}
+
+// Size returns the number of areas loaded at each level.
+func (r *Resolver) Size() (counties, constituencies, wards int) {
+	return len(r.counties), len(r.constituencies), len(r.wards)
+}
+
+// Locate returns the areas containing p. The finest level found decides
+// the chain, so a ward match always reports its own constituency and
+// county even where coarser boundaries overlap slightly.
+func (r *Resolver) Locate(p Point) Match {
+	var m Match
+	if w := find(r.wards, p); w != nil {
+		m.Ward = w
+		m.Constituency = w.parent
+	}
+	if m.Constituency == nil {
+		m.Constituency = find(r.constituencies, p)
+	}
+	if m.Constituency != nil {
+		m.County = m.Constituency.parent
+	}
+	if m.County == nil {
+		m.County = find(r.counties, p)
+	}
+	return m
+}
+
+func find(areas []*Area, p Point) *Area {
+	for _, a := range areas {
+		if a.bounds.Contains(p) && a.Shape.Contains(p) {
+			return a
+		}
+	}
+	return nil
+}
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
+	"jalada/internal/models"
 	"jalada/internal/services"
 )
 
@@ -59,3 +61,42 @@ func (h *AnalyticsHandler) Trending(w http.ResponseWriter, r *http.Request) {
 	}
 	writeJSON(w, http.StatusOK, data)
 }
+
+// Swing compares a seat's results between two elections per constituency
+// or county.
+func (h *AnalyticsHandler) Swing(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	from, err := parseUUID(q.Get("from"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "from must be an election id")
+		return
+	}
+	to, err := parseUUID(q.Get("to"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "to must be an election id")
+		return
+	}
+
+	report, err := h.svc.Swing(r.Context(), models.SwingFilter{
+		From:    from,
+		To:      to,
+		Title:   q.Get("position"),
+		GroupBy: q.Get("group_by"),
+		Level:   q.Get("level"),
+		County:  q.Get("county"),
+	})
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to compute swing")
+		return
+	}
+	if report == nil {
+		writeError(w, http.StatusNotFound, "election not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
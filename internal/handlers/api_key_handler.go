@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+
+	"jalada/internal/middleware"
+	"jalada/internal/models"
+	"jalada/internal/services"
+)
+
+type APIKeyHandler struct {
+	svc *services.APIKeyService
+}
+
+func NewAPIKeyHandler(svc *services.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{svc: svc}
+}
+
+type issueAPIKeyRequest struct {
+	Name       string   `json:"name"`
+	OwnerEmail *string  `json:"owner_email"`
+	Tier       string   `json:"tier"`
+	RateLimit  *float64 `json:"rate_limit"`
+	Burst      *int     `json:"burst"`
+	DailyQuota *int     `json:"daily_quota"`
+}
+
+func (h *APIKeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.svc.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list api keys")
+		return
+	}
+	if keys == nil {
+		keys = []models.APIKey{}
+	}
+	writeJSON(w, http.StatusOK, keys)
+}
+
+func (h *APIKeyHandler) Issue(w http.ResponseWriter, r *http.Request) {
+	var req issueAPIKeyRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	k := models.APIKey{
+		Name:       req.Name,
+		OwnerEmail: req.OwnerEmail,
+		Tier:       req.Tier,
+		RateLimit:  req.RateLimit,
+		Burst:      req.Burst,
+		DailyQuota: req.DailyQuota,
+	}
+	if editor := middleware.Editor(r.Context()); editor != "" {
+		k.CreatedBy = &editor
+	}
+
+	issued, err := h.svc.Issue(r.Context(), &k)
+	if err != nil {
+		writeWriteError(w, err, "api key")
+		return
+	}
+	writeJSON(w, http.StatusCreated, issued)
+}
+
+func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseRecordID(w, r, "api key")
+	if !ok {
+		return
+	}
+	if err := h.svc.Revoke(r.Context(), id); err != nil {
+		writeWriteError(w, err, "api key")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"jalada/internal/models"
+	"jalada/internal/services"
+)
+
+func (h *PoliticianHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	limit, offset := parsePagination(r)
+
+	revisions, total, err := h.svc.GetHistory(r.Context(), id, r.URL.Query().Get("collection"), limit, offset)
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get history")
+		return
+	}
+	if revisions == nil {
+		revisions = []models.Revision{}
+	}
+	writeJSON(w, http.StatusOK, models.NewPaginatedResponse(revisions, total, limit, offset))
+}
+
+// Revisions serves the revision list of a single record in collection. The
+// politician record itself uses the "politician" collection and needs no id.
+func (h *PoliticianHandler) Revisions(collection string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		politicianID, ok := h.resolvePoliticianID(w, r)
+		if !ok {
+			return
+		}
+		recordID := politicianID
+		if collection != "politician" {
+			if recordID, ok = parseRecordID(w, r, "record"); !ok {
+				return
+			}
+		}
+
+		revisions, err := h.svc.GetRevisions(r.Context(), politicianID, collection, recordID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to get revisions")
+			return
+		}
+		if revisions == nil {
+			revisions = []models.Revision{}
+		}
+		writeJSON(w, http.StatusOK, revisions)
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"jalada/internal/models"
+	"jalada/internal/repository"
+	"jalada/internal/services"
+)
+
+type AutocompleteHandler struct {
+	svc *services.AutocompleteService
+}
+
+func NewAutocompleteHandler(svc *services.AutocompleteService) *AutocompleteHandler {
+	return &AutocompleteHandler{svc: svc}
+}
+
+func (h *AutocompleteHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	term := strings.TrimSpace(q.Get("q"))
+	if term == "" {
+		writeError(w, http.StatusBadRequest, "q is required")
+		return
+	}
+	if len(term) > 100 {
+		writeError(w, http.StatusBadRequest, "q must be at most 100 characters")
+		return
+	}
+
+	limit := 10
+	if v := q.Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= 25 {
+			limit = n
+		}
+	}
+
+	types, ok := parseTypes(q.Get("types"), repository.AutocompleteTypes)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "types must be a comma-separated list of "+strings.Join(repository.AutocompleteTypes, ", "))
+		return
+	}
+
+	suggestions, err := h.svc.Suggest(r.Context(), term, types, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get suggestions")
+		return
+	}
+	if suggestions == nil {
+		suggestions = []models.Suggestion{}
+	}
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	writeJSON(w, http.StatusOK, suggestions)
+}
@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"jalada/internal/models"
+	"jalada/internal/services"
+)
+
+// CreateCandidacy declares a candidacy for a seat in the election.
+func (h *ElectionHandler) CreateCandidacy(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+
+	var c models.Candidacy
+	if !decodeJSON(w, r, &c) {
+		return
+	}
+	c.ElectionID = id
+	if err := h.svc.CreateCandidacy(r.Context(), &c); err != nil {
+		writeWriteError(w, err, "election")
+		return
+	}
+	writeJSON(w, http.StatusCreated, c)
+}
+
+// RecordCandidacyEvent moves a candidacy to a new status, such as cleared
+// or disqualified by the IEBC, with the reason and source.
+func (h *ElectionHandler) RecordCandidacyEvent(w http.ResponseWriter, r *http.Request) {
+	id, cid, ok := parseCandidacyPath(w, r)
+	if !ok {
+		return
+	}
+
+	var ev models.CandidacyEvent
+	if !decodeJSON(w, r, &ev) {
+		return
+	}
+	ev.CandidacyID = cid
+	if err := h.svc.RecordCandidacyEvent(r.Context(), id, &ev); err != nil {
+		writeWriteError(w, err, "candidacy")
+		return
+	}
+	writeJSON(w, http.StatusCreated, ev)
+}
+
+// GetCandidacyTimeline returns a candidacy's party primaries and status
+// history.
+func (h *ElectionHandler) GetCandidacyTimeline(w http.ResponseWriter, r *http.Request) {
+	id, cid, ok := parseCandidacyPath(w, r)
+	if !ok {
+		return
+	}
+
+	timeline, err := h.svc.CandidacyTimeline(r.Context(), id, cid)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get candidacy timeline")
+		return
+	}
+	if timeline == nil {
+		writeError(w, http.StatusNotFound, "candidacy not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, timeline)
+}
+
+func parseCandidacyPath(w http.ResponseWriter, r *http.Request) (electionID, candidacyID uuid.UUID, ok bool) {
+	electionID, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return uuid.Nil, uuid.Nil, false
+	}
+	candidacyID, err = parseUUID(chi.URLParam(r, "candidacy_id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid candidacy id")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return electionID, candidacyID, true
+}
+
+// ListPrimaries lists party primary outcomes in the election.
+func (h *ElectionHandler) ListPrimaries(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+
+	q := r.URL.Query()
+	f := models.PrimaryFilter{
+		Outcomes: queryList(r, "outcome"),
+		Title:    q.Get("position"),
+		Party:    q.Get("party"),
+		County:   q.Get("county"),
+	}
+	primaries, err := h.svc.ListPrimaries(r.Context(), id, f)
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list party primaries")
+		return
+	}
+	if primaries == nil {
+		primaries = []models.PartyPrimary{}
+	}
+	writeJSON(w, http.StatusOK, primaries)
+}
+
+func (h *ElectionHandler) CreatePrimary(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+
+	var pr models.PartyPrimary
+	if !decodeJSON(w, r, &pr) {
+		return
+	}
+	pr.ElectionID = id
+	if err := h.svc.CreatePrimary(r.Context(), &pr); err != nil {
+		writeWriteError(w, err, "election")
+		return
+	}
+	h.writePrimary(w, r, id, pr.ID, http.StatusCreated)
+}
+
+func (h *ElectionHandler) UpdatePrimary(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+	pid, err := parseUUID(chi.URLParam(r, "primary_id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid party primary id")
+		return
+	}
+	existing, err := h.svc.GetPrimary(r.Context(), id, pid)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get party primary")
+		return
+	}
+	if existing == nil {
+		writeError(w, http.StatusNotFound, "party primary not found")
+		return
+	}
+
+	pr := updateTarget(r, existing)
+	if !decodeJSON(w, r, pr) {
+		return
+	}
+	pr.ID, pr.ElectionID = pid, id
+	if err := h.svc.UpdatePrimary(r.Context(), pr); err != nil {
+		writeWriteError(w, err, "party primary")
+		return
+	}
+	h.writePrimary(w, r, id, pid, http.StatusOK)
+}
+
+// writePrimary responds with a saved primary read back with its party,
+// seat and aspirant names.
+func (h *ElectionHandler) writePrimary(w http.ResponseWriter, r *http.Request, electionID, id uuid.UUID, status int) {
+	pr, err := h.svc.GetPrimary(r.Context(), electionID, id)
+	if err != nil || pr == nil {
+		writeError(w, http.StatusInternalServerError, "failed to get party primary")
+		return
+	}
+	writeJSON(w, status, pr)
+}
+
+// ListTickets lists presidential and gubernatorial joint tickets.
+func (h *ElectionHandler) ListTickets(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+
+	q := r.URL.Query()
+	tickets, err := h.svc.ListTickets(r.Context(), id, q.Get("position"), q.Get("county"))
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list joint tickets")
+		return
+	}
+	if tickets == nil {
+		tickets = []models.JointTicket{}
+	}
+	writeJSON(w, http.StatusOK, tickets)
+}
+
+// CreateTicket pairs a principal's candidacy with a running mate's.
+func (h *ElectionHandler) CreateTicket(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+
+	var t models.JointTicket
+	if !decodeJSON(w, r, &t) {
+		return
+	}
+	t.ElectionID = id
+	if err := h.svc.CreateTicket(r.Context(), &t); err != nil {
+		writeWriteError(w, err, "joint ticket")
+		return
+	}
+	ticket, err := h.svc.GetTicket(r.Context(), id, t.ID)
+	if err != nil || ticket == nil {
+		writeError(w, http.StatusInternalServerError, "failed to get joint ticket")
+		return
+	}
+	writeJSON(w, http.StatusCreated, ticket)
+}
+
+func (h *ElectionHandler) DeleteTicket(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+	tid, err := parseUUID(chi.URLParam(r, "ticket_id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid joint ticket id")
+		return
+	}
+	if err := h.svc.DeleteTicket(r.Context(), id, tid); err != nil {
+		writeWriteError(w, err, "joint ticket")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 
 	"jalada/internal/models"
 	"jalada/internal/services"
@@ -55,7 +57,19 @@ func (h *ElectionHandler) GetCandidates(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	candidates, err := h.svc.GetCandidates(r.Context(), id)
+	q := r.URL.Query()
+	f := models.CandidateFilter{
+		Statuses: queryList(r, "status"),
+		Title:    q.Get("position"),
+		Party:    q.Get("party"),
+		County:   q.Get("county"),
+	}
+	candidates, err := h.svc.GetCandidates(r.Context(), id, f)
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to get candidates")
 		return
@@ -73,6 +87,11 @@ func (h *ElectionHandler) GetResults(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if level := r.URL.Query().Get("level"); level != "" {
+		h.getAreaResults(w, r, id, level)
+		return
+	}
+
 	results, err := h.svc.GetResults(r.Context(), id)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to get results")
@@ -84,6 +103,180 @@ func (h *ElectionHandler) GetResults(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, results)
 }
 
+// getAreaResults serves results aggregated over one reporting area, chosen
+// by level and code.
+func (h *ElectionHandler) getAreaResults(w http.ResponseWriter, r *http.Request, id uuid.UUID, level string) {
+	q := r.URL.Query()
+	f := models.ResultFilter{Level: level, Code: q.Get("code"), Title: q.Get("position")}
+	if v := q.Get("position_id"); v != "" {
+		pid, err := parseUUID(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid position_id")
+			return
+		}
+		f.PositionID = &pid
+	}
+
+	results, err := h.svc.GetAreaResults(r.Context(), id, f)
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get results")
+		return
+	}
+	if results == nil {
+		writeError(w, http.StatusNotFound, level+" not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+// SubmitTally records a results form (34A, 34B, 34C and equivalents) for
+// one seat at one reporting area.
+func (h *ElectionHandler) SubmitTally(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+
+	var t models.ResultTally
+	if !decodeJSON(w, r, &t) {
+		return
+	}
+	t.ElectionID = id
+	if err := h.svc.SubmitTally(r.Context(), &t); err != nil {
+		writeWriteError(w, err, "election")
+		return
+	}
+	writeJSON(w, http.StatusCreated, t)
+}
+
+// GetAnomalies lists discrepancies between the election's result tallies.
+func (h *ElectionHandler) GetAnomalies(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+
+	f := models.AnomalyFilter{
+		Types:      queryList(r, "type"),
+		Severities: queryList(r, "severity"),
+		Title:      r.URL.Query().Get("position"),
+	}
+	anomalies, err := h.svc.Anomalies(r.Context(), id, f)
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to reconcile results")
+		return
+	}
+	writeJSON(w, http.StatusOK, anomalies)
+}
+
+// GetPresidentialStatus evaluates the constitutional thresholds for a
+// first-round presidential win against the results counted so far.
+func (h *ElectionHandler) GetPresidentialStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+
+	status, err := h.svc.PresidentialStatus(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to evaluate presidential status")
+		return
+	}
+	if status == nil {
+		writeError(w, http.StatusNotFound, "election not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// ListSeats lists each seat's result with its winner and margin.
+func (h *ElectionHandler) ListSeats(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+
+	q := r.URL.Query()
+	seats, err := h.svc.Seats(r.Context(), id, models.SeatFilter{
+		Title:       q.Get("position"),
+		County:      q.Get("county"),
+		WinnerParty: q.Get("winner_party"),
+	})
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get seat results")
+		return
+	}
+	writeJSON(w, http.StatusOK, seats)
+}
+
+func (h *ElectionHandler) GetSeat(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+	positionID, err := parseUUID(chi.URLParam(r, "position_id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid position id")
+		return
+	}
+
+	seat, err := h.svc.Seat(r.Context(), id, positionID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get seat result")
+		return
+	}
+	if seat == nil {
+		writeError(w, http.StatusNotFound, "seat not contested in this election")
+		return
+	}
+	writeJSON(w, http.StatusOK, seat)
+}
+
+// GetSeatSummary counts seats won and led by each party per seat title.
+func (h *ElectionHandler) GetSeatSummary(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+
+	q := r.URL.Query()
+	summary, err := h.svc.SeatSummary(r.Context(), id, models.SeatFilter{
+		Title:  q.Get("position"),
+		County: q.Get("county"),
+	})
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to summarise seats")
+		return
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
 func (h *ElectionHandler) GetTimeline(w http.ResponseWriter, r *http.Request) {
 	id, err := parseUUID(chi.URLParam(r, "id"))
 	if err != nil {
@@ -1,20 +1,26 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
 
 	"jalada/internal/models"
 	"jalada/internal/repository"
+	"jalada/internal/services"
 )
 
 type GeographyHandler struct {
 	repo *repository.GeographyRepo
+	geo  *services.GeoService
 }
 
-func NewGeographyHandler(repo *repository.GeographyRepo) *GeographyHandler {
-	return &GeographyHandler{repo: repo}
+func NewGeographyHandler(repo *repository.GeographyRepo, geoSvc *services.GeoService) *GeographyHandler {
+	return &GeographyHandler{repo: repo, geo: geoSvc}
 }
 
 func (h *GeographyHandler) ListCounties(w http.ResponseWriter, r *http.Request) {
@@ -108,3 +114,108 @@ func (h *GeographyHandler) GetCandidates(w http.ResponseWriter, r *http.Request)
 	}
 	writeJSON(w, http.StatusOK, candidates)
 }
+
+func (h *GeographyHandler) GetWard(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	ward, err := h.repo.GetWardByCode(r.Context(), code)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get ward")
+		return
+	}
+	if ward == nil {
+		writeError(w, http.StatusNotFound, "ward not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, ward)
+}
+
+// Boundary returns a handler serving the GeoJSON boundary of the county,
+// constituency or ward named by the code URL parameter.
+func (h *GeographyHandler) Boundary(level string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		code := chi.URLParam(r, "code")
+		feature, err := h.repo.GetBoundary(r.Context(), level, code)
+		if err != nil {
+			log.Error().Err(err).Str("level", level).Str("code", code).Msg("failed to get boundary")
+			writeError(w, http.StatusInternalServerError, "failed to get boundary")
+			return
+		}
+		if feature == nil {
+			writeError(w, http.StatusNotFound, level+" not found")
+			return
+		}
+		if len(feature.Geometry) == 0 || string(feature.Geometry) == "null" {
+			writeError(w, http.StatusNotFound, "no boundary loaded for this "+level)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/geo+json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(feature); err != nil {
+			log.Error().Err(err).Msg("failed to encode boundary")
+		}
+	}
+}
+
+func (h *GeographyHandler) Locate(w http.ResponseWriter, r *http.Request) {
+	lat, ok := queryFloat(w, r, "lat")
+	if !ok {
+		return
+	}
+	lng, ok := queryFloat(w, r, "lng")
+	if !ok {
+		return
+	}
+
+	match, err := h.geo.Resolve(lat, lng)
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to locate point")
+		return
+	}
+	writeJSON(w, http.StatusOK, match)
+}
+
+func (h *GeographyHandler) NearbyPollingStations(w http.ResponseWriter, r *http.Request) {
+	lat, ok := queryFloat(w, r, "lat")
+	if !ok {
+		return
+	}
+	lng, ok := queryFloat(w, r, "lng")
+	if !ok {
+		return
+	}
+	radius, ok := queryFloat(w, r, "radius_km")
+	if !ok {
+		return
+	}
+	radiusKM := 5.0
+	if radius != nil {
+		radiusKM = *radius
+	}
+	limit := 20
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= 100 {
+			limit = n
+		}
+	}
+
+	stations, err := h.geo.NearbyPollingStations(r.Context(), lat, lng, radiusKM, limit)
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to find polling stations")
+		return
+	}
+	if stations == nil {
+		stations = []models.NearbyPollingStation{}
+	}
+	writeJSON(w, http.StatusOK, stations)
+}
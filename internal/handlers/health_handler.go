@@ -61,16 +61,47 @@ func endpointList() []map[string]interface{} {
 			"description": "Service health check",
 			"response":    "HealthResponse",
 		},
+		// --- Search ---
+		{
+			"path":        "/v1/search",
+			"method":      "GET",
+			"description": "Full-text search across politicians, parties, coalitions, counties, constituencies, news articles, court cases and promises. English words are stemmed; Swahili and other words match as written. Results are grouped by type, best group first, with <mark>-highlighted snippets",
+			"parameters": []map[string]interface{}{
+				{"name": "q", "in": "query", "type": "string", "required": true, "description": "Search terms. Supports \"quoted phrases\", OR and -exclusion"},
+				{"name": "types", "in": "query", "type": "string", "description": "Comma-separated subset of politician, party, coalition, county, constituency, article, court_case, promise"},
+				{"name": "limit", "in": "query", "type": "integer", "default": 5, "description": "Results per type (max 50)"},
+			},
+			"response": "SearchResponse",
+		},
+		{
+			"path":        "/v1/autocomplete",
+			"method":      "GET",
+			"description": "Typeahead suggestions for names and places, served from an in-memory prefix index. Ranked by similarity, then popularity (article mentions, party members, registered voters). Falls back to trigram matching for misspellings",
+			"parameters": []map[string]interface{}{
+				{"name": "q", "in": "query", "type": "string", "required": true, "description": "Partial name, e.g. Kalon or Kisumu E"},
+				{"name": "types", "in": "query", "type": "string", "description": "Comma-separated subset of politician, party, constituency, county, ward"},
+				{"name": "limit", "in": "query", "type": "integer", "default": 10, "description": "Max 25"},
+			},
+			"response": "Suggestion[]",
+		},
 		// --- Politicians ---
 		{
 			"path":        "/v1/politicians",
 			"method":      "GET",
-			"description": "List all politicians with optional search and filtering",
+			"description": "List all politicians with optional search and filtering. Array parameters accept repeated or comma-separated values and match any of them",
 			"parameters": []map[string]interface{}{
 				{"name": "q", "in": "query", "type": "string", "description": "Search by name"},
-				{"name": "party_id", "in": "query", "type": "uuid", "description": "Filter by party UUID"},
-				{"name": "county_id", "in": "query", "type": "uuid", "description": "Filter by county UUID"},
-				{"name": "position", "in": "query", "type": "string", "description": "Filter by position type"},
+				{"name": "party_id", "in": "query", "type": "uuid[]", "description": "Filter by current party UUID"},
+				{"name": "coalition", "in": "query", "type": "string[]", "description": "Filter by coalition slug of the current party"},
+				{"name": "position", "in": "query", "type": "string[]", "description": "Filter by current elected office: president, deputy_president, governor, deputy_governor, senator, mp, woman_rep, mca"},
+				{"name": "county_id", "in": "query", "type": "uuid[]", "description": "Filter by county UUID of the current office"},
+				{"name": "county", "in": "query", "type": "string[]", "description": "Filter by county code of the current office"},
+				{"name": "constituency", "in": "query", "type": "string[]", "description": "Filter by constituency code of the current office"},
+				{"name": "gender", "in": "query", "type": "string[]", "description": "male | female | other"},
+				{"name": "status", "in": "query", "type": "string[]", "description": "active | deceased | retired | inactive"},
+				{"name": "min_age", "in": "query", "type": "integer", "description": "Minimum age in years"},
+				{"name": "max_age", "in": "query", "type": "integer", "description": "Maximum age in years"},
+				{"name": "sort", "in": "query", "type": "string", "default": "name", "description": "name | -name | age (youngest first) | -age | recent | mentions"},
 				{"name": "limit", "in": "query", "type": "integer", "default": 20, "description": "Results per page"},
 				{"name": "offset", "in": "query", "type": "integer", "default": 0, "description": "Pagination offset"},
 			},
@@ -156,6 +187,60 @@ func endpointList() []map[string]interface{} {
 			"description": "Events and rallies associated with this politician",
 			"response":    "Event[]",
 		},
+		{
+			"path":        "/v1/politicians/{slug}/history",
+			"method":      "GET",
+			"description": "Audit trail of every change to this politician's dossier, newest first",
+			"parameters": []map[string]interface{}{
+				{"name": "collection", "in": "query", "type": "string", "description": "Restrict to one collection: politician, party-memberships, court-cases, promises, achievements, controversies, assets, integrity-flags, voting-record, affiliations"},
+				{"name": "limit", "in": "query", "type": "integer", "default": 20},
+				{"name": "offset", "in": "query", "type": "integer", "default": 0},
+			},
+			"response": "PaginatedResponse<Revision>",
+		},
+		{
+			"path":        "/v1/politicians/{slug}/revisions",
+			"method":      "GET",
+			"description": "Revisions of the politician record itself, oldest first",
+			"response":    "Revision[]",
+		},
+		{
+			"path":        "/v1/politicians/{slug}/{collection}/{id}/revisions",
+			"method":      "GET",
+			"description": "Revisions of one dossier record, oldest first",
+			"response":    "Revision[]",
+		},
+		// --- Dossier writes (require an editor bearer token) ---
+		{
+			"path":        "/v1/politicians",
+			"method":      "POST",
+			"auth":        "editor",
+			"description": "Create a politician. The slug is derived from the name when omitted",
+			"body":        "Politician",
+			"response":    "Politician",
+		},
+		{
+			"path":        "/v1/politicians/{slug}",
+			"method":      "PUT | PATCH | DELETE",
+			"auth":        "editor",
+			"description": "Replace, partially update or delete a politician",
+			"body":        "Politician",
+			"response":    "Politician",
+		},
+		{
+			"path":        "/v1/politicians/{slug}/{collection}",
+			"method":      "POST",
+			"auth":        "editor",
+			"description": "Add a record to a dossier collection: party-memberships, court-cases, promises, achievements, controversies, assets, integrity-flags",
+			"response":    "the created record",
+		},
+		{
+			"path":        "/v1/politicians/{slug}/{collection}/{id}",
+			"method":      "PUT | PATCH | DELETE",
+			"auth":        "editor",
+			"description": "Replace, partially update or delete a dossier record. Enum fields are validated against the database CHECK constraints",
+			"response":    "the updated record",
+		},
 		// --- Parties ---
 		{
 			"path":        "/v1/parties",
@@ -201,14 +286,166 @@ func endpointList() []map[string]interface{} {
 		{
 			"path":        "/v1/elections/{id}/candidates",
 			"method":      "GET",
-			"description": "Candidates registered for this election",
-			"response":    "Candidacy[]",
+			"description": "Candidates registered for this election, each with the reason for its current status, e.g. everyone disqualified by the IEBC and why",
+			"parameters": []map[string]interface{}{
+				{"name": "status", "in": "query", "type": "string", "description": "Comma-separated: declared, cleared, disqualified, withdrew, elected, lost"},
+				{"name": "position", "in": "query", "type": "string", "description": "Seat title, e.g. governor"},
+				{"name": "party", "in": "query", "type": "string", "description": "Party slug or abbreviation"},
+				{"name": "county", "in": "query", "type": "string", "description": "County code"},
+			},
+			"response": "Candidacy[]",
+		},
+		{
+			"path":        "/v1/elections/{id}/candidates",
+			"method":      "POST",
+			"auth":        "editor",
+			"description": "Declare a candidacy. It starts as declared; later changes are recorded as events",
+			"body":        "Candidacy",
+			"response":    "Candidacy",
+		},
+		{
+			"path":        "/v1/elections/{id}/candidates/{candidacy_id}/timeline",
+			"method":      "GET",
+			"description": "A candidacy's party primaries and every change of status with its reason and source, oldest first",
+			"response":    "CandidacyTimeline",
+		},
+		{
+			"path":        "/v1/elections/{id}/candidates/{candidacy_id}/events",
+			"method":      "POST",
+			"auth":        "editor",
+			"description": "Move a candidacy to a new status, e.g. cleared or disqualified at IEBC clearance, with the reason and source",
+			"body":        "CandidacyEvent",
+			"response":    "CandidacyEvent",
+		},
+		{
+			"path":        "/v1/elections/{id}/tickets",
+			"method":      "GET",
+			"description": "Presidential and gubernatorial joint tickets with both running mates",
+			"parameters": []map[string]interface{}{
+				{"name": "position", "in": "query", "type": "string", "description": "president or governor"},
+				{"name": "county", "in": "query", "type": "string", "description": "County code"},
+			},
+			"response": "JointTicket[]",
+		},
+		{
+			"path":        "/v1/elections/{id}/tickets",
+			"method":      "POST",
+			"auth":        "editor",
+			"description": "Pair a presidential or gubernatorial candidacy with its running mate's. Both must stand for the same party and, for governors, the same county",
+			"body":        "JointTicket",
+			"response":    "JointTicket",
+		},
+		{
+			"path":        "/v1/elections/{id}/tickets/{ticket_id}",
+			"method":      "DELETE",
+			"auth":        "editor",
+			"description": "Dissolve a joint ticket, e.g. when a running mate is replaced",
+		},
+		{
+			"path":        "/v1/elections/{id}/primaries",
+			"method":      "GET",
+			"description": "Party primary outcomes for every aspirant, winners first within each seat and party",
+			"parameters": []map[string]interface{}{
+				{"name": "outcome", "in": "query", "type": "string", "description": "Comma-separated: won, lost, stepped_down, nullified"},
+				{"name": "position", "in": "query", "type": "string", "description": "Seat title"},
+				{"name": "party", "in": "query", "type": "string", "description": "Party slug or abbreviation"},
+				{"name": "county", "in": "query", "type": "string", "description": "County code"},
+			},
+			"response": "PartyPrimary[]",
+		},
+		{
+			"path":        "/v1/elections/{id}/primaries",
+			"method":      "POST",
+			"auth":        "editor",
+			"description": "Record an aspirant's party primary outcome",
+			"body":        "PartyPrimary",
+			"response":    "PartyPrimary",
+		},
+		{
+			"path":        "/v1/elections/{id}/primaries/{primary_id}",
+			"method":      "PUT | PATCH",
+			"auth":        "editor",
+			"description": "Correct a party primary outcome, e.g. when a nomination is nullified",
+			"body":        "PartyPrimary",
+			"response":    "PartyPrimary",
 		},
 		{
 			"path":        "/v1/elections/{id}/results",
 			"method":      "GET",
-			"description": "Election results by constituency",
-			"response":    "ElectionResult[]",
+			"description": "Election results. Without level, per-candidate totals from current station tallies with each candidate's share of their own seat; with level, each seat's result over one reporting area, summed from Form A station tallies or taken from the tally filed for the area",
+			"parameters": []map[string]interface{}{
+				{"name": "level", "in": "query", "type": "string", "description": "station | ward | constituency | county | national"},
+				{"name": "code", "in": "query", "type": "string", "description": "Area code; required unless level is national"},
+				{"name": "position", "in": "query", "type": "string", "description": "Seat title, e.g. president or governor"},
+				{"name": "position_id", "in": "query", "type": "uuid"},
+			},
+			"response": "ResultSummary[] | AreaResults",
+		},
+		{
+			"path":        "/v1/elections/{id}/results/stream",
+			"method":      "GET",
+			"description": "Server-Sent Events stream of result tallies as they are committed. Each tally event replaces earlier ones for the same seat and area. Resume with the Last-Event-ID header; a reset event means too much was missed and results should be reloaded",
+			"parameters": []map[string]interface{}{
+				{"name": "position", "in": "query", "type": "string", "description": "Seat title, e.g. president"},
+				{"name": "position_id", "in": "query", "type": "uuid"},
+				{"name": "county", "in": "query", "type": "string", "description": "County code; matches tallies in the county"},
+				{"name": "constituency", "in": "query", "type": "string", "description": "Constituency code"},
+				{"name": "ward", "in": "query", "type": "string", "description": "Ward code"},
+				{"name": "last_event_id", "in": "query", "type": "integer", "description": "Alternative to the Last-Event-ID header"},
+			},
+			"response": "text/event-stream of ResultUpdate",
+		},
+		{
+			"path":        "/v1/elections/{id}/tallies",
+			"method":      "POST",
+			"auth":        "editor",
+			"description": "Submit a results form for one seat at one reporting area. A resubmission for the same seat and area supersedes the previous tally, which is kept",
+			"body":        "ResultTally",
+			"response":    "ResultTally",
+		},
+		{
+			"path":        "/v1/elections/{id}/anomalies",
+			"method":      "GET",
+			"description": "Discrepancies found by reconciling result tallies: aggregate forms that disagree with their station sums, turnout above 100% and stations resubmitted with different numbers. Most severe first",
+			"parameters": []map[string]interface{}{
+				{"name": "type", "in": "query", "type": "string", "description": "Comma-separated: aggregate_mismatch, turnout_over_100, conflicting_resubmission"},
+				{"name": "severity", "in": "query", "type": "string", "description": "Comma-separated: low, medium, high, critical"},
+				{"name": "position", "in": "query", "type": "string", "description": "Seat title, e.g. president"},
+			},
+			"response": "Anomaly[]",
+		},
+		{
+			"path":        "/v1/elections/{id}/presidential-status",
+			"method":      "GET",
+			"description": "Evaluates Article 138(4) against the presidential results counted so far: more than 50% of valid votes and at least 25% in at least 24 counties. Reports each candidate's progress and whether a run-off is triggered",
+			"response":    "PresidentialStatus",
+		},
+		{
+			"path":        "/v1/elections/{id}/seats",
+			"method":      "GET",
+			"description": "Each contested seat with its winner, runner-up, margin and every candidate's share within the seat, e.g. all governor races or all MP seats won by a party",
+			"parameters": []map[string]interface{}{
+				{"name": "position", "in": "query", "type": "string", "description": "Seat title, e.g. governor or mp"},
+				{"name": "county", "in": "query", "type": "string", "description": "County code"},
+				{"name": "winner_party", "in": "query", "type": "string", "description": "Party slug or abbreviation of the winner or leader"},
+			},
+			"response": "SeatResult[]",
+		},
+		{
+			"path":        "/v1/elections/{id}/seats/summary",
+			"method":      "GET",
+			"description": "Seats won and led by each party for every seat title",
+			"parameters": []map[string]interface{}{
+				{"name": "position", "in": "query", "type": "string", "description": "Seat title"},
+				{"name": "county", "in": "query", "type": "string", "description": "County code"},
+			},
+			"response": "SeatSummary[]",
+		},
+		{
+			"path":        "/v1/elections/{id}/seats/{position_id}",
+			"method":      "GET",
+			"description": "One seat's result",
+			"response":    "SeatResult",
 		},
 		{
 			"path":        "/v1/elections/{id}/timeline",
@@ -216,6 +453,58 @@ func endpointList() []map[string]interface{} {
 			"description": "Election timeline milestones (nominations, campaigns, voting, results)",
 			"response":    "TimelineMilestone[]",
 		},
+		{
+			"path":        "/v1/elections/{id}/polls",
+			"method":      "GET",
+			"description": "Published opinion polls for the election, newest fieldwork first",
+			"parameters": []map[string]interface{}{
+				{"name": "scope", "in": "query", "type": "string", "description": "candidate or party"},
+				{"name": "position", "in": "query", "type": "string", "description": "Seat title of candidate polls"},
+				{"name": "county", "in": "query", "type": "string", "description": "County code of the polled seat"},
+				{"name": "position_id", "in": "query", "type": "uuid", "description": "One polled seat"},
+				{"name": "pollster", "in": "query", "type": "string", "description": "Pollster name, e.g. TIFA"},
+				{"name": "from", "in": "query", "type": "date", "description": "Fieldwork ending on or after"},
+				{"name": "to", "in": "query", "type": "date", "description": "Fieldwork ending on or before"},
+			},
+			"response": "Poll[]",
+		},
+		{
+			"path":        "/v1/elections/{id}/polls/average",
+			"method":      "GET",
+			"description": "Poll of polls for one race: recency- and sample-weighted shares with 95% confidence bands",
+			"parameters": []map[string]interface{}{
+				{"name": "scope", "in": "query", "type": "string", "description": "candidate (default) or party"},
+				{"name": "position", "in": "query", "type": "string", "description": "Seat title (default: president)"},
+				{"name": "county", "in": "query", "type": "string", "description": "County code for county seats"},
+				{"name": "position_id", "in": "query", "type": "uuid", "description": "One polled seat"},
+				{"name": "as_of", "in": "query", "type": "date", "description": "Average as of this date (default: today)"},
+				{"name": "half_life", "in": "query", "type": "integer", "description": "Days for a poll's weight to halve (default: 14)"},
+				{"name": "window", "in": "query", "type": "integer", "description": "Days of polls to include before as_of (default: 90)"},
+			},
+			"response": "PollAverage",
+		},
+		{
+			"path":        "/v1/elections/{id}/polls/{poll_id}",
+			"method":      "GET",
+			"description": "One poll release with its shares",
+			"response":    "Poll",
+		},
+		{
+			"path":        "/v1/elections/{id}/polls",
+			"method":      "POST",
+			"auth":        "editor",
+			"description": "Record a published poll with each politician's or party's share",
+			"body":        "Poll",
+			"response":    "Poll",
+		},
+		{
+			"path":        "/v1/elections/{id}/polls/{poll_id}",
+			"method":      "PUT | PATCH | DELETE",
+			"auth":        "editor",
+			"description": "Correct or remove a poll. Shares in the body replace all of the poll's shares",
+			"body":        "Poll",
+			"response":    "Poll",
+		},
 		// --- Geography ---
 		{
 			"path":        "/v1/counties",
@@ -235,6 +524,12 @@ func endpointList() []map[string]interface{} {
 			"description": "List constituencies within a county",
 			"response":    "Constituency[]",
 		},
+		{
+			"path":        "/v1/counties/{code}/boundary",
+			"method":      "GET",
+			"description": "County boundary as a GeoJSON Feature (application/geo+json). 404 if no boundary is loaded",
+			"response":    "BoundaryFeature",
+		},
 		{
 			"path":        "/v1/constituencies/{code}",
 			"method":      "GET",
@@ -259,6 +554,58 @@ func endpointList() []map[string]interface{} {
 			"description": "IEBC polling stations in this constituency",
 			"response":    "PollingStation[]",
 		},
+		{
+			"path":        "/v1/constituencies/{code}/boundary",
+			"method":      "GET",
+			"description": "Constituency boundary as a GeoJSON Feature (application/geo+json). 404 if no boundary is loaded",
+			"response":    "BoundaryFeature",
+		},
+		{
+			"path":        "/v1/wards/{code}",
+			"method":      "GET",
+			"description": "Ward detail by IEBC ward code",
+			"response":    "Ward",
+		},
+		{
+			"path":        "/v1/wards/{code}/boundary",
+			"method":      "GET",
+			"description": "Ward boundary as a GeoJSON Feature (application/geo+json). 404 if no boundary is loaded",
+			"response":    "BoundaryFeature",
+		},
+		{
+			"path":        "/v1/polling-stations/nearby",
+			"method":      "GET",
+			"description": "Geolocated polling stations nearest a coordinate by great-circle distance, with ward, constituency and county",
+			"parameters": []map[string]interface{}{
+				{"name": "lat", "in": "query", "type": "number", "required": true},
+				{"name": "lng", "in": "query", "type": "number", "required": true},
+				{"name": "radius_km", "in": "query", "type": "number", "default": 5, "description": "Search radius, max 50"},
+				{"name": "limit", "in": "query", "type": "integer", "default": 20, "description": "Max 100"},
+			},
+			"response": "NearbyPollingStation[]",
+		},
+		{
+			"path":        "/v1/locate",
+			"method":      "GET",
+			"description": "Point-in-polygon lookup of the ward, constituency and county containing a coordinate, against the loaded boundaries",
+			"parameters": []map[string]interface{}{
+				{"name": "lat", "in": "query", "type": "number", "required": true},
+				{"name": "lng", "in": "query", "type": "number", "required": true},
+			},
+			"response": "BoundaryMatch",
+		},
+		{
+			"path":        "/v1/representatives",
+			"method":      "GET",
+			"description": "Who represents me: resolves a location to its ward, constituency and county and returns every seat covering it (MCA, MP, Woman Rep, Senator, Governor, President) with the sitting holder and the candidates declared for the upcoming general election. Give exactly one of lat and lng, polling_station or ward; coordinates resolve by ward boundary, or to the nearest geolocated polling station where boundaries are not loaded",
+			"parameters": []map[string]interface{}{
+				{"name": "lat", "in": "query", "type": "number", "description": "Latitude, with lng"},
+				{"name": "lng", "in": "query", "type": "number", "description": "Longitude, with lat"},
+				{"name": "polling_station", "in": "query", "type": "string", "description": "IEBC polling station code"},
+				{"name": "ward", "in": "query", "type": "string", "description": "IEBC ward code"},
+			},
+			"response": "Representatives",
+		},
 		// --- News ---
 		{
 			"path":        "/v1/news",
@@ -278,12 +625,41 @@ func endpointList() []map[string]interface{} {
 			"description": "Single news article detail",
 			"response":    "NewsArticle",
 		},
+		{
+			"path":        "/v1/news/stories",
+			"method":      "GET",
+			"description": "Stories covered by one or more outlets, with near-duplicate articles grouped into one cluster",
+			"parameters": []map[string]interface{}{
+				{"name": "since", "in": "query", "type": "date", "description": "Only stories with an article on or after this date (YYYY-MM-DD)"},
+				{"name": "election_related", "in": "query", "type": "boolean", "description": "Filter to election-related stories only"},
+				{"name": "min_outlets", "in": "query", "type": "integer", "description": "Only stories covered by at least this many outlets"},
+				{"name": "sort", "in": "query", "type": "string", "description": "recent (default, latest article first) or coverage (most outlets first)"},
+				{"name": "limit", "in": "query", "type": "integer", "default": 20},
+				{"name": "offset", "in": "query", "type": "integer", "default": 0},
+			},
+			"response": "PaginatedResponse<StoryCluster>",
+		},
+		{
+			"path":        "/v1/news/stories/{id}",
+			"method":      "GET",
+			"description": "Single story cluster with every article and outlet that covered it",
+			"response":    "StoryCluster",
+		},
 		{
 			"path":        "/v1/sources",
 			"method":      "GET",
 			"description": "Official data sources used by Jalada (IEBC, EACC, Hansard, etc.)",
 			"response":    "Source[]",
 		},
+		{
+			"path":        "/v1/sources/status",
+			"method":      "GET",
+			"description": "Health of each news source: healthy, stale (no new articles lately), broken (failing or switched off for failing) or inactive",
+			"parameters": []map[string]interface{}{
+				{"name": "window", "in": "query", "type": "integer", "description": "Hours a working source may go without new articles before it is stale, 1-720 (default: 24)"},
+			},
+			"response":    "NewsSourceStatusReport",
+		},
 		// --- Analytics ---
 		{
 			"path":        "/v1/analytics/trending",
@@ -315,6 +691,20 @@ func endpointList() []map[string]interface{} {
 			"description": "Parliamentary attendance rankings",
 			"response":    "AttendanceStats",
 		},
+		{
+			"path":        "/v1/analytics/swing",
+			"method":      "GET",
+			"description": "Compares a seat's results between two elections per constituency or county: each party's or coalition's vote-share swing, the turnout change and the change in winning margin. Coalitions are resolved from membership on each election day",
+			"parameters": []map[string]interface{}{
+				{"name": "from", "in": "query", "type": "uuid", "required": true, "description": "Earlier election id"},
+				{"name": "to", "in": "query", "type": "uuid", "required": true, "description": "Later election id"},
+				{"name": "position", "in": "query", "type": "string", "default": "president", "description": "Seat title"},
+				{"name": "group_by", "in": "query", "type": "string", "default": "party", "description": "party | coalition"},
+				{"name": "level", "in": "query", "type": "string", "default": "constituency", "description": "constituency | county"},
+				{"name": "county", "in": "query", "type": "string", "description": "Limit to one county code"},
+			},
+			"response": "SwingReport",
+		},
 		// --- Timeline & Events ---
 		{
 			"path":        "/v1/timeline",
@@ -333,6 +723,95 @@ func endpointList() []map[string]interface{} {
 			},
 			"response": "PaginatedResponse<Event>",
 		},
+		// --- Editorial review queue ---
+		{
+			"path":        "/v1/politicians/{slug}/submissions",
+			"method":      "POST",
+			"description": "Propose a change to a dossier record. It is queued for review and only applied once an editor approves it",
+			"body":        "EditSubmission (collection, operation, record_id, payload, summary, evidence_url, evidence_name, evidence_type, submitter_name, submitter_email)",
+			"response":    "EditSubmission",
+		},
+		{
+			"path":        "/v1/submissions",
+			"method":      "GET",
+			"auth":        "editor",
+			"description": "Review queue, oldest first",
+			"parameters": []map[string]interface{}{
+				{"name": "status", "in": "query", "type": "string", "description": "submitted | under_review | approved | rejected"},
+				{"name": "limit", "in": "query", "type": "integer", "default": 20},
+				{"name": "offset", "in": "query", "type": "integer", "default": 0},
+			},
+			"response": "PaginatedResponse<EditSubmission>",
+		},
+		{
+			"path":        "/v1/submissions/{id}",
+			"method":      "GET",
+			"auth":        "editor",
+			"description": "A single submission",
+			"response":    "EditSubmission",
+		},
+		{
+			"path":        "/v1/submissions/{id}/review | approve | reject",
+			"method":      "POST",
+			"auth":        "editor",
+			"description": "Claim a submission for review, approve it or reject it. Body: {\"comment\": \"...\"}, required when rejecting. Approval records the evidence in sources and applies the change",
+			"response":    "EditSubmission",
+		},
+		// --- Admin (require an editor bearer token) ---
+		{
+			"path":        "/v1/admin/api-keys",
+			"method":      "GET",
+			"auth":        "editor",
+			"description": "List API keys with today's usage. Plaintext keys are never returned",
+			"response":    "APIKey[]",
+		},
+		{
+			"path":        "/v1/admin/api-keys",
+			"method":      "POST",
+			"auth":        "editor",
+			"description": "Issue an API key. rate_limit, burst and daily_quota override the tier defaults. The key is only shown in this response",
+			"body":        "APIKey",
+			"response":    "APIKey & {key}",
+		},
+		{
+			"path":        "/v1/admin/api-keys/{id}",
+			"method":      "DELETE",
+			"auth":        "editor",
+			"description": "Revoke an API key",
+		},
+		{
+			"path":        "/v1/admin/news-sources",
+			"method":      "GET",
+			"auth":        "editor",
+			"description": "List the news sources the aggregator reads, with each scraper source's selectors and fetch state",
+			"response":    "NewsSource[]",
+		},
+		{
+			"path":        "/v1/admin/news-sources/{id}",
+			"method":      "PATCH",
+			"auth":        "editor",
+			"description": "Switch a source on or off, or toggle full-text extraction of its articles. Switching a source on clears its failures",
+			"body":        "NewsSource (active, extract_full_text)",
+			"response":    "NewsSource",
+		},
+		{
+			"path":        "/v1/admin/news-sources/{id}/fetches",
+			"method":      "GET",
+			"auth":        "editor",
+			"description": "A source's most recent fetch attempts, newest first. Attempts are kept for 30 days",
+			"parameters": []map[string]interface{}{
+				{"name": "limit", "in": "query", "type": "integer", "description": "Attempts to return, 1-200 (default: 50)"},
+			},
+			"response":    "FetchAttempt[]",
+		},
+		{
+			"path":        "/v1/admin/news-sources/{id}/scraper",
+			"method":      "PUT",
+			"auth":        "editor",
+			"description": "Set a source's CSS selectors for the HTML scraper and switch it to the scraper type",
+			"body":        "ScraperConfig",
+			"response":    "NewsSource",
+		},
 	}
 }
 
@@ -347,7 +826,8 @@ func schemaDefinitions() map[string]interface{} {
 				"last_name":     "string",
 				"status":        "string  - active | deceased | retired | inactive",
 				"photo_url":     "string | null",
-				"current_party": "string | null  - party name",
+				"current_party":  "string | null  - party name",
+				"current_office": "string | null  - seat currently held, e.g. governor, Nairobi",
 			},
 		},
 		"PoliticianDossier": map[string]interface{}{
@@ -434,6 +914,323 @@ func schemaDefinitions() map[string]interface{} {
 				"status":         "string  - upcoming | current | completed",
 			},
 		},
+		"Candidacy": map[string]interface{}{
+			"description": "A politician standing for a seat in an election",
+			"fields": map[string]string{
+				"id":                "uuid",
+				"politician_id":     "uuid",
+				"election_id":       "uuid",
+				"position_id":       "uuid",
+				"party_id":          "uuid | null",
+				"status":            "string  - declared | cleared | disqualified | withdrew | elected | lost",
+				"declaration_date":  "date | null",
+				"clearance_date":    "date | null  - set when the IEBC clears the candidacy",
+				"politician_name":   "string",
+				"politician_slug":   "string",
+				"party_name":        "string | null",
+				"position_title":    "string",
+				"election_name":     "string",
+				"status_reason":     "string | null  - from the latest status event",
+				"status_changed_on": "date | null",
+				"running_mate":      "RunningMate | null",
+			},
+		},
+		"RunningMate": map[string]interface{}{
+			"description": "The other half of a joint ticket",
+			"fields": map[string]string{
+				"candidacy_id":  "uuid",
+				"politician_id": "uuid",
+				"slug":          "string",
+				"name":          "string",
+				"status":        "string",
+				"role":          "string  - principal | deputy; the running mate's own role",
+			},
+		},
+		"JointTicket": map[string]interface{}{
+			"description": "A presidential or gubernatorial candidacy paired with its running mate",
+			"fields": map[string]string{
+				"id":                     "uuid",
+				"election_id":            "uuid",
+				"principal_candidacy_id": "uuid",
+				"deputy_candidacy_id":    "uuid",
+				"title":                  "string  - president | governor",
+				"area":                   "object | null  - the county, for governor tickets",
+				"party":                  "string | null",
+				"principal":              "RunningMate",
+				"deputy":                 "RunningMate",
+				"nominated_on":           "date | null",
+				"source_url":             "string | null",
+				"created_at":             "datetime",
+			},
+		},
+		"CandidacyEvent": map[string]interface{}{
+			"description": "A change of a candidacy's status",
+			"fields": map[string]string{
+				"id":           "uuid",
+				"candidacy_id": "uuid",
+				"stage":        "string  - declaration | party_primary | iebc_clearance | dispute | withdrawal | result; inferred when omitted",
+				"from_status":  "string | null",
+				"status":       "string  - the new status",
+				"reason":       "string | null  - required for disqualified",
+				"source_url":   "string | null",
+				"source_id":    "uuid | null  - from X-Source-ID",
+				"occurred_on":  "date  - defaults to today",
+				"recorded_by":  "string",
+				"recorded_at":  "datetime",
+			},
+		},
+		"CandidacyTimeline": map[string]interface{}{
+			"description": "A candidacy from declaration to result",
+			"fields": map[string]string{
+				"candidacy": "Candidacy",
+				"primaries": "PartyPrimary[]  - the politician's primaries for the same seat",
+				"events":    "CandidacyEvent[]  - oldest first",
+			},
+		},
+		"PartyPrimary": map[string]interface{}{
+			"description": "An aspirant's outcome in a party's nomination for a seat",
+			"fields": map[string]string{
+				"id":              "uuid",
+				"election_id":     "uuid",
+				"position_id":     "uuid",
+				"position_title":  "string",
+				"party_id":        "uuid",
+				"party_name":      "string",
+				"politician_id":   "uuid",
+				"politician_name": "string",
+				"politician_slug": "string",
+				"method":          "string  - vote | consensus | direct_nomination",
+				"outcome":         "string  - won | lost | stepped_down | nullified",
+				"votes":           "integer | null  - only for primaries decided by vote",
+				"held_on":         "date | null",
+				"notes":           "string | null",
+				"source_url":      "string | null",
+			},
+		},
+		"Poll": map[string]interface{}{
+			"description": "A published opinion poll release. Shares, undecided and margin_of_error are percentages",
+			"fields": map[string]string{
+				"id":              "uuid",
+				"election_id":     "uuid",
+				"scope":           "string  - candidate | party; inferred from position_id when omitted",
+				"position_id":     "uuid | null  - required for candidate polls",
+				"position_title":  "string | null",
+				"area":            "object | null  - county of the polled seat",
+				"pollster":        "string  - e.g. TIFA, Infotrak, Ipsos",
+				"commissioned_by": "string | null",
+				"fieldwork_start": "date",
+				"fieldwork_end":   "date",
+				"published_on":    "date | null",
+				"sample_size":     "integer",
+				"margin_of_error": "number | null",
+				"methodology":     "string  - face_to_face | telephone | online | mixed",
+				"undecided":       "number | null",
+				"shares":          "object[]  - politician_id (candidate polls) or party_id (party polls), share; read back with slug, name and candidacy_id",
+				"source_url":      "string | null",
+				"notes":           "string | null",
+			},
+		},
+		"PollAverage": map[string]interface{}{
+			"description": "Poll of polls for one race. Each poll is weighted by the square root of its sample size and halves in weight every half_life_days after the middle of its fieldwork",
+			"fields": map[string]string{
+				"election_id":    "uuid",
+				"scope":          "string",
+				"position_id":    "uuid | null",
+				"position":       "string | null",
+				"area":           "object | null",
+				"as_of":          "date",
+				"half_life_days": "integer",
+				"window_days":    "integer",
+				"polls":          "integer  - polls in the window",
+				"pollsters":      "string[]",
+				"options":        "object[]  - politician_id or party_id, candidacy_id, slug, name, share, low, high, polls; highest share first",
+				"undecided":      "number | null  - weighted average of the polls that report it",
+			},
+		},
+		"ResultTally": map[string]interface{}{
+			"description": "One results form for a seat at a reporting area",
+			"fields": map[string]string{
+				"id":                "uuid",
+				"election_id":       "uuid",
+				"position_id":       "uuid",
+				"level":             "string  - station | ward | constituency | county | national",
+				"form":              "string | null  - e.g. 34A, 34B, 34C; defaulted from the seat and level",
+				"area_code":         "string | null  - polling station, ward, constituency or county code; omitted for national",
+				"registered_voters": "integer",
+				"rejected_votes":    "integer",
+				"valid_votes":       "integer",
+				"is_final":          "boolean",
+				"is_current":        "boolean  - false once superseded by a resubmission",
+				"reported_by":       "string",
+				"source_id":         "uuid | null  - from the X-Source-ID header",
+				"reported_at":       "datetime",
+				"results":           "object[]  - candidacy_id, votes",
+			},
+		},
+		"AreaResults": map[string]interface{}{
+			"description": "Each seat's result over one reporting area",
+			"fields": map[string]string{
+				"election_id": "uuid",
+				"level":       "string  - station | ward | constituency | county | national",
+				"area":        "object | null  - id, code, name; omitted for national",
+				"positions":   "PositionResult[]",
+			},
+		},
+		"PositionResult": map[string]interface{}{
+			"description": "One seat's result over a reporting area",
+			"fields": map[string]string{
+				"position_id":       "uuid",
+				"title":             "string",
+				"seat_level":        "string  - ward | constituency | county | national",
+				"seat_area":         "string | null",
+				"basis":             "string  - stations (summed from station tallies) | reported (tally filed for the area)",
+				"form":              "string | null  - form of the reported tally",
+				"registered_voters": "integer",
+				"valid_votes":       "integer",
+				"rejected_votes":    "integer",
+				"total_votes":       "integer",
+				"turnout":           "number | null  - percentage of registered voters",
+				"stations_reported": "integer",
+				"stations_expected": "integer",
+				"is_final":          "boolean  - a station sum is final only once stations_reported reaches stations_expected",
+				"candidates":        "object[]  - candidacy_id, politician_id, slug, name, party, votes, share, running_mate",
+			},
+		},
+		"ResultUpdate": map[string]interface{}{
+			"description": "A result tally pushed on the live results stream; the event id is seq",
+			"fields": map[string]string{
+				"seq":               "integer  - increases as tallies arrive",
+				"tally_id":          "uuid",
+				"election_id":       "uuid",
+				"position_id":       "uuid",
+				"title":             "string  - seat title",
+				"level":             "string  - station | ward | constituency | county | national",
+				"form":              "string | null",
+				"area":              "object | null  - id, code, name",
+				"ward_code":         "string | null",
+				"constituency_code": "string | null",
+				"county_code":       "string | null",
+				"registered_voters": "integer",
+				"rejected_votes":    "integer",
+				"valid_votes":       "integer",
+				"is_final":          "boolean",
+				"reported_at":       "datetime",
+				"candidates":        "object[]  - candidacy_id, politician_id, slug, name, party, votes, share, running_mate",
+			},
+		},
+		"PresidentialStatus": map[string]interface{}{
+			"description": "Progress of the presidential count against the constitutional thresholds",
+			"fields": map[string]string{
+				"election_id":        "uuid",
+				"status":             "string  - no_results | counting | declared_pending_counties | elected | run_off; elected and run_off only once every county is complete and final",
+				"majority_threshold": "number  - 50, the share of valid votes to exceed",
+				"county_threshold":   "number  - 25, the county share to reach",
+				"counties_required":  "integer  - 24",
+				"counties_reporting": "integer",
+				"counties_total":     "integer",
+				"valid_votes":        "integer",
+				"is_final":           "boolean",
+				"leader":             "uuid | null  - candidacy_id",
+				"winner":             "uuid | null  - candidacy_id, once elected",
+				"run_off":            "boolean  - the final result requires a run-off; false while counting",
+				"run_off_candidates": "uuid[]  - the top two candidacies when run_off is true",
+				"candidates":         "object[]  - candidacy_id, politician_id, slug, name, party, votes, share, counties_at_threshold, counties_short, meets_majority, meets_county_spread, on_track, counties (code, name, votes, share, meets_threshold)",
+			},
+		},
+		"SwingReport": map[string]interface{}{
+			"description": "A seat's results compared between two elections",
+			"fields": map[string]string{
+				"from":     "Election",
+				"to":       "Election",
+				"position": "string",
+				"group_by": "string  - party | coalition",
+				"level":    "string  - constituency | county",
+				"areas":    "AreaSwing[]",
+				"overall":  "AreaSwing | null  - all areas in the report combined",
+			},
+		},
+		"AreaSwing": map[string]interface{}{
+			"description": "One area compared between two elections. Shares, turnout and margins are percentages; changes are percentage points; null where an election has no results in the area",
+			"fields": map[string]string{
+				"area":           "object  - id, code, name",
+				"county":         "object | null  - id, code, name; for constituencies",
+				"turnout_from":   "number | null",
+				"turnout_to":     "number | null",
+				"turnout_change": "number | null",
+				"winner_from":    "string | null  - leading party or coalition",
+				"winner_to":      "string | null",
+				"margin_from":    "number | null  - lead over the runner-up",
+				"margin_to":      "number | null",
+				"margin_change":  "number | null",
+				"groups":         "object[]  - id, name, type (party | coalition | independent), votes_from, votes_to, share_from, share_to, swing",
+			},
+		},
+		"ResultSummary": map[string]interface{}{
+			"description": "A candidacy's vote total",
+			"fields": map[string]string{
+				"candidacy_id":    "uuid",
+				"position_id":     "uuid",
+				"position_title":  "string",
+				"politician_name": "string",
+				"party_name":      "string | null",
+				"total_votes":     "integer",
+				"percentage":      "number  - share of the votes for the same seat",
+				"is_final":        "boolean",
+				"running_mate":    "RunningMate | null",
+			},
+		},
+		"SeatResult": map[string]interface{}{
+			"description": "The contest for one seat",
+			"fields": map[string]string{
+				"position_id":  "uuid",
+				"title":        "string  - president | governor | senator | woman_rep | mp | mca",
+				"level":        "string  - ward | constituency | county | national",
+				"area":         "object | null  - id, code, name",
+				"county":       "object | null  - for ward and constituency seats",
+				"basis":        "string  - declared (the seat's own tally) | counted (summed from lower tallies) | none",
+				"is_final":     "boolean  - a counted seat is final only once every polling station has a final tally",
+				"valid_votes":  "integer",
+				"winner":       "SeatCandidate | null  - the leader while counting; null on a tie or a presidential run-off",
+				"runner_up":    "SeatCandidate | null",
+				"margin":       "integer | null  - votes",
+				"margin_share": "number | null  - percentage points",
+				"candidates":   "SeatCandidate[]  - candidacy_id, politician_id, slug, name, party, party_slug, status, votes, share, running_mate",
+			},
+		},
+		"SeatSummary": map[string]interface{}{
+			"description": "Seats of one title by party",
+			"fields": map[string]string{
+				"title":     "string",
+				"seats":     "integer",
+				"final":     "integer",
+				"reporting": "integer",
+				"parties":   "object[]  - party, party_slug, won, leading, contested",
+			},
+		},
+		"Anomaly": map[string]interface{}{
+			"description": "A discrepancy between result tallies",
+			"fields": map[string]string{
+				"type":              "string  - aggregate_mismatch | turnout_over_100 | conflicting_resubmission",
+				"severity":          "string  - low | medium | high | critical",
+				"message":           "string",
+				"election_id":       "uuid",
+				"position_id":       "uuid",
+				"title":             "string  - seat title",
+				"level":             "string  - station | ward | constituency | county | national",
+				"area":              "object | null  - id, code, name",
+				"tally_id":          "uuid  - the current tally the anomaly was found on",
+				"related_tally_id":  "uuid | null  - the superseded tally, for resubmissions",
+				"candidacy_id":      "uuid | null",
+				"candidate":         "string | null",
+				"reported":          "integer | null  - votes on the aggregate form, votes cast, or the resubmitted figure",
+				"expected":          "integer | null  - station sum, registered voters, or the superseded figure",
+				"turnout":           "number | null",
+				"stations_reported": "integer | null",
+				"stations_expected": "integer | null",
+				"superseded_final":  "boolean  - the superseded tally had been marked final",
+				"reported_at":       "datetime",
+			},
+		},
 		"County": map[string]interface{}{
 			"description": "One of Kenya's 47 counties",
 			"fields": map[string]string{
@@ -463,6 +1260,7 @@ func schemaDefinitions() map[string]interface{} {
 				"content":              "string",
 				"summary":              "string | null",
 				"url":                  "string  - original article URL",
+				"canonical_url":        "string | null  - the page's canonical URL, for sources with full-text extraction",
 				"author":               "string | null",
 				"published_at":         "datetime",
 				"scraped_at":           "datetime",
@@ -470,6 +1268,32 @@ func schemaDefinitions() map[string]interface{} {
 				"created_at":           "datetime",
 			},
 		},
+		"StoryCluster": map[string]interface{}{
+			"description": "One story as covered across outlets. Articles whose title and lead share enough words are grouped together",
+			"fields": map[string]string{
+				"id":                  "uuid",
+				"headline":            "string  - title of the earliest article",
+				"lead_article_id":     "uuid  - the earliest article",
+				"first_seen_at":       "datetime",
+				"last_seen_at":        "datetime",
+				"article_count":       "integer",
+				"outlet_count":        "integer",
+				"is_election_related": "boolean  - any of its articles is election-related",
+				"outlets":             "string[]  - each outlet once, in the order they covered the story",
+				"articles":            "StoryArticle[]  - earliest first",
+			},
+		},
+		"StoryArticle": map[string]interface{}{
+			"description": "An article in a story cluster",
+			"fields": map[string]string{
+				"id":           "uuid",
+				"source_id":    "uuid",
+				"outlet":       "string  - the source's outlet, or its name",
+				"title":        "string",
+				"url":          "string",
+				"published_at": "datetime | null",
+			},
+		},
 		"TrendingItem": map[string]interface{}{
 			"description": "A trending politician ranked by recent news mentions",
 			"fields": map[string]string{
@@ -498,6 +1322,249 @@ func schemaDefinitions() map[string]interface{} {
 				"uptime":   "string  - server uptime duration",
 			},
 		},
+		"SearchResponse": map[string]interface{}{
+			"description": "Search results grouped by type",
+			"fields": map[string]string{
+				"query":  "string",
+				"total":  "integer  - matches across all types",
+				"groups": "{type, total, results: SearchResult[]}[]",
+			},
+		},
+		"SearchResult": map[string]interface{}{
+			"description": "A single search match",
+			"fields": map[string]string{
+				"type":     "string  - politician | party | coalition | county | constituency | article | court_case | promise",
+				"id":       "uuid",
+				"title":    "string",
+				"subtitle": "string | null  - e.g. the politician for a court case, the county for a constituency",
+				"snippet":  "string  - excerpt with matches wrapped in <mark>",
+				"url":      "string  - API path of the matching resource",
+				"rank":     "number",
+			},
+		},
+		"Suggestion": map[string]interface{}{
+			"description": "An autocomplete match",
+			"fields": map[string]string{
+				"type":       "string  - politician | party | constituency | county | ward",
+				"id":         "uuid",
+				"label":      "string",
+				"subtitle":   "string | null  - current party, parent county or constituency",
+				"key":        "string  - slug or code used in the resource URL",
+				"url":        "string  - API path of the resource",
+				"popularity": "integer",
+				"score":      "number  - similarity to the query, 0-1",
+			},
+		},
+		"Representatives": map[string]interface{}{
+			"description": "The seats covering a voter's location",
+			"fields": map[string]string{
+				"location":          "object  - resolved_by (polling_station | ward | boundary | nearest_polling_station), polling_station, distance_km, ward, constituency and county, each with id, code and name",
+				"upcoming_election": "Election | null  - the next general election, whose candidates are listed",
+				"seats":             "Seat[]  - local seats first",
+			},
+		},
+		"Seat": map[string]interface{}{
+			"description": "An elective position with its sitting holder and declared candidates",
+			"fields": map[string]string{
+				"position_id": "uuid",
+				"title":       "string  - mca | mp | woman_rep | senator | governor | deputy_governor | president | deputy_president",
+				"level":       "string  - ward | constituency | county | national",
+				"area":        "string | null  - name of the ward, constituency or county",
+				"holder":      "object | null  - politician_id, slug, name, photo_url, party, status, election_name, election_date",
+				"candidates":  "object[]  - politician_id, slug, name, photo_url, party, status",
+			},
+		},
+		"NearbyPollingStation": map[string]interface{}{
+			"description": "An IEBC polling station with its distance from the queried point",
+			"fields": map[string]string{
+				"id":                "uuid",
+				"ward_id":           "uuid",
+				"code":              "string",
+				"name":              "string",
+				"latitude":          "number",
+				"longitude":         "number",
+				"registered_voters": "integer",
+				"created_at":        "datetime",
+				"distance_km":       "number",
+				"ward_code":         "string",
+				"ward_name":         "string",
+				"constituency_code": "string",
+				"constituency_name": "string",
+				"county_code":       "string",
+				"county_name":       "string",
+			},
+		},
+		"BoundaryFeature": map[string]interface{}{
+			"description": "A GeoJSON Feature holding an electoral boundary in WGS84",
+			"fields": map[string]string{
+				"type":       "string  - Feature",
+				"properties": "object  - id, level (county | constituency | ward), code, name",
+				"geometry":   "object  - GeoJSON Polygon or MultiPolygon",
+			},
+		},
+		"BoundaryMatch": map[string]interface{}{
+			"description": "The areas whose boundaries contain a point. Levels without loaded boundaries are null",
+			"fields": map[string]string{
+				"lat":          "number",
+				"lng":          "number",
+				"ward":         "object | null  - id, code, name",
+				"constituency": "object | null  - id, code, name",
+				"county":       "object | null  - id, code, name",
+			},
+		},
+		"Revision": map[string]interface{}{
+			"description": "One audited change to a dossier record. The audit log is append-only",
+			"fields": map[string]string{
+				"id":             "integer",
+				"table":          "string  - audited table, e.g. promises",
+				"record_id":      "uuid",
+				"politician_id":  "uuid | null",
+				"action":         "string  - insert | update | delete",
+				"actor":          "string  - editor name, or system for seeded data",
+				"source_id":      "uuid | null  - source justifying the change",
+				"source_name":    "string | null",
+				"source_url":     "string | null",
+				"changed_fields": "string[]",
+				"old_data":       "object | null  - record before the change",
+				"new_data":       "object | null  - record after the change",
+				"changed_at":     "datetime",
+			},
+		},
+		"EditSubmission": map[string]interface{}{
+			"description": "A proposed dossier edit awaiting editorial review",
+			"fields": map[string]string{
+				"id":                "uuid",
+				"politician_id":     "uuid",
+				"politician_name":   "string",
+				"collection":        "string  - politician | party-memberships | court-cases | promises | achievements | controversies | assets | integrity-flags",
+				"operation":         "string  - create | update | delete",
+				"record_id":         "uuid | null  - required for update and delete",
+				"payload":           "object  - the new record for create, the fields to change for update",
+				"summary":           "string  - what the change is and why",
+				"evidence_url":      "string",
+				"evidence_name":     "string | null",
+				"evidence_type":     "string  - iebc | eacc | gazette | hansard | news | social | court | other",
+				"submitter_name":    "string | null",
+				"submitter_email":   "string | null",
+				"status":            "string  - submitted | under_review | approved | rejected",
+				"reviewer":          "string | null",
+				"review_comment":    "string | null",
+				"reviewed_at":       "datetime | null",
+				"source_id":         "uuid | null  - source created from the evidence on approval",
+				"applied_record_id": "uuid | null",
+				"created_at":        "datetime",
+				"updated_at":        "datetime",
+			},
+		},
+		"NewsSource": map[string]interface{}{
+			"description": "A news outlet feed or page the aggregator reads",
+			"fields": map[string]string{
+				"id":                "uuid",
+				"name":              "string",
+				"url":               "string",
+				"feed_url":          "string | null  - for rss sources",
+				"type":              "string  - rss | scraper | api",
+				"outlet":            "string | null",
+				"active":            "boolean",
+				"extract_full_text": "boolean  - fetch each new article's page for its full text, byline and canonical URL",
+				"scraper":           "ScraperConfig | null",
+				"fetch_state":       "FetchState | null  - set once the aggregator has tried the source",
+			},
+		},
+		"FetchState": map[string]interface{}{
+			"description": "What the aggregator keeps about a news source between cycles",
+			"fields": map[string]string{
+				"etag":                 "string | null  - validator of the last feed or listing page response",
+				"last_modified":        "string | null  - validator of the last feed or listing page response",
+				"last_fetched_at":      "datetime",
+				"last_success_at":      "datetime | null",
+				"last_status_code":     "integer | null  - 304 when the page was unchanged",
+				"last_error":           "string | null",
+				"consecutive_failures": "integer",
+				"next_fetch_at":        "datetime | null  - a failing source is not fetched again before this",
+				"deactivated_at":       "datetime | null  - when the source was switched off for failing FETCH_MAX_FAILURES times in a row",
+			},
+		},
+		"FetchAttempt": map[string]interface{}{
+			"description": "One attempt to fetch a news source",
+			"fields": map[string]string{
+				"id":          "integer",
+				"source_id":   "uuid",
+				"started_at":  "datetime",
+				"duration_ms": "integer",
+				"status_code": "integer | null  - empty when no response came back",
+				"items_seen":  "integer  - feed items or listing page links",
+				"new_items":   "integer",
+				"item_errors": "integer  - items that could not be read or stored",
+				"error":       "string | null  - set when the attempt failed",
+			},
+		},
+		"NewsSourceStatusReport": map[string]interface{}{
+			"description": "Health of every news source over a window",
+			"fields": map[string]string{
+				"generated_at": "datetime",
+				"window_hours": "integer",
+				"summary":      "object  - number of sources per status",
+				"sources":      "NewsSourceStatus[]",
+			},
+		},
+		"NewsSourceStatus": map[string]interface{}{
+			"description": "Health of one news source",
+			"fields": map[string]string{
+				"id":                   "uuid",
+				"name":                 "string",
+				"outlet":               "string | null",
+				"type":                 "string",
+				"active":               "boolean",
+				"status":               "string  - healthy | stale | broken | inactive",
+				"reason":               "string  - why the source is not healthy",
+				"last_fetched_at":      "datetime | null",
+				"last_success_at":      "datetime | null",
+				"last_status_code":     "integer | null",
+				"last_error":           "string | null",
+				"consecutive_failures": "integer",
+				"next_fetch_at":        "datetime | null",
+				"deactivated_at":       "datetime | null",
+				"last_article_at":      "datetime | null  - when the newest article was stored",
+				"recent_articles":      "integer  - articles stored within the window",
+				"attempts":             "integer  - fetch attempts within the window",
+				"failures":             "integer  - failed attempts within the window",
+				"avg_duration_ms":      "integer | null",
+			},
+		},
+		"ScraperConfig": map[string]interface{}{
+			"description": "Where the HTML scraper finds a source's articles. Selectors are CSS selectors",
+			"fields": map[string]string{
+				"list_url":        "string  - page listing recent articles",
+				"link_selector":   "string  - article links on the listing page, or elements containing them",
+				"title_selector":  "string  - falls back to og:title",
+				"body_selector":   "string  - paragraphs inside the matches make up the content",
+				"date_selector":   "string | null  - falls back to article:published_time",
+				"date_attribute":  "string | null  - read the date from this attribute, e.g. datetime, instead of the text",
+				"date_layout":     "string | null  - Go time layout, e.g. \"January 2, 2006\"; common formats are tried otherwise",
+				"author_selector": "string | null  - falls back to the author meta tag",
+				"max_articles":    "integer  - links followed per cycle, 1-100 (default: 20)",
+			},
+		},
+		"APIKey": map[string]interface{}{
+			"description": "An API key, sent in the X-API-Key header",
+			"fields": map[string]string{
+				"id":           "uuid",
+				"name":         "string",
+				"owner_email":  "string | null",
+				"prefix":       "string  - first characters of the key, for identification",
+				"tier":         "string  - registered | partner",
+				"rate_limit":   "number | null  - requests per second, overrides the tier",
+				"burst":        "integer | null  - overrides the tier",
+				"daily_quota":  "integer | null  - requests per UTC day, 0 = unlimited, overrides the tier",
+				"created_by":   "string | null",
+				"last_used_at": "datetime | null",
+				"revoked_at":   "datetime | null",
+				"usage_today":  "integer",
+				"created_at":   "datetime",
+				"updated_at":   "datetime",
+			},
+		},
 		"ErrorResponse": map[string]interface{}{
 			"description": "Standard error response returned on 4xx/5xx",
 			"fields": map[string]string{
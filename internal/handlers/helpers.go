@@ -2,15 +2,22 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 
 	"jalada/internal/models"
+	"jalada/internal/repository"
+	"jalada/internal/services"
 )
 
+const maxBodyBytes = 1 << 20
+
 func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -47,3 +54,102 @@ func parsePagination(r *http.Request) (limit, offset int) {
 	}
 	return
 }
+
+// queryList returns the values of a query parameter that may be repeated
+// (?gender=male&gender=female) or comma-separated (?gender=male,female).
+func queryList(r *http.Request, key string) []string {
+	var values []string
+	for _, v := range r.URL.Query()[key] {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+	}
+	return values
+}
+
+func queryUUIDs(w http.ResponseWriter, r *http.Request, key string) ([]uuid.UUID, bool) {
+	var ids []uuid.UUID
+	for _, v := range queryList(r, key) {
+		id, err := parseUUID(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid "+key+": "+v)
+			return nil, false
+		}
+		ids = append(ids, id)
+	}
+	return ids, true
+}
+
+func queryInt(w http.ResponseWriter, r *http.Request, key string) (*int, bool) {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return nil, true
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, key+" must be an integer")
+		return nil, false
+	}
+	return &n, true
+}
+
+func queryFloat(w http.ResponseWriter, r *http.Request, key string) (*float64, bool) {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return nil, true
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, key+" must be a number")
+		return nil, false
+	}
+	return &f, true
+}
+
+// queryDate parses a YYYY-MM-DD query parameter as a UTC date.
+func queryDate(w http.ResponseWriter, r *http.Request, key string) (*time.Time, bool) {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return nil, true
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, key+" must be a date (YYYY-MM-DD)")
+		return nil, false
+	}
+	return &t, true
+}
+
+// decodeJSON decodes the request body into v, writing a 400 on failure.
+// Decoding onto an existing value only overwrites the fields present in
+// the body, which is what PATCH handlers rely on.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// writeWriteError maps service and repository errors from a write to the
+// matching status code. resource names the record in 404 messages.
+func writeWriteError(w http.ResponseWriter, err error, resource string) {
+	var verr *services.ValidationError
+	switch {
+	case errors.As(err, &verr):
+		writeError(w, http.StatusBadRequest, verr.Error())
+	case errors.Is(err, repository.ErrNotFound):
+		writeError(w, http.StatusNotFound, resource+" not found")
+	case errors.Is(err, repository.ErrConflict):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, repository.ErrInvalidReference), errors.Is(err, repository.ErrConstraint):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		log.Error().Err(err).Str("resource", resource).Msg("write failed")
+		writeError(w, http.StatusInternalServerError, "failed to save "+resource)
+	}
+}
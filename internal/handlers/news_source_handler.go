@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"jalada/internal/models"
+	"jalada/internal/services"
+)
+
+// NewsSourceHandler manages the news sources the aggregator reads.
+type NewsSourceHandler struct {
+	svc *services.NewsSourceService
+}
+
+func NewNewsSourceHandler(svc *services.NewsSourceService) *NewsSourceHandler {
+	return &NewsSourceHandler{svc: svc}
+}
+
+func (h *NewsSourceHandler) List(w http.ResponseWriter, r *http.Request) {
+	sources, err := h.svc.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list news sources")
+		return
+	}
+	if sources == nil {
+		sources = []models.NewsSource{}
+	}
+	writeJSON(w, http.StatusOK, sources)
+}
+
+// Status reports which news sources are healthy, stale or broken.
+func (h *NewsSourceHandler) Status(w http.ResponseWriter, r *http.Request) {
+	window, ok := queryInt(w, r, "window")
+	if !ok {
+		return
+	}
+	var hours int
+	if window != nil {
+		hours = *window
+	}
+
+	report, err := h.svc.Status(r.Context(), hours)
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get news source status")
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// Fetches lists a source's most recent fetch attempts.
+func (h *NewsSourceHandler) Fetches(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid news source id")
+		return
+	}
+	limit, ok := queryInt(w, r, "limit")
+	if !ok {
+		return
+	}
+	var n int
+	if limit != nil {
+		n = *limit
+	}
+
+	attempts, err := h.svc.Fetches(r.Context(), id, n)
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list fetches")
+		return
+	}
+	if attempts == nil {
+		writeError(w, http.StatusNotFound, "news source not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, attempts)
+}
+
+// Update changes whether a source is read and whether its articles'
+// full text is fetched.
+func (h *NewsSourceHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid news source id")
+		return
+	}
+
+	var u models.NewsSourceUpdate
+	if !decodeJSON(w, r, &u) {
+		return
+	}
+	if err := h.svc.Update(r.Context(), id, u); err != nil {
+		writeWriteError(w, err, "news source")
+		return
+	}
+	h.writeSource(w, r, id)
+}
+
+// SetScraper saves a source's CSS selectors for the HTML scraper.
+func (h *NewsSourceHandler) SetScraper(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid news source id")
+		return
+	}
+
+	var c models.ScraperConfig
+	if !decodeJSON(w, r, &c) {
+		return
+	}
+	c.SourceID = id
+	if err := h.svc.SetScraperConfig(r.Context(), &c); err != nil {
+		writeWriteError(w, err, "news source")
+		return
+	}
+	h.writeSource(w, r, id)
+}
+
+func (h *NewsSourceHandler) writeSource(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	source, err := h.svc.Get(r.Context(), id)
+	if err != nil || source == nil {
+		writeError(w, http.StatusInternalServerError, "failed to get news source")
+		return
+	}
+	writeJSON(w, http.StatusOK, source)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -23,19 +24,38 @@ func (h *PoliticianHandler) List(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
 
 	filter := models.PoliticianFilter{
-		Query:  q.Get("q"),
-		Limit:  limit,
-		Offset: offset,
+		Query:          q.Get("q"),
+		CountyCodes:    queryList(r, "county"),
+		Constituencies: queryList(r, "constituency"),
+		Positions:      queryList(r, "position"),
+		Genders:        queryList(r, "gender"),
+		Statuses:       queryList(r, "status"),
+		Coalitions:     queryList(r, "coalition"),
+		Sort:           q.Get("sort"),
+		Limit:          limit,
+		Offset:         offset,
 	}
 
-	if pid := q.Get("party_id"); pid != "" {
-		id, err := parseUUID(pid)
-		if err == nil {
-			filter.PartyID = &id
-		}
+	var ok bool
+	if filter.PartyIDs, ok = queryUUIDs(w, r, "party_id"); !ok {
+		return
+	}
+	if filter.CountyIDs, ok = queryUUIDs(w, r, "county_id"); !ok {
+		return
+	}
+	if filter.MinAge, ok = queryInt(w, r, "min_age"); !ok {
+		return
+	}
+	if filter.MaxAge, ok = queryInt(w, r, "max_age"); !ok {
+		return
 	}
 
 	politicians, total, err := h.svc.List(r.Context(), filter)
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to list politicians")
 		return
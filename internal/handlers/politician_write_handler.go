@@ -0,0 +1,523 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"jalada/internal/models"
+)
+
+// updateTarget returns the value a write body is decoded onto: the stored
+// record for PATCH so omitted fields are kept, a blank record for PUT.
+func updateTarget[T any](r *http.Request, existing *T) *T {
+	if r.Method == http.MethodPut {
+		return new(T)
+	}
+	return existing
+}
+
+func parseRecordID(w http.ResponseWriter, r *http.Request, resource string) (uuid.UUID, bool) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid "+resource+" id")
+		return uuid.UUID{}, false
+	}
+	return id, true
+}
+
+func (h *PoliticianHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var p models.Politician
+	if !decodeJSON(w, r, &p) {
+		return
+	}
+	if err := h.svc.CreatePolitician(r.Context(), &p); err != nil {
+		writeWriteError(w, err, "politician")
+		return
+	}
+	writeJSON(w, http.StatusCreated, p)
+}
+
+func (h *PoliticianHandler) Update(w http.ResponseWriter, r *http.Request) {
+	existing, err := h.svc.GetBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to find politician")
+		return
+	}
+	if existing == nil {
+		writeError(w, http.StatusNotFound, "politician not found")
+		return
+	}
+
+	p := updateTarget(r, existing)
+	if !decodeJSON(w, r, p) {
+		return
+	}
+	p.ID = existing.ID
+	if err := h.svc.UpdatePolitician(r.Context(), p); err != nil {
+		writeWriteError(w, err, "politician")
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (h *PoliticianHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.svc.DeletePolitician(r.Context(), id); err != nil {
+		writeWriteError(w, err, "politician")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PoliticianHandler) CreatePartyMembership(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	var m models.PartyMembership
+	if !decodeJSON(w, r, &m) {
+		return
+	}
+	m.PoliticianID = pid
+	if err := h.svc.CreatePartyMembership(r.Context(), &m); err != nil {
+		writeWriteError(w, err, "party membership")
+		return
+	}
+	writeJSON(w, http.StatusCreated, m)
+}
+
+func (h *PoliticianHandler) UpdatePartyMembership(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseRecordID(w, r, "party membership")
+	if !ok {
+		return
+	}
+	existing, err := h.svc.GetPartyMembership(r.Context(), pid, id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get party membership")
+		return
+	}
+	if existing == nil {
+		writeError(w, http.StatusNotFound, "party membership not found")
+		return
+	}
+
+	m := updateTarget(r, existing)
+	if !decodeJSON(w, r, m) {
+		return
+	}
+	m.ID, m.PoliticianID = id, pid
+	if err := h.svc.UpdatePartyMembership(r.Context(), m); err != nil {
+		writeWriteError(w, err, "party membership")
+		return
+	}
+	writeJSON(w, http.StatusOK, m)
+}
+
+func (h *PoliticianHandler) DeletePartyMembership(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseRecordID(w, r, "party membership")
+	if !ok {
+		return
+	}
+	if err := h.svc.DeletePartyMembership(r.Context(), pid, id); err != nil {
+		writeWriteError(w, err, "party membership")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PoliticianHandler) CreateCourtCase(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	var c models.CourtCase
+	if !decodeJSON(w, r, &c) {
+		return
+	}
+	c.PoliticianID = pid
+	if err := h.svc.CreateCourtCase(r.Context(), &c); err != nil {
+		writeWriteError(w, err, "court case")
+		return
+	}
+	writeJSON(w, http.StatusCreated, c)
+}
+
+func (h *PoliticianHandler) UpdateCourtCase(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseRecordID(w, r, "court case")
+	if !ok {
+		return
+	}
+	existing, err := h.svc.GetCourtCase(r.Context(), pid, id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get court case")
+		return
+	}
+	if existing == nil {
+		writeError(w, http.StatusNotFound, "court case not found")
+		return
+	}
+
+	c := updateTarget(r, existing)
+	if !decodeJSON(w, r, c) {
+		return
+	}
+	c.ID, c.PoliticianID = id, pid
+	if err := h.svc.UpdateCourtCase(r.Context(), c); err != nil {
+		writeWriteError(w, err, "court case")
+		return
+	}
+	writeJSON(w, http.StatusOK, c)
+}
+
+func (h *PoliticianHandler) DeleteCourtCase(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseRecordID(w, r, "court case")
+	if !ok {
+		return
+	}
+	if err := h.svc.DeleteCourtCase(r.Context(), pid, id); err != nil {
+		writeWriteError(w, err, "court case")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PoliticianHandler) CreatePromise(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	var p models.Promise
+	if !decodeJSON(w, r, &p) {
+		return
+	}
+	p.PoliticianID = pid
+	if err := h.svc.CreatePromise(r.Context(), &p); err != nil {
+		writeWriteError(w, err, "promise")
+		return
+	}
+	writeJSON(w, http.StatusCreated, p)
+}
+
+func (h *PoliticianHandler) UpdatePromise(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseRecordID(w, r, "promise")
+	if !ok {
+		return
+	}
+	existing, err := h.svc.GetPromise(r.Context(), pid, id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get promise")
+		return
+	}
+	if existing == nil {
+		writeError(w, http.StatusNotFound, "promise not found")
+		return
+	}
+
+	p := updateTarget(r, existing)
+	if !decodeJSON(w, r, p) {
+		return
+	}
+	p.ID, p.PoliticianID = id, pid
+	if err := h.svc.UpdatePromise(r.Context(), p); err != nil {
+		writeWriteError(w, err, "promise")
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (h *PoliticianHandler) DeletePromise(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseRecordID(w, r, "promise")
+	if !ok {
+		return
+	}
+	if err := h.svc.DeletePromise(r.Context(), pid, id); err != nil {
+		writeWriteError(w, err, "promise")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PoliticianHandler) CreateAchievement(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	var a models.Achievement
+	if !decodeJSON(w, r, &a) {
+		return
+	}
+	a.PoliticianID = pid
+	if err := h.svc.CreateAchievement(r.Context(), &a); err != nil {
+		writeWriteError(w, err, "achievement")
+		return
+	}
+	writeJSON(w, http.StatusCreated, a)
+}
+
+func (h *PoliticianHandler) UpdateAchievement(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseRecordID(w, r, "achievement")
+	if !ok {
+		return
+	}
+	existing, err := h.svc.GetAchievement(r.Context(), pid, id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get achievement")
+		return
+	}
+	if existing == nil {
+		writeError(w, http.StatusNotFound, "achievement not found")
+		return
+	}
+
+	a := updateTarget(r, existing)
+	if !decodeJSON(w, r, a) {
+		return
+	}
+	a.ID, a.PoliticianID = id, pid
+	if err := h.svc.UpdateAchievement(r.Context(), a); err != nil {
+		writeWriteError(w, err, "achievement")
+		return
+	}
+	writeJSON(w, http.StatusOK, a)
+}
+
+func (h *PoliticianHandler) DeleteAchievement(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseRecordID(w, r, "achievement")
+	if !ok {
+		return
+	}
+	if err := h.svc.DeleteAchievement(r.Context(), pid, id); err != nil {
+		writeWriteError(w, err, "achievement")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PoliticianHandler) CreateControversy(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	var c models.Controversy
+	if !decodeJSON(w, r, &c) {
+		return
+	}
+	c.PoliticianID = pid
+	if err := h.svc.CreateControversy(r.Context(), &c); err != nil {
+		writeWriteError(w, err, "controversy")
+		return
+	}
+	writeJSON(w, http.StatusCreated, c)
+}
+
+func (h *PoliticianHandler) UpdateControversy(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseRecordID(w, r, "controversy")
+	if !ok {
+		return
+	}
+	existing, err := h.svc.GetControversy(r.Context(), pid, id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get controversy")
+		return
+	}
+	if existing == nil {
+		writeError(w, http.StatusNotFound, "controversy not found")
+		return
+	}
+
+	c := updateTarget(r, existing)
+	if !decodeJSON(w, r, c) {
+		return
+	}
+	c.ID, c.PoliticianID = id, pid
+	if err := h.svc.UpdateControversy(r.Context(), c); err != nil {
+		writeWriteError(w, err, "controversy")
+		return
+	}
+	writeJSON(w, http.StatusOK, c)
+}
+
+func (h *PoliticianHandler) DeleteControversy(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseRecordID(w, r, "controversy")
+	if !ok {
+		return
+	}
+	if err := h.svc.DeleteControversy(r.Context(), pid, id); err != nil {
+		writeWriteError(w, err, "controversy")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PoliticianHandler) CreateAssetDeclaration(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	var d models.AssetDeclaration
+	if !decodeJSON(w, r, &d) {
+		return
+	}
+	d.PoliticianID = pid
+	if err := h.svc.CreateAssetDeclaration(r.Context(), &d); err != nil {
+		writeWriteError(w, err, "asset declaration")
+		return
+	}
+	writeJSON(w, http.StatusCreated, d)
+}
+
+func (h *PoliticianHandler) UpdateAssetDeclaration(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseRecordID(w, r, "asset declaration")
+	if !ok {
+		return
+	}
+	existing, err := h.svc.GetAssetDeclaration(r.Context(), pid, id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get asset declaration")
+		return
+	}
+	if existing == nil {
+		writeError(w, http.StatusNotFound, "asset declaration not found")
+		return
+	}
+
+	d := updateTarget(r, existing)
+	if !decodeJSON(w, r, d) {
+		return
+	}
+	d.ID, d.PoliticianID = id, pid
+	if err := h.svc.UpdateAssetDeclaration(r.Context(), d); err != nil {
+		writeWriteError(w, err, "asset declaration")
+		return
+	}
+	writeJSON(w, http.StatusOK, d)
+}
+
+func (h *PoliticianHandler) DeleteAssetDeclaration(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseRecordID(w, r, "asset declaration")
+	if !ok {
+		return
+	}
+	if err := h.svc.DeleteAssetDeclaration(r.Context(), pid, id); err != nil {
+		writeWriteError(w, err, "asset declaration")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PoliticianHandler) CreateIntegrityFlag(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	var f models.IntegrityFlag
+	if !decodeJSON(w, r, &f) {
+		return
+	}
+	f.PoliticianID = pid
+	if err := h.svc.CreateIntegrityFlag(r.Context(), &f); err != nil {
+		writeWriteError(w, err, "integrity flag")
+		return
+	}
+	writeJSON(w, http.StatusCreated, f)
+}
+
+func (h *PoliticianHandler) UpdateIntegrityFlag(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseRecordID(w, r, "integrity flag")
+	if !ok {
+		return
+	}
+	existing, err := h.svc.GetIntegrityFlag(r.Context(), pid, id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get integrity flag")
+		return
+	}
+	if existing == nil {
+		writeError(w, http.StatusNotFound, "integrity flag not found")
+		return
+	}
+
+	f := updateTarget(r, existing)
+	if !decodeJSON(w, r, f) {
+		return
+	}
+	f.ID, f.PoliticianID = id, pid
+	if err := h.svc.UpdateIntegrityFlag(r.Context(), f); err != nil {
+		writeWriteError(w, err, "integrity flag")
+		return
+	}
+	writeJSON(w, http.StatusOK, f)
+}
+
+func (h *PoliticianHandler) DeleteIntegrityFlag(w http.ResponseWriter, r *http.Request) {
+	pid, ok := h.resolvePoliticianID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseRecordID(w, r, "integrity flag")
+	if !ok {
+		return
+	}
+	if err := h.svc.DeleteIntegrityFlag(r.Context(), pid, id); err != nil {
+		writeWriteError(w, err, "integrity flag")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"jalada/internal/models"
+	"jalada/internal/services"
+)
+
+type PollHandler struct {
+	svc *services.PollService
+}
+
+func NewPollHandler(svc *services.PollService) *PollHandler {
+	return &PollHandler{svc: svc}
+}
+
+// List lists an election's published opinion polls, newest first.
+func (h *PollHandler) List(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+	positionID, ok := queryPositionID(w, r)
+	if !ok {
+		return
+	}
+	since, ok := queryDate(w, r, "from")
+	if !ok {
+		return
+	}
+	until, ok := queryDate(w, r, "to")
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	polls, err := h.svc.List(r.Context(), id, models.PollFilter{
+		Scope:      q.Get("scope"),
+		Title:      q.Get("position"),
+		County:     q.Get("county"),
+		PositionID: positionID,
+		Pollster:   q.Get("pollster"),
+		Since:      since,
+		Until:      until,
+	})
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list polls")
+		return
+	}
+	if polls == nil {
+		polls = []models.Poll{}
+	}
+	writeJSON(w, http.StatusOK, polls)
+}
+
+// Average returns the poll of polls for one race.
+func (h *PollHandler) Average(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+	positionID, ok := queryPositionID(w, r)
+	if !ok {
+		return
+	}
+	asOf, ok := queryDate(w, r, "as_of")
+	if !ok {
+		return
+	}
+	halfLife, ok := queryInt(w, r, "half_life")
+	if !ok {
+		return
+	}
+	window, ok := queryInt(w, r, "window")
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	f := models.PollAverageFilter{
+		Scope:      q.Get("scope"),
+		Title:      q.Get("position"),
+		County:     q.Get("county"),
+		PositionID: positionID,
+		AsOf:       asOf,
+	}
+	if halfLife != nil {
+		f.HalfLifeDays = *halfLife
+	}
+	if window != nil {
+		f.WindowDays = *window
+	}
+	avg, err := h.svc.Average(r.Context(), id, f)
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to compute poll average")
+		return
+	}
+	if avg == nil {
+		writeError(w, http.StatusNotFound, "election not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, avg)
+}
+
+func (h *PollHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, pid, ok := parsePollPath(w, r)
+	if !ok {
+		return
+	}
+
+	poll, err := h.svc.Get(r.Context(), id, pid)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get poll")
+		return
+	}
+	if poll == nil {
+		writeError(w, http.StatusNotFound, "poll not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, poll)
+}
+
+func (h *PollHandler) Create(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+
+	var p models.Poll
+	if !decodeJSON(w, r, &p) {
+		return
+	}
+	p.ElectionID = id
+	if err := h.svc.Create(r.Context(), &p); err != nil {
+		writeWriteError(w, err, "election")
+		return
+	}
+	h.writePoll(w, r, id, p.ID, http.StatusCreated)
+}
+
+// Update replaces a poll. Shares given in the body replace all of the
+// poll's shares.
+func (h *PollHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, pid, ok := parsePollPath(w, r)
+	if !ok {
+		return
+	}
+	existing, err := h.svc.Get(r.Context(), id, pid)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get poll")
+		return
+	}
+	if existing == nil {
+		writeError(w, http.StatusNotFound, "poll not found")
+		return
+	}
+
+	p := updateTarget(r, existing)
+	if !decodeJSON(w, r, p) {
+		return
+	}
+	p.ID, p.ElectionID = pid, id
+	if err := h.svc.Update(r.Context(), p); err != nil {
+		writeWriteError(w, err, "poll")
+		return
+	}
+	h.writePoll(w, r, id, pid, http.StatusOK)
+}
+
+func (h *PollHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, pid, ok := parsePollPath(w, r)
+	if !ok {
+		return
+	}
+	if err := h.svc.Delete(r.Context(), id, pid); err != nil {
+		writeWriteError(w, err, "poll")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writePoll responds with a saved poll read back with its seat and the
+// names behind its shares.
+func (h *PollHandler) writePoll(w http.ResponseWriter, r *http.Request, electionID, id uuid.UUID, status int) {
+	poll, err := h.svc.Get(r.Context(), electionID, id)
+	if err != nil || poll == nil {
+		writeError(w, http.StatusInternalServerError, "failed to get poll")
+		return
+	}
+	writeJSON(w, status, poll)
+}
+
+func parsePollPath(w http.ResponseWriter, r *http.Request) (electionID, pollID uuid.UUID, ok bool) {
+	electionID, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return uuid.Nil, uuid.Nil, false
+	}
+	pollID, err = parseUUID(chi.URLParam(r, "poll_id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid poll id")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return electionID, pollID, true
+}
+
+func queryPositionID(w http.ResponseWriter, r *http.Request) (*uuid.UUID, bool) {
+	v := r.URL.Query().Get("position_id")
+	if v == "" {
+		return nil, true
+	}
+	id, err := parseUUID(v)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid position_id")
+		return nil, false
+	}
+	return &id, true
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"jalada/internal/services"
+)
+
+type RepresentativeHandler struct {
+	svc *services.RepresentativeService
+}
+
+func NewRepresentativeHandler(svc *services.RepresentativeService) *RepresentativeHandler {
+	return &RepresentativeHandler{svc: svc}
+}
+
+func (h *RepresentativeHandler) Lookup(w http.ResponseWriter, r *http.Request) {
+	q := services.RepresentativeQuery{
+		PollingStation: strings.TrimSpace(r.URL.Query().Get("polling_station")),
+		Ward:           strings.TrimSpace(r.URL.Query().Get("ward")),
+	}
+	var ok bool
+	if q.Lat, ok = queryFloat(w, r, "lat"); !ok {
+		return
+	}
+	if q.Lng, ok = queryFloat(w, r, "lng"); !ok {
+		return
+	}
+
+	reps, err := h.svc.Lookup(r.Context(), q)
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to look up representatives")
+		return
+	}
+	if reps == nil {
+		writeError(w, http.StatusNotFound, "location not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, reps)
+}
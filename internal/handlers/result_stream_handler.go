@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+
+	"jalada/internal/models"
+	"jalada/internal/services"
+)
+
+const (
+	streamHeartbeat   = 20 * time.Second
+	streamRetry       = 3 * time.Second
+	streamReplayLimit = 1000
+)
+
+type ResultStreamHandler struct {
+	stream *services.ResultStream
+}
+
+func NewResultStreamHandler(stream *services.ResultStream) *ResultStreamHandler {
+	return &ResultStreamHandler{stream: stream}
+}
+
+// Stream pushes result tallies to the client as Server-Sent Events as they
+// are committed. A client resuming with Last-Event-ID (or last_event_id,
+// for EventSource polyfills that cannot set headers) first receives the
+// tallies it missed. If it missed too many, it gets a reset event and
+// should reload /results before applying further updates.
+func (h *ResultStreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid election id")
+		return
+	}
+
+	q := r.URL.Query()
+	f := models.ResultStreamFilter{
+		ElectionID:   id,
+		Title:        q.Get("position"),
+		County:       q.Get("county"),
+		Constituency: q.Get("constituency"),
+		Ward:         q.Get("ward"),
+	}
+	if v := q.Get("position_id"); v != "" {
+		pid, err := parseUUID(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid position_id")
+			return
+		}
+		f.PositionID = &pid
+	}
+	if err := services.ValidateStreamFilter(f); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	lastID := r.Header.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = q.Get("last_event_id")
+	}
+	var lastSeq int64
+	if lastID != "" {
+		if lastSeq, err = strconv.ParseInt(lastID, 10, 64); err != nil || lastSeq < 0 {
+			writeError(w, http.StatusBadRequest, "invalid Last-Event-ID")
+			return
+		}
+	}
+
+	// The server's write timeout is meant for ordinary responses; a stream
+	// stays open until the client goes away.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Warn().Err(err).Msg("failed to clear stream write deadline")
+	}
+
+	updates, unsubscribe := h.stream.Subscribe(f)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds())
+
+	replayed := make(map[int64]bool)
+	if lastID != "" {
+		missed, complete, latest, err := h.stream.Replay(r.Context(), f, lastSeq, streamReplayLimit)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to replay results")
+			return
+		}
+		if !complete {
+			writeEvent(w, latest, "reset", map[string]string{"reason": "too many missed updates; reload results"})
+		}
+		for _, u := range missed {
+			writeEvent(w, u.Seq, "tally", u)
+			replayed[u.Seq] = true
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case u, ok := <-updates:
+			if !ok {
+				return
+			}
+			if replayed[u.Seq] {
+				continue
+			}
+			writeEvent(w, u.Seq, "tally", u)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, id int64, event string, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		log.Error().Err(err).Str("event", event).Msg("failed to encode stream event")
+		return
+	}
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, event, payload)
+}
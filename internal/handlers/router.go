@@ -5,39 +5,55 @@ import (
 	chimw "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 
+	"jalada/internal/config"
+	"jalada/internal/geo"
 	"jalada/internal/middleware"
 )
 
 type Handlers struct {
-	Health     *HealthHandler
-	Politician *PoliticianHandler
-	Party      *PartyHandler
-	Election   *ElectionHandler
-	News       *NewsHandler
-	Geography  *GeographyHandler
-	Analytics  *AnalyticsHandler
-	Timeline   *TimelineHandler
+	Health         *HealthHandler
+	Politician     *PoliticianHandler
+	Party          *PartyHandler
+	Election       *ElectionHandler
+	News           *NewsHandler
+	Geography      *GeographyHandler
+	Analytics      *AnalyticsHandler
+	Timeline       *TimelineHandler
+	APIKey         *APIKeyHandler
+	Submission     *SubmissionHandler
+	Search         *SearchHandler
+	Autocomplete   *AutocompleteHandler
+	Representative *RepresentativeHandler
+	ResultStream   *ResultStreamHandler
+	Poll           *PollHandler
+	NewsSource     *NewsSourceHandler
+	Story          *StoryHandler
 }
 
-func NewRouter(h *Handlers) *chi.Mux {
+func NewRouter(h *Handlers, cfg *config.Config, limiter *middleware.RateLimiter) *chi.Mux {
 	r := chi.NewRouter()
+	requireEditor := middleware.RequireEditor(cfg.Auth.EditorTokens)
 
 	r.Use(chimw.RealIP)
 	r.Use(chimw.Recoverer)
 	r.Use(middleware.RequestID)
 	r.Use(middleware.Logger)
 	r.Use(cors.Handler(middleware.CORS()))
-	r.Use(middleware.RateLimit(100, 200))
+	r.Use(limiter.Handler)
 
 	r.Get("/", h.Health.Home)
 	r.Get("/health", h.Health.Health)
 
 	r.Route("/v1", func(r chi.Router) {
 		r.Get("/", h.Health.Home)
+		r.Get("/search", h.Search.Search)
+		r.Get("/autocomplete", h.Autocomplete.Suggest)
+		r.Get("/representatives", h.Representative.Lookup)
 
 		// Politicians
 		r.Route("/politicians", func(r chi.Router) {
 			r.Get("/", h.Politician.List)
+			r.With(requireEditor).Post("/", h.Politician.Create)
 			r.Route("/{slug}", func(r chi.Router) {
 				r.Get("/", h.Politician.GetDossier)
 				r.Get("/news", h.Politician.GetNews)
@@ -51,6 +67,58 @@ func NewRouter(h *Handlers) *chi.Mux {
 				r.Get("/attendance", h.Politician.GetAttendance)
 				r.Get("/sentiment", h.Politician.GetSentiment)
 				r.Get("/events", h.Politician.GetEvents)
+				r.Get("/history", h.Politician.GetHistory)
+				r.Get("/revisions", h.Politician.Revisions("politician"))
+				r.Get("/party-memberships/{id}/revisions", h.Politician.Revisions("party-memberships"))
+				r.Get("/court-cases/{id}/revisions", h.Politician.Revisions("court-cases"))
+				r.Get("/promises/{id}/revisions", h.Politician.Revisions("promises"))
+				r.Get("/achievements/{id}/revisions", h.Politician.Revisions("achievements"))
+				r.Get("/controversies/{id}/revisions", h.Politician.Revisions("controversies"))
+				r.Get("/assets/{id}/revisions", h.Politician.Revisions("assets"))
+				r.Get("/integrity-flags/{id}/revisions", h.Politician.Revisions("integrity-flags"))
+				r.Post("/submissions", h.Submission.Submit)
+
+				r.Group(func(r chi.Router) {
+					r.Use(requireEditor)
+					r.Put("/", h.Politician.Update)
+					r.Patch("/", h.Politician.Update)
+					r.Delete("/", h.Politician.Delete)
+
+					r.Post("/party-memberships", h.Politician.CreatePartyMembership)
+					r.Put("/party-memberships/{id}", h.Politician.UpdatePartyMembership)
+					r.Patch("/party-memberships/{id}", h.Politician.UpdatePartyMembership)
+					r.Delete("/party-memberships/{id}", h.Politician.DeletePartyMembership)
+
+					r.Post("/court-cases", h.Politician.CreateCourtCase)
+					r.Put("/court-cases/{id}", h.Politician.UpdateCourtCase)
+					r.Patch("/court-cases/{id}", h.Politician.UpdateCourtCase)
+					r.Delete("/court-cases/{id}", h.Politician.DeleteCourtCase)
+
+					r.Post("/promises", h.Politician.CreatePromise)
+					r.Put("/promises/{id}", h.Politician.UpdatePromise)
+					r.Patch("/promises/{id}", h.Politician.UpdatePromise)
+					r.Delete("/promises/{id}", h.Politician.DeletePromise)
+
+					r.Post("/achievements", h.Politician.CreateAchievement)
+					r.Put("/achievements/{id}", h.Politician.UpdateAchievement)
+					r.Patch("/achievements/{id}", h.Politician.UpdateAchievement)
+					r.Delete("/achievements/{id}", h.Politician.DeleteAchievement)
+
+					r.Post("/controversies", h.Politician.CreateControversy)
+					r.Put("/controversies/{id}", h.Politician.UpdateControversy)
+					r.Patch("/controversies/{id}", h.Politician.UpdateControversy)
+					r.Delete("/controversies/{id}", h.Politician.DeleteControversy)
+
+					r.Post("/assets", h.Politician.CreateAssetDeclaration)
+					r.Put("/assets/{id}", h.Politician.UpdateAssetDeclaration)
+					r.Patch("/assets/{id}", h.Politician.UpdateAssetDeclaration)
+					r.Delete("/assets/{id}", h.Politician.DeleteAssetDeclaration)
+
+					r.Post("/integrity-flags", h.Politician.CreateIntegrityFlag)
+					r.Put("/integrity-flags/{id}", h.Politician.UpdateIntegrityFlag)
+					r.Patch("/integrity-flags/{id}", h.Politician.UpdateIntegrityFlag)
+					r.Delete("/integrity-flags/{id}", h.Politician.DeleteIntegrityFlag)
+				})
 			})
 		})
 
@@ -72,8 +140,39 @@ func NewRouter(h *Handlers) *chi.Mux {
 			r.Route("/{id}", func(r chi.Router) {
 				r.Get("/", h.Election.Get)
 				r.Get("/candidates", h.Election.GetCandidates)
+				r.With(requireEditor).Post("/candidates", h.Election.CreateCandidacy)
+				r.Get("/candidates/{candidacy_id}/timeline", h.Election.GetCandidacyTimeline)
+				r.With(requireEditor).Post("/candidates/{candidacy_id}/events", h.Election.RecordCandidacyEvent)
+				r.Get("/primaries", h.Election.ListPrimaries)
+				r.With(requireEditor).Post("/primaries", h.Election.CreatePrimary)
+				r.With(requireEditor).Put("/primaries/{primary_id}", h.Election.UpdatePrimary)
+				r.With(requireEditor).Patch("/primaries/{primary_id}", h.Election.UpdatePrimary)
+				r.Get("/tickets", h.Election.ListTickets)
+				r.With(requireEditor).Post("/tickets", h.Election.CreateTicket)
+				r.With(requireEditor).Delete("/tickets/{ticket_id}", h.Election.DeleteTicket)
 				r.Get("/results", h.Election.GetResults)
+				r.Get("/results/stream", h.ResultStream.Stream)
+				r.With(requireEditor).Post("/tallies", h.Election.SubmitTally)
+				r.Get("/anomalies", h.Election.GetAnomalies)
+				r.Get("/presidential-status", h.Election.GetPresidentialStatus)
+				r.Get("/seats", h.Election.ListSeats)
+				r.Get("/seats/summary", h.Election.GetSeatSummary)
+				r.Get("/seats/{position_id}", h.Election.GetSeat)
 				r.Get("/timeline", h.Election.GetTimeline)
+
+				r.Route("/polls", func(r chi.Router) {
+					r.Get("/", h.Poll.List)
+					r.Get("/average", h.Poll.Average)
+					r.Get("/{poll_id}", h.Poll.Get)
+
+					r.Group(func(r chi.Router) {
+						r.Use(requireEditor)
+						r.Post("/", h.Poll.Create)
+						r.Put("/{poll_id}", h.Poll.Update)
+						r.Patch("/{poll_id}", h.Poll.Update)
+						r.Delete("/{poll_id}", h.Poll.Delete)
+					})
+				})
 			})
 		})
 
@@ -83,6 +182,7 @@ func NewRouter(h *Handlers) *chi.Mux {
 			r.Route("/{code}", func(r chi.Router) {
 				r.Get("/", h.Geography.GetCounty)
 				r.Get("/constituencies", h.Geography.GetConstituencies)
+				r.Get("/boundary", h.Geography.Boundary(geo.LevelCounty))
 			})
 		})
 		r.Route("/constituencies", func(r chi.Router) {
@@ -91,15 +191,25 @@ func NewRouter(h *Handlers) *chi.Mux {
 				r.Get("/candidates", h.Geography.GetCandidates)
 				r.Get("/wards", h.Geography.GetWards)
 				r.Get("/polling-stations", h.Geography.GetPollingStations)
+				r.Get("/boundary", h.Geography.Boundary(geo.LevelConstituency))
 			})
 		})
+		r.Route("/wards/{code}", func(r chi.Router) {
+			r.Get("/", h.Geography.GetWard)
+			r.Get("/boundary", h.Geography.Boundary(geo.LevelWard))
+		})
+		r.Get("/polling-stations/nearby", h.Geography.NearbyPollingStations)
+		r.Get("/locate", h.Geography.Locate)
 
 		// News
 		r.Route("/news", func(r chi.Router) {
 			r.Get("/", h.News.ListArticles)
+			r.Get("/stories", h.Story.List)
+			r.Get("/stories/{id}", h.Story.Get)
 			r.Get("/{id}", h.News.GetArticle)
 		})
 		r.Get("/sources", h.News.ListSources)
+		r.Get("/sources/status", h.NewsSource.Status)
 
 		// Analytics
 		r.Route("/analytics", func(r chi.Router) {
@@ -108,11 +218,36 @@ func NewRouter(h *Handlers) *chi.Mux {
 			r.Get("/integrity", h.Analytics.Integrity)
 			r.Get("/attendance", h.Analytics.Attendance)
 			r.Get("/trending", h.Analytics.Trending)
+			r.Get("/swing", h.Analytics.Swing)
 		})
 
 		// Timeline and events
 		r.Get("/timeline", h.Timeline.GetTimeline)
 		r.Get("/events", h.Timeline.ListEvents)
+
+		// Editorial review queue
+		r.Route("/submissions", func(r chi.Router) {
+			r.Use(requireEditor)
+			r.Get("/", h.Submission.List)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", h.Submission.Get)
+				r.Post("/review", h.Submission.StartReview)
+				r.Post("/approve", h.Submission.Approve)
+				r.Post("/reject", h.Submission.Reject)
+			})
+		})
+
+		// Admin
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(requireEditor)
+			r.Get("/api-keys", h.APIKey.List)
+			r.Post("/api-keys", h.APIKey.Issue)
+			r.Delete("/api-keys/{id}", h.APIKey.Revoke)
+			r.Get("/news-sources", h.NewsSource.List)
+			r.Patch("/news-sources/{id}", h.NewsSource.Update)
+			r.Get("/news-sources/{id}/fetches", h.NewsSource.Fetches)
+			r.Put("/news-sources/{id}/scraper", h.NewsSource.SetScraper)
+		})
 	})
 
 	return r
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"jalada/internal/models"
+	"jalada/internal/repository"
+)
+
+const maxSearchQueryLen = 200
+
+type SearchHandler struct {
+	repo *repository.SearchRepo
+}
+
+func NewSearchHandler(repo *repository.SearchRepo) *SearchHandler {
+	return &SearchHandler{repo: repo}
+}
+
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	filter := models.SearchFilter{
+		Query: strings.TrimSpace(q.Get("q")),
+		Limit: 5,
+	}
+	if filter.Query == "" {
+		writeError(w, http.StatusBadRequest, "q is required")
+		return
+	}
+	if len(filter.Query) > maxSearchQueryLen {
+		writeError(w, http.StatusBadRequest, "q must be at most 200 characters")
+		return
+	}
+	if v := q.Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= 50 {
+			filter.Limit = n
+		}
+	}
+
+	types, ok := parseTypes(q.Get("types"), repository.SearchTypes)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "types must be a comma-separated list of "+strings.Join(repository.SearchTypes, ", "))
+		return
+	}
+	filter.Types = types
+
+	results, err := h.repo.Search(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to search")
+		return
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+// parseTypes splits a comma-separated types parameter, rejecting values not
+// in allowed. An empty parameter yields nil.
+func parseTypes(v string, allowed []string) ([]string, bool) {
+	if v == "" {
+		return nil, true
+	}
+	var types []string
+	for _, t := range strings.Split(v, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		found := false
+		for _, a := range allowed {
+			if t == a {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+		types = append(types, t)
+	}
+	return types, true
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"jalada/internal/models"
+	"jalada/internal/repository"
+)
+
+type StoryHandler struct {
+	repo *repository.StoryRepo
+}
+
+func NewStoryHandler(repo *repository.StoryRepo) *StoryHandler {
+	return &StoryHandler{repo: repo}
+}
+
+func (h *StoryHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePagination(r)
+	q := r.URL.Query()
+
+	filter := models.StoryFilter{
+		ElectionRelated: q.Get("election_related") == "true",
+		Sort:            q.Get("sort"),
+		Limit:           limit,
+		Offset:          offset,
+	}
+
+	since, ok := queryDate(w, r, "since")
+	if !ok {
+		return
+	}
+	filter.Since = since
+
+	minOutlets, ok := queryInt(w, r, "min_outlets")
+	if !ok {
+		return
+	}
+	if minOutlets != nil {
+		if *minOutlets < 1 {
+			writeError(w, http.StatusBadRequest, "min_outlets must be at least 1")
+			return
+		}
+		filter.MinOutlets = *minOutlets
+	}
+
+	switch filter.Sort {
+	case "":
+		filter.Sort = "recent"
+	case "recent", "coverage":
+	default:
+		writeError(w, http.StatusBadRequest, "sort must be recent or coverage")
+		return
+	}
+
+	stories, total, err := h.repo.List(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list stories")
+		return
+	}
+	if stories == nil {
+		stories = []models.StoryCluster{}
+	}
+	writeJSON(w, http.StatusOK, models.NewPaginatedResponse(stories, total, limit, offset))
+}
+
+func (h *StoryHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid story id")
+		return
+	}
+
+	story, err := h.repo.Get(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get story")
+		return
+	}
+	if story == nil {
+		writeError(w, http.StatusNotFound, "story not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, story)
+}
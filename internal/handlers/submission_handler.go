@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"jalada/internal/middleware"
+	"jalada/internal/models"
+	"jalada/internal/services"
+)
+
+type SubmissionHandler struct {
+	svc         *services.SubmissionService
+	politicians *services.PoliticianService
+}
+
+func NewSubmissionHandler(svc *services.SubmissionService, politicians *services.PoliticianService) *SubmissionHandler {
+	return &SubmissionHandler{svc: svc, politicians: politicians}
+}
+
+type submitEditRequest struct {
+	Collection     string          `json:"collection"`
+	Operation      string          `json:"operation"`
+	RecordID       *uuid.UUID      `json:"record_id"`
+	Payload        json.RawMessage `json:"payload"`
+	Summary        string          `json:"summary"`
+	EvidenceURL    string          `json:"evidence_url"`
+	EvidenceName   *string         `json:"evidence_name"`
+	EvidenceType   string          `json:"evidence_type"`
+	SubmitterName  *string         `json:"submitter_name"`
+	SubmitterEmail *string         `json:"submitter_email"`
+}
+
+type reviewRequest struct {
+	Comment *string `json:"comment"`
+}
+
+func (h *SubmissionHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	p, err := h.politicians.GetBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to find politician")
+		return
+	}
+	if p == nil {
+		writeError(w, http.StatusNotFound, "politician not found")
+		return
+	}
+
+	var req submitEditRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	sub := models.EditSubmission{
+		PoliticianID:   p.ID,
+		Collection:     req.Collection,
+		Operation:      req.Operation,
+		RecordID:       req.RecordID,
+		Payload:        req.Payload,
+		Summary:        req.Summary,
+		EvidenceURL:    req.EvidenceURL,
+		EvidenceName:   req.EvidenceName,
+		EvidenceType:   req.EvidenceType,
+		SubmitterName:  req.SubmitterName,
+		SubmitterEmail: req.SubmitterEmail,
+	}
+	if err := h.svc.Submit(r.Context(), &sub); err != nil {
+		writeWriteError(w, err, "submission")
+		return
+	}
+	sub.PoliticianName = p.FirstName + " " + p.LastName
+	writeJSON(w, http.StatusAccepted, sub)
+}
+
+func (h *SubmissionHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePagination(r)
+	filter := models.SubmissionFilter{
+		Status: r.URL.Query().Get("status"),
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	submissions, total, err := h.svc.List(r.Context(), filter)
+	var verr *services.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list submissions")
+		return
+	}
+	if submissions == nil {
+		submissions = []models.EditSubmission{}
+	}
+	writeJSON(w, http.StatusOK, models.NewPaginatedResponse(submissions, total, limit, offset))
+}
+
+func (h *SubmissionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseRecordID(w, r, "submission")
+	if !ok {
+		return
+	}
+	sub, err := h.svc.Get(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get submission")
+		return
+	}
+	if sub == nil {
+		writeError(w, http.StatusNotFound, "submission not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, sub)
+}
+
+func (h *SubmissionHandler) StartReview(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseRecordID(w, r, "submission")
+	if !ok {
+		return
+	}
+	sub, err := h.svc.StartReview(r.Context(), id, middleware.Editor(r.Context()))
+	if err != nil {
+		writeWriteError(w, err, "submission")
+		return
+	}
+	writeJSON(w, http.StatusOK, sub)
+}
+
+func (h *SubmissionHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	id, req, ok := h.parseReview(w, r)
+	if !ok {
+		return
+	}
+	sub, err := h.svc.Approve(r.Context(), id, middleware.Editor(r.Context()), req.Comment)
+	if err != nil {
+		writeWriteError(w, err, "submission")
+		return
+	}
+	writeJSON(w, http.StatusOK, sub)
+}
+
+func (h *SubmissionHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	id, req, ok := h.parseReview(w, r)
+	if !ok {
+		return
+	}
+	sub, err := h.svc.Reject(r.Context(), id, middleware.Editor(r.Context()), req.Comment)
+	if err != nil {
+		writeWriteError(w, err, "submission")
+		return
+	}
+	writeJSON(w, http.StatusOK, sub)
+}
+
+// parseReview reads the submission id and the optional review comment. An
+// empty body is allowed.
+func (h *SubmissionHandler) parseReview(w http.ResponseWriter, r *http.Request) (uuid.UUID, reviewRequest, bool) {
+	var req reviewRequest
+	id, ok := parseRecordID(w, r, "submission")
+	if !ok {
+		return id, req, false
+	}
+	if r.ContentLength != 0 && !decodeJSON(w, r, &req) {
+		return id, req, false
+	}
+	return id, req, true
+}
@@ -0,0 +1,156 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// table is a parsed register file with normalised header names.
+type table struct {
+	columns map[string]int
+	rows    []row
+}
+
+type row struct {
+	line   int
+	fields []string
+}
+
+// readTable parses a CSV register file. Files exported from Excel often
+// carry a UTF-8 byte order mark and use semicolons or tabs as the
+// delimiter, so both are detected from the header line.
+func readTable(r io.Reader) (*table, error) {
+	br := bufio.NewReader(r)
+	if bom, err := br.Peek(3); err == nil && bytes.Equal(bom, []byte{0xEF, 0xBB, 0xBF}) {
+		_, _ = br.Discard(3)
+	}
+	first, err := br.Peek(br.Size())
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+	if i := bytes.IndexByte(first, '\n'); i >= 0 {
+		first = first[:i]
+	}
+
+	cr := csv.NewReader(br)
+	cr.Comma = sniffDelimiter(first)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	t := &table{columns: make(map[string]int, len(header))}
+	for i, h := range header {
+		t.columns[normaliseHeader(h)] = i
+	}
+
+	for {
+		fields, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		if blank(fields) {
+			continue
+		}
+		t.rows = append(t.rows, row{line: line, fields: fields})
+	}
+	return t, nil
+}
+
+func sniffDelimiter(header []byte) rune {
+	best, bestCount := ',', bytes.Count(header, []byte{','})
+	for _, d := range []rune{';', '\t'} {
+		if n := bytes.Count(header, []byte(string(d))); n > bestCount {
+			best, bestCount = d, n
+		}
+	}
+	return best
+}
+
+// normaliseHeader lowercases a header and collapses punctuation and spaces
+// to underscores, so "Ward Code", "WARD_CODE" and "ward-code" all match.
+func normaliseHeader(h string) string {
+	var b strings.Builder
+	sep := false
+	for _, r := range strings.ToLower(strings.TrimSpace(h)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			sep = false
+		} else if b.Len() > 0 && !sep {
+			b.WriteByte('_')
+			sep = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+// column returns the index of the first header matching one of names, or
+// -1 if the file has none of them.
+func (t *table) column(names ...string) int {
+	for _, n := range names {
+		if i, ok := t.columns[n]; ok {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r row) get(i int) string {
+	if i < 0 || i >= len(r.fields) {
+		return ""
+	}
+	return strings.TrimSpace(r.fields[i])
+}
+
+func blank(fields []string) bool {
+	for _, f := range fields {
+		if strings.TrimSpace(f) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// normaliseCode restores leading zeros that spreadsheets strip from
+// numeric codes, and the ".0" suffix they sometimes add.
+func normaliseCode(v string, width int) string {
+	v = strings.TrimSuffix(strings.TrimSpace(v), ".0")
+	if v == "" {
+		return ""
+	}
+	if _, err := strconv.ParseUint(v, 10, 64); err == nil && len(v) < width {
+		v = strings.Repeat("0", width-len(v)) + v
+	}
+	return v
+}
+
+// parseCount reads a non-negative count, allowing thousands separators.
+// An empty value is unknown and returns nil.
+func parseCount(v string) (*int, error) {
+	v = strings.NewReplacer(",", "", " ", "", "_", "").Replace(v)
+	v = strings.TrimSuffix(v, ".0")
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("invalid count %q", v)
+	}
+	return &n, nil
+}
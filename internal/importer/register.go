@@ -0,0 +1,477 @@
+// Package importer loads IEBC register files (wards and polling stations)
+// into the geography tables.
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"jalada/internal/slug"
+)
+
+// Code widths used by IEBC. Spreadsheet exports drop leading zeros, which
+// are restored on import.
+const (
+	countyCodeWidth       = 3
+	constituencyCodeWidth = 3
+	wardCodeWidth         = 4
+	stationCodeWidth      = 15
+)
+
+// Kenya's extent with a small margin. Coordinates outside it are almost
+// always swapped or mistyped.
+const (
+	minLat, maxLat = -5.0, 5.5
+	minLng, maxLng = 33.5, 42.5
+)
+
+// Rejection is a register row that was not imported.
+type Rejection struct {
+	Line   int    `json:"line"`
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
+
+// Report summarises one imported file.
+type Report struct {
+	Kind     string      `json:"kind"`
+	Rows     int         `json:"rows"`
+	Inserted int         `json:"inserted"`
+	Updated  int         `json:"updated"`
+	Rejected []Rejection `json:"rejected"`
+}
+
+func (r *Report) reject(line int, code, format string, args ...interface{}) {
+	r.Rejected = append(r.Rejected, Rejection{Line: line, Code: code, Reason: fmt.Sprintf(format, args...)})
+}
+
+// Files are the register files for one import. Either may be nil.
+type Files struct {
+	Wards    io.Reader
+	Stations io.Reader
+}
+
+type Importer struct {
+	pool *pgxpool.Pool
+}
+
+func New(pool *pgxpool.Pool) *Importer {
+	return &Importer{pool: pool}
+}
+
+// Run imports wards and then polling stations in one transaction, so a
+// station file can refer to wards from the ward file. Valid rows are
+// upserted by code and invalid ones are reported, not fatal. Registered
+// voters are only overwritten where the file gives a count; a new row
+// without one is stored as unknown. Counts are rolled up to the wards and
+// constituencies above them where every child has one. With dryRun the
+// transaction is rolled back after validation.
+func (im *Importer) Run(ctx context.Context, files Files, dryRun bool) ([]Report, error) {
+	tx, err := im.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin import: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var reports []Report
+	if files.Wards != nil {
+		report, err := importWards(ctx, tx, files.Wards)
+		if err != nil {
+			return nil, fmt.Errorf("import wards: %w", err)
+		}
+		reports = append(reports, *report)
+	}
+	if files.Stations != nil {
+		report, err := importStations(ctx, tx, files.Stations)
+		if err != nil {
+			return nil, fmt.Errorf("import polling stations: %w", err)
+		}
+		reports = append(reports, *report)
+	}
+
+	if dryRun {
+		return reports, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit import: %w", err)
+	}
+	return reports, nil
+}
+
+type constituencyRef struct {
+	id         uuid.UUID
+	slug       string
+	countyCode string
+}
+
+type wardRef struct {
+	id               uuid.UUID
+	constituencyCode string
+}
+
+func importWards(ctx context.Context, tx pgx.Tx, r io.Reader) (*Report, error) {
+	t, err := readTable(r)
+	if err != nil {
+		return nil, err
+	}
+	colCode := t.column("ward_code", "caw_code", "code")
+	colName := t.column("ward_name", "caw_name", "ward", "name")
+	colConst := t.column("constituency_code", "const_code", "constituency_no")
+	colCounty := t.column("county_code", "county_no")
+	colVoters := t.column("registered_voters", "reg_voters", "voters")
+	if colCode < 0 || colName < 0 || colConst < 0 {
+		return nil, fmt.Errorf("ward file needs ward_code, ward_name and constituency_code columns")
+	}
+
+	constituencies, err := loadConstituencies(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	slugs, err := loadWardSlugs(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	slugOf := make(map[string]string, len(slugs))
+	for s, code := range slugs {
+		slugOf[code] = s
+	}
+
+	report := &Report{Kind: "wards", Rows: len(t.rows)}
+	seenCode := make(map[string]int)
+	seenName := make(map[string]int)
+	touched := make(map[uuid.UUID]bool)
+
+	for _, row := range t.rows {
+		code := normaliseCode(row.get(colCode), wardCodeWidth)
+		name := row.get(colName)
+		constCode := normaliseCode(row.get(colConst), constituencyCodeWidth)
+
+		if code == "" {
+			report.reject(row.line, code, "missing ward code")
+			continue
+		}
+		if name == "" {
+			report.reject(row.line, code, "missing ward name")
+			continue
+		}
+		if first, ok := seenCode[code]; ok {
+			report.reject(row.line, code, "duplicate ward code, first seen on line %d", first)
+			continue
+		}
+		parent, ok := constituencies[constCode]
+		if !ok {
+			report.reject(row.line, code, "unknown constituency code %q", constCode)
+			continue
+		}
+		if colCounty >= 0 {
+			if county := normaliseCode(row.get(colCounty), countyCodeWidth); county != "" && county != parent.countyCode {
+				report.reject(row.line, code, "constituency %s is in county %s, not %s", constCode, parent.countyCode, county)
+				continue
+			}
+		}
+		wardSlug := slug.Make(name, parent.slug)
+		if first, ok := seenName[wardSlug]; ok {
+			report.reject(row.line, code, "duplicate ward name in constituency %s, first seen on line %d", constCode, first)
+			continue
+		}
+		if owner, ok := slugs[wardSlug]; ok && owner != code {
+			report.reject(row.line, code, "ward name clashes with ward %s, whose slug is also %q", owner, wardSlug)
+			continue
+		}
+		voters, err := parseCount(row.get(colVoters))
+		if err != nil {
+			report.reject(row.line, code, "registered_voters: %v", err)
+			continue
+		}
+		seenCode[code] = row.line
+		seenName[wardSlug] = row.line
+		// The upsert frees the ward's old slug for the rows after it.
+		if old, ok := slugOf[code]; ok {
+			delete(slugs, old)
+		}
+		slugs[wardSlug], slugOf[code] = code, wardSlug
+
+		var inserted bool
+		err = tx.QueryRow(ctx,
+			`INSERT INTO wards (constituency_id, code, name, slug, registered_voters)
+			 VALUES ($1, $2, $3, $4, $5)
+			 ON CONFLICT (code) DO UPDATE SET
+			     constituency_id = EXCLUDED.constituency_id,
+			     name = EXCLUDED.name,
+			     slug = EXCLUDED.slug,
+			     registered_voters = COALESCE($5, wards.registered_voters)
+			 RETURNING xmax = 0`,
+			parent.id, code, name, wardSlug, voters,
+		).Scan(&inserted)
+		if err != nil {
+			return nil, fmt.Errorf("upsert ward %s (line %d): %w", code, row.line, err)
+		}
+		if inserted {
+			report.Inserted++
+		} else {
+			report.Updated++
+		}
+		if voters != nil {
+			touched[parent.id] = true
+		}
+	}
+
+	if err := rollUpConstituencies(ctx, tx, keys(touched)); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func importStations(ctx context.Context, tx pgx.Tx, r io.Reader) (*Report, error) {
+	t, err := readTable(r)
+	if err != nil {
+		return nil, err
+	}
+	colCode := t.column("polling_station_code", "station_code", "ps_code", "code")
+	colName := t.column("polling_station_name", "station_name", "ps_name", "polling_station", "name")
+	colWard := t.column("ward_code", "caw_code")
+	colConst := t.column("constituency_code", "const_code")
+	colLat := t.column("latitude", "lat")
+	colLng := t.column("longitude", "lng", "lon", "long")
+	colVoters := t.column("registered_voters", "reg_voters", "voters")
+	if colCode < 0 || colName < 0 || colWard < 0 {
+		return nil, fmt.Errorf("polling station file needs polling_station_code, polling_station_name and ward_code columns")
+	}
+
+	wards, err := loadWards(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Kind: "polling_stations", Rows: len(t.rows)}
+	seen := make(map[string]int)
+	touched := make(map[uuid.UUID]bool)
+
+	for _, row := range t.rows {
+		code := normaliseCode(row.get(colCode), stationCodeWidth)
+		name := row.get(colName)
+		wardCode := normaliseCode(row.get(colWard), wardCodeWidth)
+
+		if code == "" {
+			report.reject(row.line, code, "missing polling station code")
+			continue
+		}
+		if name == "" {
+			report.reject(row.line, code, "missing polling station name")
+			continue
+		}
+		if first, ok := seen[code]; ok {
+			report.reject(row.line, code, "duplicate polling station code, first seen on line %d", first)
+			continue
+		}
+		ward, ok := wards[wardCode]
+		if !ok {
+			report.reject(row.line, code, "unknown ward code %q", wardCode)
+			continue
+		}
+		if colConst >= 0 {
+			if c := normaliseCode(row.get(colConst), constituencyCodeWidth); c != "" && c != ward.constituencyCode {
+				report.reject(row.line, code, "ward %s is in constituency %s, not %s", wardCode, ward.constituencyCode, c)
+				continue
+			}
+		}
+		lat, lng, err := parseCoordinates(row.get(colLat), row.get(colLng))
+		if err != nil {
+			report.reject(row.line, code, "%v", err)
+			continue
+		}
+		voters, err := parseCount(row.get(colVoters))
+		if err != nil {
+			report.reject(row.line, code, "registered_voters: %v", err)
+			continue
+		}
+		seen[code] = row.line
+
+		var inserted bool
+		err = tx.QueryRow(ctx,
+			`INSERT INTO polling_stations (ward_id, code, name, latitude, longitude, registered_voters)
+			 VALUES ($1, $2, $3, $4, $5, $6)
+			 ON CONFLICT (code) DO UPDATE SET
+			     ward_id = EXCLUDED.ward_id,
+			     name = EXCLUDED.name,
+			     latitude = COALESCE(EXCLUDED.latitude, polling_stations.latitude),
+			     longitude = COALESCE(EXCLUDED.longitude, polling_stations.longitude),
+			     registered_voters = COALESCE($6, polling_stations.registered_voters)
+			 RETURNING xmax = 0`,
+			ward.id, code, name, lat, lng, voters,
+		).Scan(&inserted)
+		if err != nil {
+			return nil, fmt.Errorf("upsert polling station %s (line %d): %w", code, row.line, err)
+		}
+		if inserted {
+			report.Inserted++
+		} else {
+			report.Updated++
+		}
+		if voters != nil {
+			touched[ward.id] = true
+		}
+	}
+
+	if err := rollUpWards(ctx, tx, keys(touched)); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// parseCoordinates reads an optional coordinate pair. Both or neither must
+// be present, and they must fall inside Kenya.
+func parseCoordinates(latStr, lngStr string) (*float64, *float64, error) {
+	if latStr == "" && lngStr == "" {
+		return nil, nil, nil
+	}
+	if latStr == "" || lngStr == "" {
+		return nil, nil, fmt.Errorf("latitude and longitude must be given together")
+	}
+	lat, err := strconv.ParseFloat(latStr, 64)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid latitude %q", latStr)
+	}
+	lng, err := strconv.ParseFloat(lngStr, 64)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid longitude %q", lngStr)
+	}
+	if lat < minLat || lat > maxLat || lng < minLng || lng > maxLng {
+		return nil, nil, fmt.Errorf("coordinates %g, %g are outside Kenya", lat, lng)
+	}
+	return &lat, &lng, nil
+}
+
+func loadConstituencies(ctx context.Context, tx pgx.Tx) (map[string]constituencyRef, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT c.code, c.id, c.slug, co.code FROM constituencies c JOIN counties co ON co.id = c.county_id`)
+	if err != nil {
+		return nil, fmt.Errorf("load constituencies: %w", err)
+	}
+	defer rows.Close()
+
+	refs := make(map[string]constituencyRef)
+	for rows.Next() {
+		var code string
+		var ref constituencyRef
+		if err := rows.Scan(&code, &ref.id, &ref.slug, &ref.countyCode); err != nil {
+			return nil, fmt.Errorf("scan constituency: %w", err)
+		}
+		refs[code] = ref
+	}
+	return refs, rows.Err()
+}
+
+// loadWardSlugs maps each ward slug in use to the code of its ward. Slugs
+// are unique across all wards, so a row whose slug belongs to another code
+// is rejected rather than left to fail the upsert.
+func loadWardSlugs(ctx context.Context, tx pgx.Tx) (map[string]string, error) {
+	rows, err := tx.Query(ctx, `SELECT slug, code FROM wards`)
+	if err != nil {
+		return nil, fmt.Errorf("load ward slugs: %w", err)
+	}
+	defer rows.Close()
+
+	slugs := make(map[string]string)
+	for rows.Next() {
+		var wardSlug, code string
+		if err := rows.Scan(&wardSlug, &code); err != nil {
+			return nil, fmt.Errorf("scan ward slug: %w", err)
+		}
+		slugs[wardSlug] = code
+	}
+	return slugs, rows.Err()
+}
+
+func loadWards(ctx context.Context, tx pgx.Tx) (map[string]wardRef, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT w.code, w.id, c.code FROM wards w JOIN constituencies c ON c.id = w.constituency_id`)
+	if err != nil {
+		return nil, fmt.Errorf("load wards: %w", err)
+	}
+	defer rows.Close()
+
+	refs := make(map[string]wardRef)
+	for rows.Next() {
+		var code string
+		var ref wardRef
+		if err := rows.Scan(&code, &ref.id, &ref.constituencyCode); err != nil {
+			return nil, fmt.Errorf("scan ward: %w", err)
+		}
+		refs[code] = ref
+	}
+	return refs, rows.Err()
+}
+
+// rollUpWards sets each ward's registered voters to the sum of its polling
+// stations, then rolls the wards up to their constituencies. A ward with a
+// station of unknown size keeps its own figure.
+func rollUpWards(ctx context.Context, tx pgx.Tx, wardIDs []uuid.UUID) error {
+	if len(wardIDs) == 0 {
+		return nil
+	}
+	var constituencyIDs []uuid.UUID
+	rows, err := tx.Query(ctx,
+		`UPDATE wards w SET registered_voters = s.total
+		 FROM (SELECT ward_id, SUM(registered_voters) AS total
+		       FROM polling_stations WHERE ward_id = ANY($1)
+		       GROUP BY ward_id
+		       HAVING COUNT(*) = COUNT(registered_voters)) s
+		 WHERE w.id = s.ward_id
+		 RETURNING w.constituency_id`,
+		wardIDs,
+	)
+	if err != nil {
+		return fmt.Errorf("roll up ward voters: %w", err)
+	}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan ward constituency: %w", err)
+		}
+		constituencyIDs = append(constituencyIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("roll up ward voters: %w", err)
+	}
+	return rollUpConstituencies(ctx, tx, constituencyIDs)
+}
+
+// rollUpConstituencies gives constituencies without a registered voter
+// figure the sum of their wards, once every ward has a count. A figure
+// already set is left alone: the importer cannot tell whether a file
+// covers all of a constituency's wards, so the sum may be partial.
+func rollUpConstituencies(ctx context.Context, tx pgx.Tx, constituencyIDs []uuid.UUID) error {
+	if len(constituencyIDs) == 0 {
+		return nil
+	}
+	_, err := tx.Exec(ctx,
+		`UPDATE constituencies c SET registered_voters = s.total
+		 FROM (SELECT constituency_id, SUM(registered_voters) AS total
+		       FROM wards WHERE constituency_id = ANY($1)
+		       GROUP BY constituency_id
+		       HAVING COUNT(*) = COUNT(registered_voters)) s
+		 WHERE c.id = s.constituency_id AND COALESCE(c.registered_voters, 0) = 0`,
+		constituencyIDs,
+	)
+	if err != nil {
+		return fmt.Errorf("roll up constituency voters: %w", err)
+	}
+	return nil
+}
+
+func keys(m map[uuid.UUID]bool) []uuid.UUID {
+	out := make([]uuid.UUID, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
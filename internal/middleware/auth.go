@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"jalada/internal/audit"
+	"jalada/internal/models"
+)
+
+const (
+	EditorKey      contextKey = "editor"
+	SourceIDHeader            = "X-Source-ID"
+)
+
+// RequireEditor rejects requests that do not carry one of the configured
+// editor tokens as a bearer token. The matching editor name is stored in
+// the request context under EditorKey and recorded as the audit actor,
+// along with the justifying source from the X-Source-ID header.
+func RequireEditor(tokens map[string]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok {
+				writeError(w, http.StatusUnauthorized, "missing bearer token")
+				return
+			}
+
+			editor := ""
+			for t, name := range tokens {
+				if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+					editor = name
+				}
+			}
+			if editor == "" {
+				writeError(w, http.StatusForbidden, "invalid editor token")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), EditorKey, editor)
+			ctx = audit.WithActor(ctx, editor)
+			if v := r.Header.Get(SourceIDHeader); v != "" {
+				sourceID, err := uuid.Parse(v)
+				if err != nil {
+					writeError(w, http.StatusBadRequest, "invalid "+SourceIDHeader+" header")
+					return
+				}
+				ctx = audit.WithSource(ctx, sourceID)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Editor returns the authenticated editor name, if any.
+func Editor(ctx context.Context) string {
+	editor, _ := ctx.Value(EditorKey).(string)
+	return editor
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(models.ErrorResponse{
+		Error:   http.StatusText(status),
+		Code:    status,
+		Message: message,
+	})
+}
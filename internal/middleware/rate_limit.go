@@ -1,50 +1,346 @@
 package middleware
 
 import (
+	"context"
+	"net"
 	"net/http"
+	"strconv"
 	"sync"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
 	"golang.org/x/time/rate"
+
+	"jalada/internal/models"
+	"jalada/internal/repository"
+)
+
+const (
+	APIKeyHeader            = "X-API-Key"
+	APIKeyKey    contextKey = "api_key"
+
+	keyCacheTTL   = time.Minute
+	usageFlush    = 30 * time.Second
+	callerIdleTTL = time.Hour
 )
 
-type ipLimiter struct {
-	limiters map[string]*rate.Limiter
-	mu       sync.Mutex
-	rate     rate.Limit
-	burst    int
+// Tier is the request rate and daily quota applied to a caller. A
+// DailyQuota of zero means unlimited.
+type Tier struct {
+	Name       string
+	Rate       float64
+	Burst      int
+	DailyQuota int
+}
+
+// DefaultTiers keeps anonymous callers on the 100 requests a second, burst
+// 200, that every caller had before keys existed. A key's main benefit is
+// a bucket of its own rather than one shared with its whole address.
+var DefaultTiers = map[string]Tier{
+	"anonymous":  {Name: "anonymous", Rate: 100, Burst: 200, DailyQuota: 0},
+	"registered": {Name: "registered", Rate: 100, Burst: 200, DailyQuota: 0},
+	"partner":    {Name: "partner", Rate: 500, Burst: 1000, DailyQuota: 0},
 }
 
-func newIPLimiter(r rate.Limit, burst int) *ipLimiter {
-	return &ipLimiter{
-		limiters: make(map[string]*rate.Limiter),
-		rate:     r,
-		burst:    burst,
+// WithAnonymous returns a copy of tiers with the anonymous tier replaced.
+func WithAnonymous(tiers map[string]Tier, anonymous Tier) map[string]Tier {
+	out := make(map[string]Tier, len(tiers))
+	for name, t := range tiers {
+		out[name] = t
 	}
+	anonymous.Name = "anonymous"
+	out["anonymous"] = anonymous
+	return out
 }
 
-func (l *ipLimiter) getLimiter(ip string) *rate.Limiter {
-	l.mu.Lock()
-	defer l.mu.Unlock()
+// KeyStore is the subset of the API key repository the limiter needs.
+type KeyStore interface {
+	GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	GetUsage(ctx context.Context, id uuid.UUID, day time.Time) (int, error)
+	AddUsage(ctx context.Context, day time.Time, counts map[uuid.UUID]int) error
+}
+
+type caller struct {
+	limiter  *rate.Limiter
+	tier     Tier
+	day      time.Time
+	used     int
+	lastSeen time.Time
+}
 
-	limiter, exists := l.limiters[ip]
-	if !exists {
-		limiter = rate.NewLimiter(l.rate, l.burst)
-		l.limiters[ip] = limiter
+type cachedKey struct {
+	key       *models.APIKey
+	fetchedAt time.Time
+}
+
+// RateLimiter applies per-caller token buckets and daily quotas. Callers
+// with a valid X-API-Key are limited per key using the key's tier; everyone
+// else is limited per client IP on the anonymous tier. Key usage is kept in
+// memory and flushed to Postgres periodically by Start.
+type RateLimiter struct {
+	store KeyStore
+	tiers map[string]Tier
+
+	mu      sync.Mutex
+	callers map[string]*caller
+	keys    map[string]cachedKey
+	pending map[time.Time]map[uuid.UUID]int
+}
+
+func NewRateLimiter(store KeyStore, tiers map[string]Tier) *RateLimiter {
+	return &RateLimiter{
+		store:   store,
+		tiers:   tiers,
+		callers: make(map[string]*caller),
+		keys:    make(map[string]cachedKey),
+		pending: make(map[time.Time]map[uuid.UUID]int),
 	}
-	return limiter
 }
 
-func RateLimit(rps float64, burst int) func(http.Handler) http.Handler {
-	limiter := newIPLimiter(rate.Limit(rps), burst)
+func (l *RateLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		subject := "ip:" + clientIP(r)
+		tier := l.tiers["anonymous"]
+		today := utcDay(time.Now())
+		var key *models.APIKey
 
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ip := r.RemoteAddr
-			if !limiter.getLimiter(ip).Allow() {
-				http.Error(w, `{"error":"rate limit exceeded"}`, http.StatusTooManyRequests)
+		if raw := r.Header.Get(APIKeyHeader); raw != "" {
+			k, limited, err := l.lookupKey(ctx, raw, subject, tier, today)
+			if err != nil {
+				log.Error().Err(err).Msg("failed to look up api key")
+				writeError(w, http.StatusInternalServerError, "failed to verify api key")
 				return
 			}
-			next.ServeHTTP(w, r)
-		})
+			if limited {
+				w.Header().Set("Retry-After", "1")
+				writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+				return
+			}
+			if k == nil || k.RevokedAt != nil {
+				writeError(w, http.StatusUnauthorized, "invalid or revoked api key")
+				return
+			}
+			key = k
+			subject = "key:" + k.ID.String()
+			tier = keyTier(l.tiers[k.Tier], k)
+		}
+
+		c, err := l.caller(ctx, subject, tier, key, today)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to load api key usage")
+			writeError(w, http.StatusInternalServerError, "failed to check quota")
+			return
+		}
+
+		l.mu.Lock()
+		quotaExceeded := c.tier.DailyQuota > 0 && c.used >= c.tier.DailyQuota
+		allowed := !quotaExceeded && c.limiter.Allow()
+		if allowed {
+			c.used++
+			if key != nil {
+				l.recordUsage(today, key.ID)
+			}
+		}
+		used := c.used
+		l.mu.Unlock()
+
+		setRateLimitHeaders(w, c.tier, used, today)
+
+		if quotaExceeded {
+			w.Header().Set("Retry-After", strconv.Itoa(int(time.Until(today.AddDate(0, 0, 1)).Seconds())+1))
+			writeError(w, http.StatusTooManyRequests, "daily quota exceeded")
+			return
+		}
+		if !allowed {
+			w.Header().Set("Retry-After", "1")
+			writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+
+		if key != nil {
+			ctx = context.WithValue(ctx, APIKeyKey, key)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Start flushes key usage to the store until ctx is cancelled.
+func (l *RateLimiter) Start(ctx context.Context) {
+	ticker := time.NewTicker(usageFlush)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			l.flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			l.flush(ctx)
+		}
+	}
+}
+
+func (l *RateLimiter) flush(ctx context.Context) {
+	l.mu.Lock()
+	pending := l.pending
+	l.pending = make(map[time.Time]map[uuid.UUID]int)
+	cutoff := time.Now().Add(-callerIdleTTL)
+	for subject, c := range l.callers {
+		if c.lastSeen.Before(cutoff) {
+			delete(l.callers, subject)
+		}
+	}
+	for hash, k := range l.keys {
+		if time.Since(k.fetchedAt) >= keyCacheTTL {
+			delete(l.keys, hash)
+		}
+	}
+	l.mu.Unlock()
+
+	for day, counts := range pending {
+		if err := l.store.AddUsage(ctx, day, counts); err != nil {
+			log.Error().Err(err).Int("keys", len(counts)).Msg("failed to flush api key usage")
+		}
 	}
 }
+
+func (l *RateLimiter) recordUsage(day time.Time, id uuid.UUID) {
+	counts, ok := l.pending[day]
+	if !ok {
+		counts = make(map[uuid.UUID]int)
+		l.pending[day] = counts
+	}
+	counts[id]++
+}
+
+// lookupKey returns the key for raw. A key that is not cached costs the
+// client a request from its anonymous limiter before the store is asked,
+// so made-up keys are limited like anonymous callers; limited reports that
+// the limiter refused.
+func (l *RateLimiter) lookupKey(ctx context.Context, raw, subject string, tier Tier, today time.Time) (key *models.APIKey, limited bool, err error) {
+	hash := repository.HashAPIKey(raw)
+
+	l.mu.Lock()
+	cached, ok := l.keys[hash]
+	l.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < keyCacheTTL {
+		return cached.key, false, nil
+	}
+
+	c, err := l.caller(ctx, subject, tier, nil, today)
+	if err != nil {
+		return nil, false, err
+	}
+	l.mu.Lock()
+	allowed := c.limiter.Allow()
+	l.mu.Unlock()
+	if !allowed {
+		return nil, true, nil
+	}
+
+	key, err = l.store.GetByHash(ctx, hash)
+	if err != nil || key == nil {
+		return nil, false, err
+	}
+
+	// Only keys that exist are cached, so made-up keys cannot fill it.
+	l.mu.Lock()
+	l.keys[hash] = cachedKey{key: key, fetchedAt: time.Now()}
+	l.mu.Unlock()
+	return key, false, nil
+}
+
+// caller returns the limiter state for subject, resetting the daily count
+// when the UTC day rolls over. Key usage already recorded for today is
+// loaded from the store the first time a key is seen.
+func (l *RateLimiter) caller(ctx context.Context, subject string, tier Tier, key *models.APIKey, today time.Time) (*caller, error) {
+	l.mu.Lock()
+	c, ok := l.callers[subject]
+	if ok && c.day.Equal(today) {
+		c.lastSeen = time.Now()
+		if c.tier != tier {
+			c.tier = tier
+			c.limiter.SetLimit(rate.Limit(tier.Rate))
+			c.limiter.SetBurst(tier.Burst)
+		}
+		l.mu.Unlock()
+		return c, nil
+	}
+	l.mu.Unlock()
+
+	used := 0
+	if key != nil {
+		var err error
+		used, err = l.store.GetUsage(ctx, key.ID, today)
+		if err != nil {
+			return nil, err
+		}
+		used += l.pendingUsage(today, key.ID)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if c, ok = l.callers[subject]; ok && c.day.Equal(today) {
+		return c, nil
+	}
+	c = &caller{
+		limiter:  rate.NewLimiter(rate.Limit(tier.Rate), tier.Burst),
+		tier:     tier,
+		day:      today,
+		used:     used,
+		lastSeen: time.Now(),
+	}
+	l.callers[subject] = c
+	return c, nil
+}
+
+func (l *RateLimiter) pendingUsage(day time.Time, id uuid.UUID) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.pending[day][id]
+}
+
+// keyTier applies a key's own rate, burst and quota over its tier defaults.
+func keyTier(t Tier, k *models.APIKey) Tier {
+	if k.RateLimit != nil {
+		t.Rate = *k.RateLimit
+	}
+	if k.Burst != nil {
+		t.Burst = *k.Burst
+	}
+	if k.DailyQuota != nil {
+		t.DailyQuota = *k.DailyQuota
+	}
+	return t
+}
+
+func setRateLimitHeaders(w http.ResponseWriter, t Tier, used int, today time.Time) {
+	h := w.Header()
+	h.Set("X-RateLimit-Tier", t.Name)
+	if t.DailyQuota == 0 {
+		return
+	}
+	remaining := t.DailyQuota - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	h.Set("X-RateLimit-Limit", strconv.Itoa(t.DailyQuota))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(today.AddDate(0, 0, 1).Unix(), 10))
+}
+
+func clientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+func utcDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	OwnerEmail *string    `json:"owner_email,omitempty"`
+	Prefix     string     `json:"prefix"`
+	Tier       string     `json:"tier"`
+	RateLimit  *float64   `json:"rate_limit,omitempty"`
+	Burst      *int       `json:"burst,omitempty"`
+	DailyQuota *int       `json:"daily_quota,omitempty"`
+	CreatedBy  *string    `json:"created_by,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	UsageToday int        `json:"usage_today"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// IssuedAPIKey is returned once, when a key is created. Only the hash of
+// Key is stored.
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Revision is one entry in the audit log: a single insert, update or
+// delete of a dossier record.
+type Revision struct {
+	ID            int64           `json:"id"`
+	Table         string          `json:"table"`
+	RecordID      uuid.UUID       `json:"record_id"`
+	PoliticianID  *uuid.UUID      `json:"politician_id,omitempty"`
+	Action        string          `json:"action"`
+	Actor         string          `json:"actor"`
+	SourceID      *uuid.UUID      `json:"source_id,omitempty"`
+	SourceName    *string         `json:"source_name,omitempty"`
+	SourceURL     *string         `json:"source_url,omitempty"`
+	ChangedFields []string        `json:"changed_fields"`
+	OldData       json.RawMessage `json:"old_data,omitempty"`
+	NewData       json.RawMessage `json:"new_data,omitempty"`
+	ChangedAt     time.Time       `json:"changed_at"`
+}
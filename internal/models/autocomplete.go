@@ -0,0 +1,17 @@
+package models
+
+import "github.com/google/uuid"
+
+// Suggestion is an autocomplete match. Popularity is article mentions for
+// politicians, current members for parties and registered voters for
+// places.
+type Suggestion struct {
+	Type       string    `json:"type"`
+	ID         uuid.UUID `json:"id"`
+	Label      string    `json:"label"`
+	Subtitle   *string   `json:"subtitle,omitempty"`
+	Key        string    `json:"key"`
+	URL        string    `json:"url"`
+	Popularity int       `json:"popularity"`
+	Score      float64   `json:"score"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// BoundaryMatch is the chain of areas whose boundaries contain a point.
+// Levels without loaded boundaries, or outside every boundary, are null.
+type BoundaryMatch struct {
+	Lat          float64  `json:"lat"`
+	Lng          float64  `json:"lng"`
+	Ward         *AreaRef `json:"ward"`
+	Constituency *AreaRef `json:"constituency"`
+	County       *AreaRef `json:"county"`
+}
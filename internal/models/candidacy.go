@@ -26,4 +26,103 @@ type CandidacyDetail struct {
 	PartyName      *string `json:"party_name,omitempty"`
 	PositionTitle  string  `json:"position_title"`
 	ElectionName   string  `json:"election_name"`
+
+	// StatusReason and StatusChangedOn come from the latest status event
+	// and are only filled in election candidate listings.
+	StatusReason    *string    `json:"status_reason,omitempty"`
+	StatusChangedOn *time.Time `json:"status_changed_on,omitempty"`
+
+	RunningMate *RunningMate `json:"running_mate,omitempty"`
+}
+
+// RunningMate is the other half of a joint ticket. Role is the running
+// mate's own role: the deputy of a principal, or the principal of a
+// deputy.
+type RunningMate struct {
+	CandidacyID  uuid.UUID `json:"candidacy_id"`
+	PoliticianID uuid.UUID `json:"politician_id"`
+	Slug         string    `json:"slug"`
+	Name         string    `json:"name"`
+	Status       string    `json:"status"`
+	Role         string    `json:"role"`
+}
+
+// JointTicket pairs a presidential or gubernatorial candidacy with its
+// running mate's. Votes cast for the principal count for the ticket.
+type JointTicket struct {
+	ID                   uuid.UUID   `json:"id"`
+	ElectionID           uuid.UUID   `json:"election_id"`
+	PrincipalCandidacyID uuid.UUID   `json:"principal_candidacy_id"`
+	DeputyCandidacyID    uuid.UUID   `json:"deputy_candidacy_id"`
+	Title                string      `json:"title"`
+	Area                 *AreaRef    `json:"area,omitempty"`
+	Party                *string     `json:"party,omitempty"`
+	Principal            RunningMate `json:"principal"`
+	Deputy               RunningMate `json:"deputy"`
+	NominatedOn          *time.Time  `json:"nominated_on,omitempty"`
+	SourceURL            *string     `json:"source_url,omitempty"`
+	CreatedAt            time.Time   `json:"created_at"`
+}
+
+// CandidacyEvent is one step in a candidacy's path from declaration to
+// the result: a change of status with the stage it happened at, why, and
+// the source that reported it.
+type CandidacyEvent struct {
+	ID          uuid.UUID  `json:"id"`
+	CandidacyID uuid.UUID  `json:"candidacy_id"`
+	Stage       string     `json:"stage"`
+	FromStatus  *string    `json:"from_status,omitempty"`
+	Status      string     `json:"status"`
+	Reason      *string    `json:"reason,omitempty"`
+	SourceURL   *string    `json:"source_url,omitempty"`
+	SourceID    *uuid.UUID `json:"source_id,omitempty"`
+	OccurredOn  time.Time  `json:"occurred_on"`
+	RecordedBy  string     `json:"recorded_by"`
+	RecordedAt  time.Time  `json:"recorded_at"`
+}
+
+// PartyPrimary is one aspirant's outcome in a party's nomination for a
+// seat. Losing aspirants usually never get a candidacy.
+type PartyPrimary struct {
+	ID             uuid.UUID  `json:"id"`
+	ElectionID     uuid.UUID  `json:"election_id"`
+	PositionID     uuid.UUID  `json:"position_id"`
+	PositionTitle  string     `json:"position_title"`
+	PartyID        uuid.UUID  `json:"party_id"`
+	PartyName      string     `json:"party_name"`
+	PoliticianID   uuid.UUID  `json:"politician_id"`
+	PoliticianName string     `json:"politician_name"`
+	PoliticianSlug string     `json:"politician_slug"`
+	Method         string     `json:"method"`
+	Outcome        string     `json:"outcome"`
+	Votes          *int       `json:"votes,omitempty"`
+	HeldOn         *time.Time `json:"held_on,omitempty"`
+	Notes          *string    `json:"notes,omitempty"`
+	SourceURL      *string    `json:"source_url,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// CandidacyTimeline is a candidacy with its party primaries and every
+// status change, oldest first.
+type CandidacyTimeline struct {
+	Candidacy CandidacyDetail  `json:"candidacy"`
+	Primaries []PartyPrimary   `json:"primaries"`
+	Events    []CandidacyEvent `json:"events"`
+}
+
+// CandidateFilter narrows an election's candidates. Party matches a slug
+// or abbreviation and County a county code.
+type CandidateFilter struct {
+	Statuses []string
+	Title    string
+	Party    string
+	County   string
+}
+
+type PrimaryFilter struct {
+	Outcomes []string
+	Title    string
+	Party    string
+	County   string
 }
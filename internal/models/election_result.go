@@ -16,11 +16,216 @@ type ElectionResult struct {
 	ReportedAt       time.Time  `json:"reported_at"`
 }
 
+// ResultSummary is a candidacy's total; Percentage is its share of the
+// votes cast for the same seat. A running mate's total is their
+// principal's.
 type ResultSummary struct {
 	CandidacyID    uuid.UUID `json:"candidacy_id"`
+	PositionID     uuid.UUID `json:"position_id"`
+	PositionTitle  string    `json:"position_title"`
 	PoliticianName string    `json:"politician_name"`
 	PartyName      *string   `json:"party_name,omitempty"`
 	TotalVotes     int       `json:"total_votes"`
 	Percentage     float64   `json:"percentage"`
 	IsFinal        bool      `json:"is_final"`
+
+	RunningMate *RunningMate `json:"running_mate,omitempty"`
+}
+
+// ResultTally is one results form for a seat at a reporting area. AreaCode
+// is the polling station, ward, constituency or county code for the level;
+// national tallies have none.
+type ResultTally struct {
+	ID               uuid.UUID   `json:"id"`
+	ElectionID       uuid.UUID   `json:"election_id"`
+	PositionID       uuid.UUID   `json:"position_id"`
+	Level            string      `json:"level"`
+	Form             *string     `json:"form,omitempty"`
+	AreaCode         *string     `json:"area_code,omitempty"`
+	AreaID           *uuid.UUID  `json:"area_id,omitempty"`
+	RegisteredVoters int         `json:"registered_voters"`
+	RejectedVotes    int         `json:"rejected_votes"`
+	ValidVotes       int         `json:"valid_votes"`
+	IsFinal          bool        `json:"is_final"`
+	IsCurrent        bool        `json:"is_current"`
+	ReportedBy       string      `json:"reported_by"`
+	SourceID         *uuid.UUID  `json:"source_id,omitempty"`
+	ReportedAt       time.Time   `json:"reported_at"`
+	Results          []TallyVote `json:"results"`
+}
+
+type TallyVote struct {
+	CandidacyID uuid.UUID `json:"candidacy_id"`
+	Votes       int       `json:"votes"`
+}
+
+// ResultFilter selects the reporting area results are aggregated over.
+// Code is empty for national results.
+type ResultFilter struct {
+	Level      string
+	Code       string
+	PositionID *uuid.UUID
+	Title      string
+}
+
+type CandidateResult struct {
+	CandidacyID  uuid.UUID `json:"candidacy_id"`
+	PoliticianID uuid.UUID `json:"politician_id"`
+	Slug         string    `json:"slug"`
+	Name         string    `json:"name"`
+	Party        *string   `json:"party,omitempty"`
+	Votes        int       `json:"votes"`
+	Share        float64   `json:"share"`
+
+	RunningMate *RunningMate `json:"running_mate,omitempty"`
+}
+
+// PositionResult is one seat's result over a reporting area. Basis is
+// "stations" when summed from the current Form A station tallies in the
+// area, or "reported" when taken from a tally filed for the area itself.
+// The filed tally is used while the station sum is incomplete and whenever
+// it is final. A station sum is only final once every expected station has
+// reported.
+type PositionResult struct {
+	PositionID       uuid.UUID         `json:"position_id"`
+	Title            string            `json:"title"`
+	SeatLevel        string            `json:"seat_level"`
+	SeatArea         *string           `json:"seat_area,omitempty"`
+	Basis            string            `json:"basis"`
+	Form             *string           `json:"form,omitempty"`
+	RegisteredVoters int               `json:"registered_voters"`
+	ValidVotes       int               `json:"valid_votes"`
+	RejectedVotes    int               `json:"rejected_votes"`
+	TotalVotes       int               `json:"total_votes"`
+	Turnout          *float64          `json:"turnout"`
+	StationsReported int               `json:"stations_reported"`
+	StationsExpected int               `json:"stations_expected"`
+	IsFinal          bool              `json:"is_final"`
+	Candidates       []CandidateResult `json:"candidates"`
+}
+
+type AreaResults struct {
+	ElectionID uuid.UUID        `json:"election_id"`
+	Level      string           `json:"level"`
+	Area       *AreaRef         `json:"area,omitempty"`
+	Positions  []PositionResult `json:"positions"`
+}
+
+// Anomaly is a discrepancy found by reconciling result tallies. Reported
+// and Expected are the two figures that disagree: for an aggregate
+// mismatch, the votes on the aggregate form and the sum of its station
+// tallies; for a resubmission, the current and superseded figures for the
+// candidate whose votes changed most.
+type Anomaly struct {
+	Type             string     `json:"type"`
+	Severity         string     `json:"severity"`
+	Message          string     `json:"message"`
+	ElectionID       uuid.UUID  `json:"election_id"`
+	PositionID       uuid.UUID  `json:"position_id"`
+	Title            string     `json:"title"`
+	Level            string     `json:"level"`
+	Area             *AreaRef   `json:"area,omitempty"`
+	TallyID          uuid.UUID  `json:"tally_id"`
+	RelatedTallyID   *uuid.UUID `json:"related_tally_id,omitempty"`
+	CandidacyID      *uuid.UUID `json:"candidacy_id,omitempty"`
+	Candidate        *string    `json:"candidate,omitempty"`
+	Reported         *int       `json:"reported,omitempty"`
+	Expected         *int       `json:"expected,omitempty"`
+	Turnout          *float64   `json:"turnout,omitempty"`
+	StationsReported *int       `json:"stations_reported,omitempty"`
+	StationsExpected *int       `json:"stations_expected,omitempty"`
+	SupersededFinal  bool       `json:"superseded_final,omitempty"`
+	ReportedAt       time.Time  `json:"reported_at"`
+}
+
+type AnomalyFilter struct {
+	Types      []string
+	Severities []string
+	Title      string
+}
+
+// ResultUpdate is a tally as pushed to live results subscribers. Each
+// update is the current tally for its seat and area and replaces any
+// earlier update for the same pair. The ward, constituency and county
+// codes place the area for filtering.
+type ResultUpdate struct {
+	Seq              int64             `json:"seq"`
+	TallyID          uuid.UUID         `json:"tally_id"`
+	ElectionID       uuid.UUID         `json:"election_id"`
+	PositionID       uuid.UUID         `json:"position_id"`
+	Title            string            `json:"title"`
+	Level            string            `json:"level"`
+	Form             *string           `json:"form,omitempty"`
+	Area             *AreaRef          `json:"area,omitempty"`
+	WardCode         *string           `json:"ward_code,omitempty"`
+	ConstituencyCode *string           `json:"constituency_code,omitempty"`
+	CountyCode       *string           `json:"county_code,omitempty"`
+	RegisteredVoters int               `json:"registered_voters"`
+	RejectedVotes    int               `json:"rejected_votes"`
+	ValidVotes       int               `json:"valid_votes"`
+	IsFinal          bool              `json:"is_final"`
+	ReportedAt       time.Time         `json:"reported_at"`
+	Candidates       []CandidateResult `json:"candidates"`
+}
+
+// ResultStreamFilter narrows a live results stream. Empty fields match
+// everything; a geography code matches tallies at or below that area.
+type ResultStreamFilter struct {
+	ElectionID   uuid.UUID
+	PositionID   *uuid.UUID
+	Title        string
+	County       string
+	Constituency string
+	Ward         string
+}
+
+// CountyVotes is a candidate's presidential votes in one county, taken
+// from the most complete tallies available there.
+type CountyVotes struct {
+	County    AreaRef
+	Candidate CandidateResult
+	IsFinal   bool
+}
+
+// PresidentialStatus evaluates Article 138(4): a candidate is elected with
+// more than half of the valid votes and at least a quarter of the votes in
+// at least half of the counties. Status is no_results, counting,
+// declared_pending_counties, elected or run_off. The last two are only
+// given once every county's count is complete and final, since the county
+// spread cannot be judged before; declared_pending_counties means a final
+// national tally is in but some county is not. RunOff and
+// RunOffCandidates are only set once the result is final.
+type PresidentialStatus struct {
+	ElectionID        uuid.UUID                     `json:"election_id"`
+	Status            string                        `json:"status"`
+	MajorityThreshold float64                       `json:"majority_threshold"`
+	CountyThreshold   float64                       `json:"county_threshold"`
+	CountiesRequired  int                           `json:"counties_required"`
+	CountiesReporting int                           `json:"counties_reporting"`
+	CountiesTotal     int                           `json:"counties_total"`
+	ValidVotes        int                           `json:"valid_votes"`
+	IsFinal           bool                          `json:"is_final"`
+	Leader            *uuid.UUID                    `json:"leader,omitempty"`
+	Winner            *uuid.UUID                    `json:"winner,omitempty"`
+	RunOff            bool                          `json:"run_off"`
+	RunOffCandidates  []uuid.UUID                   `json:"run_off_candidates"`
+	Candidates        []PresidentialCandidateStatus `json:"candidates"`
+}
+
+type PresidentialCandidateStatus struct {
+	CandidateResult
+	CountiesAtThreshold int           `json:"counties_at_threshold"`
+	CountiesShort       int           `json:"counties_short"`
+	MeetsMajority       bool          `json:"meets_majority"`
+	MeetsCountySpread   bool          `json:"meets_county_spread"`
+	OnTrack             bool          `json:"on_track"`
+	Counties            []CountyShare `json:"counties"`
+}
+
+type CountyShare struct {
+	Code           string  `json:"code"`
+	Name           string  `json:"name"`
+	Votes          int     `json:"votes"`
+	Share          float64 `json:"share"`
+	MeetsThreshold bool    `json:"meets_threshold"`
 }
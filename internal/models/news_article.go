@@ -6,16 +6,110 @@ import (
 	"github.com/google/uuid"
 )
 
+// NewsSource is a feed or site the aggregator reads. With ExtractFullText
+// each new article's page is fetched for its full text.
 type NewsSource struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	URL       string    `json:"url"`
-	FeedURL   *string   `json:"feed_url,omitempty"`
-	Type      string    `json:"type"`
-	Outlet    *string   `json:"outlet,omitempty"`
-	Active    bool      `json:"active"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	URL             string    `json:"url"`
+	FeedURL         *string   `json:"feed_url,omitempty"`
+	Type            string    `json:"type"`
+	Outlet          *string   `json:"outlet,omitempty"`
+	Active          bool      `json:"active"`
+	ExtractFullText bool      `json:"extract_full_text"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+
+	// Scraper is set for sources read by the HTML scraper.
+	Scraper *ScraperConfig `json:"scraper,omitempty"`
+	// FetchState is set once the aggregator has tried the source.
+	FetchState *FetchState `json:"fetch_state,omitempty"`
+}
+
+// FetchState is what the aggregator keeps about a source between cycles.
+// ETag and LastModified are the validators of the last feed or listing
+// page response. A source that has failed ConsecutiveFailures times in a
+// row is not fetched again before NextFetchAt, and DeactivatedAt is set
+// when the aggregator switched it off for failing too often.
+type FetchState struct {
+	ETag                *string    `json:"etag,omitempty"`
+	LastModified        *string    `json:"last_modified,omitempty"`
+	LastFetchedAt       time.Time  `json:"last_fetched_at"`
+	LastSuccessAt       *time.Time `json:"last_success_at,omitempty"`
+	LastStatusCode      *int       `json:"last_status_code,omitempty"`
+	LastError           *string    `json:"last_error,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	NextFetchAt         *time.Time `json:"next_fetch_at,omitempty"`
+	DeactivatedAt       *time.Time `json:"deactivated_at,omitempty"`
+}
+
+// FetchAttempt is one logged attempt to fetch a news source. StatusCode
+// is empty when no response came back, and Error is set when the attempt
+// failed as a whole.
+type FetchAttempt struct {
+	ID         int64     `json:"id"`
+	SourceID   uuid.UUID `json:"source_id"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMS int64     `json:"duration_ms"`
+	StatusCode *int      `json:"status_code,omitempty"`
+	ItemsSeen  int       `json:"items_seen"`
+	NewItems   int       `json:"new_items"`
+	ItemErrors int       `json:"item_errors"`
+	Error      *string   `json:"error,omitempty"`
+}
+
+// NewsSourceStatus is the health of one news source. Status is healthy,
+// stale (fetching works but nothing new has arrived lately), broken
+// (failing, or switched off for failing) or inactive (switched off by an
+// editor). Attempts, Failures and AvgDurationMS cover the status window.
+type NewsSourceStatus struct {
+	ID                  uuid.UUID  `json:"id"`
+	Name                string     `json:"name"`
+	Outlet              *string    `json:"outlet,omitempty"`
+	Type                string     `json:"type"`
+	Active              bool       `json:"active"`
+	Status              string     `json:"status"`
+	Reason              string     `json:"reason,omitempty"`
+	LastFetchedAt       *time.Time `json:"last_fetched_at,omitempty"`
+	LastSuccessAt       *time.Time `json:"last_success_at,omitempty"`
+	LastStatusCode      *int       `json:"last_status_code,omitempty"`
+	LastError           *string    `json:"last_error,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	NextFetchAt         *time.Time `json:"next_fetch_at,omitempty"`
+	DeactivatedAt       *time.Time `json:"deactivated_at,omitempty"`
+	LastArticleAt       *time.Time `json:"last_article_at,omitempty"`
+	RecentArticles      int        `json:"recent_articles"`
+	Attempts            int        `json:"attempts"`
+	Failures            int        `json:"failures"`
+	AvgDurationMS       *int       `json:"avg_duration_ms,omitempty"`
+}
+
+// NewsSourceStatusReport is the health of every news source over the last
+// WindowHours, with a count of sources in each status.
+type NewsSourceStatusReport struct {
+	GeneratedAt time.Time          `json:"generated_at"`
+	WindowHours int                `json:"window_hours"`
+	Summary     map[string]int     `json:"summary"`
+	Sources     []NewsSourceStatus `json:"sources"`
+}
+
+// ScraperConfig tells the HTML scraper where a source's articles are: the
+// listing page, the selector for article links on it, and the selector
+// for each field on an article page. DateAttribute reads the date from an
+// attribute such as datetime instead of the element's text, and
+// DateLayout is a Go time layout for dates in an unusual format.
+type ScraperConfig struct {
+	SourceID       uuid.UUID `json:"source_id"`
+	ListURL        string    `json:"list_url"`
+	LinkSelector   string    `json:"link_selector"`
+	TitleSelector  string    `json:"title_selector"`
+	BodySelector   string    `json:"body_selector"`
+	DateSelector   *string   `json:"date_selector,omitempty"`
+	DateAttribute  *string   `json:"date_attribute,omitempty"`
+	DateLayout     *string   `json:"date_layout,omitempty"`
+	AuthorSelector *string   `json:"author_selector,omitempty"`
+	MaxArticles    int       `json:"max_articles"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
 
 type NewsArticle struct {
@@ -25,6 +119,7 @@ type NewsArticle struct {
 	Content           *string    `json:"content,omitempty"`
 	Summary           *string    `json:"summary,omitempty"`
 	URL               string     `json:"url"`
+	CanonicalURL      *string    `json:"canonical_url,omitempty"`
 	Author            *string    `json:"author,omitempty"`
 	ImageURL          *string    `json:"image_url,omitempty"`
 	PublishedAt       *time.Time `json:"published_at,omitempty"`
@@ -34,6 +129,13 @@ type NewsArticle struct {
 	CreatedAt         time.Time  `json:"created_at"`
 }
 
+// NewsSourceUpdate changes the aggregator settings of a news source.
+// Fields left nil are unchanged.
+type NewsSourceUpdate struct {
+	Active          *bool `json:"active"`
+	ExtractFullText *bool `json:"extract_full_text"`
+}
+
 type ArticlePoliticianMention struct {
 	ArticleID      uuid.UUID `json:"article_id"`
 	PoliticianID   uuid.UUID `json:"politician_id"`
@@ -33,6 +33,7 @@ type PoliticianSummary struct {
 	Status    string    `json:"status"`
 	PhotoURL  *string   `json:"photo_url,omitempty"`
 	Party     *string   `json:"current_party,omitempty"`
+	Office    *string   `json:"current_office,omitempty"`
 }
 
 type PoliticianDossier struct {
@@ -43,11 +44,22 @@ type PoliticianDossier struct {
 	IntegrityFlags  []IntegrityFlag    `json:"integrity_flags"`
 }
 
+// PoliticianFilter narrows the politician list. Slice fields match any of
+// their values; position and geography filters apply to the seat a
+// politician currently holds.
 type PoliticianFilter struct {
-	Query    string
-	PartyID  *uuid.UUID
-	CountyID *uuid.UUID
-	Position *string
-	Limit    int
-	Offset   int
+	Query          string
+	PartyIDs       []uuid.UUID
+	CountyIDs      []uuid.UUID
+	CountyCodes    []string
+	Constituencies []string
+	Positions      []string
+	Genders        []string
+	Statuses       []string
+	Coalitions     []string
+	MinAge         *int
+	MaxAge         *int
+	Sort           string
+	Limit          int
+	Offset         int
 }
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Poll is one published opinion poll release. Candidate polls cover one
+// seat and party polls national party preference. Shares, the undecided
+// share and the margin of error are percentages.
+type Poll struct {
+	ID             uuid.UUID   `json:"id"`
+	ElectionID     uuid.UUID   `json:"election_id"`
+	Scope          string      `json:"scope"`
+	PositionID     *uuid.UUID  `json:"position_id,omitempty"`
+	PositionTitle  *string     `json:"position_title,omitempty"`
+	Area           *AreaRef    `json:"area,omitempty"`
+	Pollster       string      `json:"pollster"`
+	CommissionedBy *string     `json:"commissioned_by,omitempty"`
+	FieldworkStart time.Time   `json:"fieldwork_start"`
+	FieldworkEnd   time.Time   `json:"fieldwork_end"`
+	PublishedOn    *time.Time  `json:"published_on,omitempty"`
+	SampleSize     int         `json:"sample_size"`
+	MarginOfError  *float64    `json:"margin_of_error,omitempty"`
+	Methodology    string      `json:"methodology"`
+	Undecided      *float64    `json:"undecided,omitempty"`
+	Shares         []PollShare `json:"shares"`
+	SourceURL      *string     `json:"source_url,omitempty"`
+	Notes          *string     `json:"notes,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// PollShare is one politician's or party's share in a poll. CandidacyID
+// is set once the politician has declared for the polled seat.
+type PollShare struct {
+	PoliticianID *uuid.UUID `json:"politician_id,omitempty"`
+	PartyID      *uuid.UUID `json:"party_id,omitempty"`
+	CandidacyID  *uuid.UUID `json:"candidacy_id,omitempty"`
+	Slug         string     `json:"slug"`
+	Name         string     `json:"name"`
+	Share        float64    `json:"share"`
+}
+
+// PollFilter narrows an election's polls. Title and County, or
+// PositionID, pick the seat of candidate polls; Since and Until bound the
+// end of fieldwork.
+type PollFilter struct {
+	Scope      string
+	Title      string
+	County     string
+	PositionID *uuid.UUID
+	Pollster   string
+	Since      *time.Time
+	Until      *time.Time
+}
+
+// PollAverageFilter picks the race for a poll of polls and how polls are
+// weighted. Zero values take the service defaults.
+type PollAverageFilter struct {
+	Scope        string
+	Title        string
+	County       string
+	PositionID   *uuid.UUID
+	AsOf         *time.Time
+	HalfLifeDays int
+	WindowDays   int
+}
+
+// PollAverage is a poll of polls for one race as of a date. Each poll is
+// weighted by the square root of its sample size and halves in weight
+// every HalfLifeDays days after its fieldwork.
+type PollAverage struct {
+	ElectionID   uuid.UUID    `json:"election_id"`
+	Scope        string       `json:"scope"`
+	PositionID   *uuid.UUID   `json:"position_id,omitempty"`
+	Position     *string      `json:"position,omitempty"`
+	Area         *AreaRef     `json:"area,omitempty"`
+	AsOf         time.Time    `json:"as_of"`
+	HalfLifeDays int          `json:"half_life_days"`
+	WindowDays   int          `json:"window_days"`
+	Polls        int          `json:"polls"`
+	Pollsters    []string     `json:"pollsters"`
+	Options      []PollOption `json:"options"`
+	Undecided    *float64     `json:"undecided,omitempty"`
+}
+
+// PollOption is one politician's or party's weighted average share, with
+// the 95% confidence band around it. Polls counts the polls that named it.
+type PollOption struct {
+	PoliticianID *uuid.UUID `json:"politician_id,omitempty"`
+	PartyID      *uuid.UUID `json:"party_id,omitempty"`
+	CandidacyID  *uuid.UUID `json:"candidacy_id,omitempty"`
+	Slug         string     `json:"slug"`
+	Name         string     `json:"name"`
+	Share        float64    `json:"share"`
+	Low          float64    `json:"low"`
+	High         float64    `json:"high"`
+	Polls        int        `json:"polls"`
+}
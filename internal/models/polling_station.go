@@ -16,3 +16,16 @@ type PollingStation struct {
 	RegisteredVoters int       `json:"registered_voters"`
 	CreatedAt        time.Time `json:"created_at"`
 }
+
+// NearbyPollingStation is a polling station with its distance from a
+// queried point and the areas it sits in.
+type NearbyPollingStation struct {
+	PollingStation
+	DistanceKM       float64 `json:"distance_km"`
+	WardCode         string  `json:"ward_code"`
+	WardName         string  `json:"ward_name"`
+	ConstituencyCode string  `json:"constituency_code"`
+	ConstituencyName string  `json:"constituency_name"`
+	CountyCode       string  `json:"county_code"`
+	CountyName       string  `json:"county_name"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AreaRef struct {
+	ID   uuid.UUID `json:"id"`
+	Code string    `json:"code"`
+	Name string    `json:"name"`
+}
+
+// VoterLocation is the ward -> constituency -> county chain a lookup
+// resolved to. ResolvedBy says how: polling_station, ward, boundary or
+// nearest_polling_station.
+type VoterLocation struct {
+	ResolvedBy     string   `json:"resolved_by"`
+	PollingStation *AreaRef `json:"polling_station,omitempty"`
+	DistanceKM     *float64 `json:"distance_km,omitempty"`
+	Ward           AreaRef  `json:"ward"`
+	Constituency   AreaRef  `json:"constituency"`
+	County         AreaRef  `json:"county"`
+}
+
+type SeatPolitician struct {
+	PoliticianID uuid.UUID `json:"politician_id"`
+	Slug         string    `json:"slug"`
+	Name         string    `json:"name"`
+	PhotoURL     *string   `json:"photo_url,omitempty"`
+	Party        *string   `json:"party,omitempty"`
+	Status       string    `json:"status"`
+}
+
+type OfficeHolder struct {
+	SeatPolitician
+	ElectionName string     `json:"election_name"`
+	ElectionDate *time.Time `json:"election_date,omitempty"`
+}
+
+// Seat is one elective position covering a voter, with whoever holds it
+// now and who has declared for it in the upcoming election.
+type Seat struct {
+	PositionID uuid.UUID        `json:"position_id"`
+	Title      string           `json:"title"`
+	Level      string           `json:"level"`
+	Area       *string          `json:"area,omitempty"`
+	Holder     *OfficeHolder    `json:"holder"`
+	Candidates []SeatPolitician `json:"candidates"`
+}
+
+type Representatives struct {
+	Location VoterLocation `json:"location"`
+	Election *Election     `json:"upcoming_election"`
+	Seats    []Seat        `json:"seats"`
+}
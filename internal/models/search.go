@@ -0,0 +1,32 @@
+package models
+
+import "github.com/google/uuid"
+
+type SearchResult struct {
+	Type     string    `json:"type"`
+	ID       uuid.UUID `json:"id"`
+	Title    string    `json:"title"`
+	Subtitle *string   `json:"subtitle,omitempty"`
+	Snippet  string    `json:"snippet"`
+	URL      string    `json:"url"`
+	Rank     float64   `json:"rank"`
+}
+
+type SearchGroup struct {
+	Type    string         `json:"type"`
+	Total   int            `json:"total"`
+	Results []SearchResult `json:"results"`
+}
+
+type SearchResponse struct {
+	Query  string        `json:"query"`
+	Total  int           `json:"total"`
+	Groups []SearchGroup `json:"groups"`
+}
+
+type SearchFilter struct {
+	Query string
+	Types []string
+	// Limit caps the results returned per type.
+	Limit int
+}
@@ -0,0 +1,64 @@
+package models
+
+import "github.com/google/uuid"
+
+// SeatCandidate is a candidacy's result within its seat.
+type SeatCandidate struct {
+	CandidateResult
+	PartySlug *string `json:"party_slug,omitempty"`
+	Status    string  `json:"status"`
+}
+
+// PositionRank orders seat titles from the top of the ballot down.
+var PositionRank = map[string]int{
+	"president": 0, "deputy_president": 1, "governor": 2, "deputy_governor": 3,
+	"senator": 4, "woman_rep": 5, "mp": 6, "mca": 7,
+}
+
+// SeatResult is the contest for one elective_positions row. Basis is
+// declared when taken from the tally filed at the seat's own level (for
+// example Form 35B for an MP), counted when summed from lower-level
+// tallies, or none before any results arrive. A counted seat is final only
+// once every polling station in it has a final tally. Margin is the
+// winner's lead over the runner-up in votes and in percentage points.
+type SeatResult struct {
+	PositionID  uuid.UUID       `json:"position_id"`
+	Title       string          `json:"title"`
+	Level       string          `json:"level"`
+	Area        *AreaRef        `json:"area,omitempty"`
+	County      *AreaRef        `json:"county,omitempty"`
+	Basis       string          `json:"basis"`
+	IsFinal     bool            `json:"is_final"`
+	ValidVotes  int             `json:"valid_votes"`
+	Winner      *SeatCandidate  `json:"winner"`
+	RunnerUp    *SeatCandidate  `json:"runner_up"`
+	Margin      *int            `json:"margin"`
+	MarginShare *float64        `json:"margin_share"`
+	Candidates  []SeatCandidate `json:"candidates"`
+}
+
+type SeatFilter struct {
+	PositionID  *uuid.UUID
+	Title       string
+	County      string
+	WinnerParty string
+}
+
+// SeatSummary counts the seats of one title by the winning party. Won
+// counts final results; Leading counts seats still being counted. Tied
+// seats and a presidential seat going to a run-off count for no party.
+type SeatSummary struct {
+	Title     string       `json:"title"`
+	Seats     int          `json:"seats"`
+	Final     int          `json:"final"`
+	Reporting int          `json:"reporting"`
+	Parties   []PartySeats `json:"parties"`
+}
+
+type PartySeats struct {
+	Party     string  `json:"party"`
+	PartySlug *string `json:"party_slug,omitempty"`
+	Won       int     `json:"won"`
+	Leading   int     `json:"leading"`
+	Contested int     `json:"contested"`
+}
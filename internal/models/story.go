@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StoryCluster groups the articles that cover one story, across outlets.
+// Outlets lists each outlet once, in the order they covered the story.
+type StoryCluster struct {
+	ID                uuid.UUID      `json:"id"`
+	Headline          string         `json:"headline"`
+	LeadArticleID     *uuid.UUID     `json:"lead_article_id,omitempty"`
+	FirstSeenAt       time.Time      `json:"first_seen_at"`
+	LastSeenAt        time.Time      `json:"last_seen_at"`
+	ArticleCount      int            `json:"article_count"`
+	OutletCount       int            `json:"outlet_count"`
+	IsElectionRelated bool           `json:"is_election_related"`
+	Outlets           []string       `json:"outlets"`
+	Articles          []StoryArticle `json:"articles"`
+}
+
+// StoryArticle is one article in a story cluster.
+type StoryArticle struct {
+	ID          uuid.UUID  `json:"id"`
+	SourceID    *uuid.UUID `json:"source_id,omitempty"`
+	Outlet      *string    `json:"outlet,omitempty"`
+	Title       string     `json:"title"`
+	URL         string     `json:"url"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+type StoryFilter struct {
+	Since           *time.Time
+	ElectionRelated bool
+	MinOutlets      int
+	// Sort is recent (default) or coverage, the most outlets first.
+	Sort   string
+	Limit  int
+	Offset int
+}
+
+// ClusterCandidate is an article as the story clusterer sees it. At is
+// when it was published, or scraped if the date is unknown.
+type ClusterCandidate struct {
+	ArticleID uuid.UUID
+	ClusterID *uuid.UUID
+	Title     string
+	Text      string
+	At        time.Time
+	MinHash   []int64
+}
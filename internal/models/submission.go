@@ -0,0 +1,41 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EditSubmission is a proposed change to a dossier record, held for
+// editorial review. Payload is the record for a create and the fields to
+// change for an update.
+type EditSubmission struct {
+	ID              uuid.UUID       `json:"id"`
+	PoliticianID    uuid.UUID       `json:"politician_id"`
+	PoliticianName  string          `json:"politician_name,omitempty"`
+	Collection      string          `json:"collection"`
+	Operation       string          `json:"operation"`
+	RecordID        *uuid.UUID      `json:"record_id,omitempty"`
+	Payload         json.RawMessage `json:"payload"`
+	Summary         string          `json:"summary"`
+	EvidenceURL     string          `json:"evidence_url"`
+	EvidenceName    *string         `json:"evidence_name,omitempty"`
+	EvidenceType    string          `json:"evidence_type"`
+	SubmitterName   *string         `json:"submitter_name,omitempty"`
+	SubmitterEmail  *string         `json:"submitter_email,omitempty"`
+	Status          string          `json:"status"`
+	Reviewer        *string         `json:"reviewer,omitempty"`
+	ReviewComment   *string         `json:"review_comment,omitempty"`
+	ReviewedAt      *time.Time      `json:"reviewed_at,omitempty"`
+	SourceID        *uuid.UUID      `json:"source_id,omitempty"`
+	AppliedRecordID *uuid.UUID      `json:"applied_record_id,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+type SubmissionFilter struct {
+	Status string
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,66 @@
+package models
+
+import "github.com/google/uuid"
+
+// GroupVotes is the vote for one party or coalition in a constituency in
+// one election, with the constituency's registered voters and rejected
+// votes for the seat. GroupType is party, coalition or independent.
+type GroupVotes struct {
+	Constituency     AreaRef
+	County           AreaRef
+	RegisteredVoters int
+	RejectedVotes    int
+	GroupID          *uuid.UUID
+	GroupName        string
+	GroupType        string
+	Votes            int
+}
+
+// SwingFilter selects the two elections, the seat and how candidates are
+// grouped for a swing comparison.
+type SwingFilter struct {
+	From    uuid.UUID
+	To      uuid.UUID
+	Title   string
+	GroupBy string
+	Level   string
+	County  string
+}
+
+type SwingReport struct {
+	From     Election    `json:"from"`
+	To       Election    `json:"to"`
+	Position string      `json:"position"`
+	GroupBy  string      `json:"group_by"`
+	Level    string      `json:"level"`
+	Areas    []AreaSwing `json:"areas"`
+	Overall  *AreaSwing  `json:"overall,omitempty"`
+}
+
+// AreaSwing compares one constituency or county between two elections.
+// Shares, turnout and margins are percentages; changes are in percentage
+// points. Figures for an election with no results in the area are null.
+type AreaSwing struct {
+	Area          AreaRef      `json:"area"`
+	County        *AreaRef     `json:"county,omitempty"`
+	TurnoutFrom   *float64     `json:"turnout_from"`
+	TurnoutTo     *float64     `json:"turnout_to"`
+	TurnoutChange *float64     `json:"turnout_change"`
+	WinnerFrom    *string      `json:"winner_from"`
+	WinnerTo      *string      `json:"winner_to"`
+	MarginFrom    *float64     `json:"margin_from"`
+	MarginTo      *float64     `json:"margin_to"`
+	MarginChange  *float64     `json:"margin_change"`
+	Groups        []GroupSwing `json:"groups"`
+}
+
+type GroupSwing struct {
+	ID        *uuid.UUID `json:"id,omitempty"`
+	Name      string     `json:"name"`
+	Type      string     `json:"type"`
+	VotesFrom int        `json:"votes_from"`
+	VotesTo   int        `json:"votes_to"`
+	ShareFrom float64    `json:"share_from"`
+	ShareTo   float64    `json:"share_to"`
+	Swing     float64    `json:"swing"`
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"jalada/internal/models"
 )
 
 type AnalyticsRepo struct {
@@ -150,3 +153,84 @@ func (r *AnalyticsRepo) GetTrending(ctx context.Context, limit int) ([]TrendingI
 	}
 	return items, nil
 }
+
+// GroupVotes returns each party's or coalition's vote per constituency for
+// one seat title in an election. Each seat is counted from its finest
+// tallies in the constituency: stations, then wards, then the
+// constituency tally. Coalitions are resolved from coalition_members as
+// they stood on election day; parties outside any coalition stand alone.
+func (r *AnalyticsRepo) GroupVotes(ctx context.Context, electionID uuid.UUID, title, groupBy string) ([]models.GroupVotes, error) {
+	query := `
+		WITH placed AS (
+			SELECT t.id, t.position_id, t.level, t.registered_voters, t.rejected_votes,
+			       COALESCE(t.constituency_id, w.constituency_id) AS constituency_id
+			FROM result_tallies t
+			JOIN elective_positions ep ON ep.id = t.position_id
+			LEFT JOIN polling_stations ps ON ps.id = t.polling_station_id
+			LEFT JOIN wards w ON w.id = COALESCE(t.ward_id, ps.ward_id)
+			WHERE t.election_id = $1 AND t.is_current AND ep.title = $2
+			  AND t.level IN ('station', 'ward', 'constituency')
+		),
+		finest AS (
+			SELECT p.* FROM placed p
+			WHERE p.level = (
+				SELECT CASE WHEN BOOL_OR(q.level = 'station') THEN 'station'
+				            WHEN BOOL_OR(q.level = 'ward') THEN 'ward'
+				            ELSE 'constituency' END
+				FROM placed q
+				WHERE q.position_id = p.position_id AND q.constituency_id = p.constituency_id)
+		),
+		totals AS (
+			SELECT constituency_id, SUM(registered_voters) AS registered, SUM(rejected_votes) AS rejected
+			FROM finest GROUP BY constituency_id
+		),
+		votes AS (
+			SELECT f.constituency_id, c.party_id, SUM(er.votes) AS votes
+			FROM finest f
+			JOIN election_results er ON er.tally_id = f.id
+			JOIN candidacies c ON c.id = er.candidacy_id
+			GROUP BY f.constituency_id, c.party_id
+		)
+		SELECT cn.id, cn.code, cn.name, co.id, co.code, co.name, tt.registered, tt.rejected,
+		       COALESCE(cl.id, pp.id), COALESCE(cl.name, pp.name, 'Independent'),
+		       CASE WHEN cl.id IS NOT NULL THEN 'coalition'
+		            WHEN pp.id IS NOT NULL THEN 'party'
+		            ELSE 'independent' END,
+		       SUM(v.votes)
+		FROM votes v
+		JOIN totals tt ON tt.constituency_id = v.constituency_id
+		JOIN constituencies cn ON cn.id = v.constituency_id
+		JOIN counties co ON co.id = cn.county_id
+		LEFT JOIN political_parties pp ON pp.id = v.party_id
+		LEFT JOIN LATERAL (
+			SELECT cm.coalition_id
+			FROM coalition_members cm, elections e
+			WHERE $3 = 'coalition' AND e.id = $1 AND cm.party_id = v.party_id
+			  AND (cm.joined_at IS NULL OR cm.joined_at <= COALESCE(e.election_date, CURRENT_DATE))
+			  AND (cm.left_at IS NULL OR cm.left_at > COALESCE(e.election_date, CURRENT_DATE))
+			ORDER BY cm.joined_at DESC NULLS LAST
+			LIMIT 1
+		) m ON TRUE
+		LEFT JOIN coalitions cl ON cl.id = m.coalition_id
+		GROUP BY cn.id, cn.code, cn.name, co.id, co.code, co.name, tt.registered, tt.rejected,
+		         cl.id, pp.id, cl.name, pp.name
+		ORDER BY cn.code`
+
+	rows, err := r.pool.Query(ctx, query, electionID, title, groupBy)
+	if err != nil {
+		return nil, fmt.Errorf("get group votes: %w", err)
+	}
+	defer rows.Close()
+
+	var out []models.GroupVotes
+	for rows.Next() {
+		var g models.GroupVotes
+		if err := rows.Scan(&g.Constituency.ID, &g.Constituency.Code, &g.Constituency.Name,
+			&g.County.ID, &g.County.Code, &g.County.Name, &g.RegisteredVoters, &g.RejectedVotes,
+			&g.GroupID, &g.GroupName, &g.GroupType, &g.Votes); err != nil {
+			return nil, fmt.Errorf("scan group votes: %w", err)
+		}
+		out = append(out, g)
+	}
+	return out, rows.Err()
+}
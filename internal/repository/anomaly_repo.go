@@ -0,0 +1,314 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"jalada/internal/models"
+)
+
+// tallyContextSQL is a CTE naming every tally in election $1 with its seat
+// title and reporting area.
+const tallyContextSQL = `
+	tally_ctx AS (
+		SELECT t.id, t.position_id, t.level, t.is_current, t.is_final, t.registered_voters,
+		       t.rejected_votes, t.reported_at, t.polling_station_id, t.ward_id, t.constituency_id,
+		       t.county_id, ep.title,
+		       COALESCE(ps.id, w.id, cn.id, co.id) AS area_id,
+		       COALESCE(ps.code, w.code, cn.code, co.code) AS area_code,
+		       COALESCE(ps.name, w.name, cn.name, co.name) AS area_name
+		FROM result_tallies t
+		JOIN elective_positions ep ON ep.id = t.position_id
+		LEFT JOIN polling_stations ps ON ps.id = t.polling_station_id
+		LEFT JOIN wards w ON w.id = t.ward_id
+		LEFT JOIN constituencies cn ON cn.id = t.constituency_id
+		LEFT JOIN counties co ON co.id = t.county_id
+		WHERE t.election_id = $1 AND ($2 = '' OR ep.title = $2)
+	)`
+
+// AnomalyRepo finds discrepancies between result tallies. Severity is left
+// for the caller to assign.
+type AnomalyRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewAnomalyRepo(pool *pgxpool.Pool) *AnomalyRepo {
+	return &AnomalyRepo{pool: pool}
+}
+
+func areaRef(id *uuid.UUID, code, name *string) *models.AreaRef {
+	if id == nil {
+		return nil
+	}
+	return &models.AreaRef{ID: *id, Code: *code, Name: *name}
+}
+
+// stationScopesSQL is a CTE giving every polling station once for each
+// area it falls in: its ward, constituency and county, and the nation
+// under the nil UUID. Grouping by (level, area_id) then counts or sums
+// stations per area with plain equality joins.
+const stationScopesSQL = `
+	station_scopes AS (
+		SELECT ps.id AS polling_station_id, s.level, s.area_id
+		FROM polling_stations ps
+		JOIN wards w ON w.id = ps.ward_id
+		JOIN constituencies cn ON cn.id = w.constituency_id
+		CROSS JOIN LATERAL (VALUES ('ward', w.id), ('constituency', cn.id), ('county', cn.county_id),
+		                           ('national', '00000000-0000-0000-0000-000000000000'::uuid)) s(level, area_id)
+	)`
+
+// AggregateMismatches compares each candidate's votes on current ward,
+// constituency, county and national tallies with the sum of the current
+// station tallies in the same area. Candidates on either side are
+// compared, so one left off the aggregate form is flagged with 0 votes.
+func (r *AnomalyRepo) AggregateMismatches(ctx context.Context, electionID uuid.UUID, title string) ([]models.Anomaly, error) {
+	query := `
+		WITH ` + tallyContextSQL + `,` + stationScopesSQL + `,
+		reported AS (
+			SELECT tc.id, tc.position_id, tc.title, tc.level, tc.area_id, tc.area_code, tc.area_name, tc.reported_at,
+			       COALESCE(tc.area_id, '00000000-0000-0000-0000-000000000000'::uuid) AS scope_id
+			FROM tally_ctx tc
+			WHERE tc.is_current AND tc.level <> 'station'
+		),
+		station_tallies AS (
+			SELECT tc.id, tc.position_id, sc.level, sc.area_id
+			FROM tally_ctx tc
+			JOIN station_scopes sc ON sc.polling_station_id = tc.polling_station_id
+			WHERE tc.is_current AND tc.level = 'station'
+		),
+		stations_reported AS (
+			SELECT position_id, level, area_id, COUNT(*) AS stations
+			FROM station_tallies
+			GROUP BY position_id, level, area_id
+		),
+		station_votes AS (
+			SELECT st.position_id, st.level, st.area_id, er.candidacy_id, SUM(er.votes) AS votes
+			FROM station_tallies st
+			JOIN election_results er ON er.tally_id = st.id
+			GROUP BY st.position_id, st.level, st.area_id, er.candidacy_id
+		),
+		expected AS (
+			SELECT level, area_id, COUNT(*) AS stations
+			FROM station_scopes
+			GROUP BY level, area_id
+		),
+		candidates AS (
+			SELECT r.id, er.candidacy_id
+			FROM reported r JOIN election_results er ON er.tally_id = r.id
+			UNION
+			SELECT r.id, sv.candidacy_id
+			FROM reported r
+			JOIN station_votes sv ON sv.position_id = r.position_id AND sv.level = r.level AND sv.area_id = r.scope_id
+		)
+		SELECT r.id, r.position_id, r.title, r.level, r.area_id, r.area_code, r.area_name, r.reported_at,
+		       c.candidacy_id, p.first_name || ' ' || p.last_name, COALESCE(er.votes, 0), COALESCE(sv.votes, 0),
+		       sr.stations, COALESCE(e.stations, 0)
+		FROM reported r
+		JOIN stations_reported sr ON sr.position_id = r.position_id AND sr.level = r.level AND sr.area_id = r.scope_id
+		JOIN candidates c ON c.id = r.id
+		JOIN candidacies cd ON cd.id = c.candidacy_id
+		JOIN politicians p ON p.id = cd.politician_id
+		LEFT JOIN election_results er ON er.tally_id = r.id AND er.candidacy_id = c.candidacy_id
+		LEFT JOIN station_votes sv ON sv.position_id = r.position_id AND sv.level = r.level
+		     AND sv.area_id = r.scope_id AND sv.candidacy_id = c.candidacy_id
+		LEFT JOIN expected e ON e.level = r.level AND e.area_id = r.scope_id
+		WHERE COALESCE(sv.votes, 0) <> COALESCE(er.votes, 0)`
+
+	rows, err := r.pool.Query(ctx, query, electionID, title)
+	if err != nil {
+		return nil, fmt.Errorf("find aggregate mismatches: %w", err)
+	}
+	defer rows.Close()
+
+	var out []models.Anomaly
+	for rows.Next() {
+		a := models.Anomaly{Type: "aggregate_mismatch", ElectionID: electionID}
+		var (
+			areaID                        *uuid.UUID
+			code, name                    *string
+			candidacyID                   uuid.UUID
+			candidate                     string
+			reported, expected            int
+			stationsReported, stationsExp int
+		)
+		if err := rows.Scan(&a.TallyID, &a.PositionID, &a.Title, &a.Level, &areaID, &code, &name, &a.ReportedAt,
+			&candidacyID, &candidate, &reported, &expected, &stationsReported, &stationsExp); err != nil {
+			return nil, fmt.Errorf("scan aggregate mismatch: %w", err)
+		}
+		a.Area = areaRef(areaID, code, name)
+		a.CandidacyID, a.Candidate = &candidacyID, &candidate
+		a.Reported, a.Expected = &reported, &expected
+		a.StationsReported, a.StationsExpected = &stationsReported, &stationsExp
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// ExcessTurnout finds current tallies whose votes cast exceed their
+// registered voters.
+func (r *AnomalyRepo) ExcessTurnout(ctx context.Context, electionID uuid.UUID, title string) ([]models.Anomaly, error) {
+	query := `
+		WITH ` + tallyContextSQL + `,
+		cast_votes AS (
+			SELECT tc.id, SUM(er.votes) + tc.rejected_votes AS votes
+			FROM tally_ctx tc JOIN election_results er ON er.tally_id = tc.id
+			WHERE tc.is_current
+			GROUP BY tc.id, tc.rejected_votes
+		)
+		SELECT tc.id, tc.position_id, tc.title, tc.level, tc.area_id, tc.area_code, tc.area_name,
+		       tc.reported_at, cv.votes, tc.registered_voters
+		FROM tally_ctx tc JOIN cast_votes cv ON cv.id = tc.id
+		WHERE cv.votes > tc.registered_voters`
+
+	rows, err := r.pool.Query(ctx, query, electionID, title)
+	if err != nil {
+		return nil, fmt.Errorf("find excess turnout: %w", err)
+	}
+	defer rows.Close()
+
+	var out []models.Anomaly
+	for rows.Next() {
+		a := models.Anomaly{Type: "turnout_over_100", ElectionID: electionID}
+		var (
+			areaID           *uuid.UUID
+			code, name       *string
+			cast, registered int
+		)
+		if err := rows.Scan(&a.TallyID, &a.PositionID, &a.Title, &a.Level, &areaID, &code, &name,
+			&a.ReportedAt, &cast, &registered); err != nil {
+			return nil, fmt.Errorf("scan excess turnout: %w", err)
+		}
+		a.Area = areaRef(areaID, code, name)
+		a.Reported, a.Expected = &cast, &registered
+		if registered > 0 {
+			t := math.Round(float64(cast)/float64(registered)*10000) / 100
+			a.Turnout = &t
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// ConflictingResubmissions finds station tallies superseded by a current
+// tally with different candidate votes. The candidate whose votes changed
+// most is reported.
+func (r *AnomalyRepo) ConflictingResubmissions(ctx context.Context, electionID uuid.UUID, title string) ([]models.Anomaly, error) {
+	query := `
+		WITH ` + tallyContextSQL + `,
+		votes AS (
+			SELECT tc.id, jsonb_object_agg(er.candidacy_id, er.votes) AS votes
+			FROM tally_ctx tc JOIN election_results er ON er.tally_id = tc.id
+			WHERE tc.level = 'station'
+			GROUP BY tc.id
+		)
+		SELECT cur.id, old.id, old.is_final, cur.position_id, cur.title, cur.level, cur.area_id,
+		       cur.area_code, cur.area_name, cur.reported_at, cv.votes, ov.votes
+		FROM tally_ctx cur
+		JOIN tally_ctx old ON old.position_id = cur.position_id AND old.level = 'station'
+		     AND old.polling_station_id = cur.polling_station_id AND NOT old.is_current
+		JOIN votes cv ON cv.id = cur.id
+		JOIN votes ov ON ov.id = old.id
+		WHERE cur.is_current AND cur.level = 'station' AND cv.votes <> ov.votes
+		ORDER BY cur.id, old.reported_at`
+
+	rows, err := r.pool.Query(ctx, query, electionID, title)
+	if err != nil {
+		return nil, fmt.Errorf("find conflicting resubmissions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []models.Anomaly
+	for rows.Next() {
+		a := models.Anomaly{Type: "conflicting_resubmission", ElectionID: electionID}
+		var (
+			previousID          uuid.UUID
+			areaID              *uuid.UUID
+			code, name          *string
+			current, superseded map[uuid.UUID]int
+		)
+		if err := rows.Scan(&a.TallyID, &previousID, &a.SupersededFinal, &a.PositionID, &a.Title, &a.Level,
+			&areaID, &code, &name, &a.ReportedAt, &current, &superseded); err != nil {
+			return nil, fmt.Errorf("scan conflicting resubmission: %w", err)
+		}
+		a.Area = areaRef(areaID, code, name)
+		a.RelatedTallyID = &previousID
+
+		candidacyID, now, before := largestChange(current, superseded)
+		a.CandidacyID, a.Reported, a.Expected = &candidacyID, &now, &before
+		out = append(out, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, r.nameCandidates(ctx, out)
+}
+
+// largestChange returns the candidacy whose votes differ most between two
+// submissions of the same form, with its votes in each.
+func largestChange(current, superseded map[uuid.UUID]int) (uuid.UUID, int, int) {
+	var id uuid.UUID
+	best := -1
+	check := func(c uuid.UUID) {
+		d := current[c] - superseded[c]
+		if d < 0 {
+			d = -d
+		}
+		if d > best {
+			id, best = c, d
+		}
+	}
+	for c := range current {
+		check(c)
+	}
+	for c := range superseded {
+		check(c)
+	}
+	return id, current[id], superseded[id]
+}
+
+func (r *AnomalyRepo) nameCandidates(ctx context.Context, anomalies []models.Anomaly) error {
+	var ids []uuid.UUID
+	for _, a := range anomalies {
+		if a.CandidacyID != nil && a.Candidate == nil {
+			ids = append(ids, *a.CandidacyID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := r.pool.Query(ctx,
+		`SELECT c.id, p.first_name || ' ' || p.last_name
+		 FROM candidacies c JOIN politicians p ON p.id = c.politician_id
+		 WHERE c.id = ANY($1)`, ids)
+	if err != nil {
+		return fmt.Errorf("name candidates: %w", err)
+	}
+	defer rows.Close()
+
+	names := make(map[uuid.UUID]string)
+	for rows.Next() {
+		var id uuid.UUID
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return fmt.Errorf("scan candidate name: %w", err)
+		}
+		names[id] = name
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range anomalies {
+		if a := &anomalies[i]; a.CandidacyID != nil && a.Candidate == nil {
+			if name, ok := names[*a.CandidacyID]; ok {
+				a.Candidate = &name
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"jalada/internal/models"
+)
+
+type APIKeyRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewAPIKeyRepo(pool *pgxpool.Pool) *APIKeyRepo {
+	return &APIKeyRepo{pool: pool}
+}
+
+// HashAPIKey returns the value stored in api_keys.key_hash for a plaintext key.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *APIKeyRepo) Create(ctx context.Context, k *models.APIKey, keyHash string) error {
+	err := r.pool.QueryRow(ctx,
+		`INSERT INTO api_keys (name, owner_email, key_prefix, key_hash, tier, rate_limit, burst, daily_quota, created_by)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 RETURNING id, created_at, updated_at`,
+		k.Name, k.OwnerEmail, k.Prefix, keyHash, k.Tier, k.RateLimit, k.Burst, k.DailyQuota, k.CreatedBy,
+	).Scan(&k.ID, &k.CreatedAt, &k.UpdatedAt)
+	if err != nil {
+		return mapWriteError("create api key", err)
+	}
+	return nil
+}
+
+// List returns every key with its usage on day, the UTC date the rate
+// limiter counts against.
+func (r *APIKeyRepo) List(ctx context.Context, day time.Time) ([]models.APIKey, error) {
+	query := `
+		SELECT k.id, k.name, k.owner_email, k.key_prefix, k.tier, k.rate_limit, k.burst, k.daily_quota,
+		       k.created_by, k.last_used_at, k.revoked_at, COALESCE(u.requests, 0), k.created_at, k.updated_at
+		FROM api_keys k
+		LEFT JOIN api_key_usage u ON u.api_key_id = k.id AND u.day = $1
+		ORDER BY k.revoked_at NULLS FIRST, k.created_at DESC`
+
+	rows, err := r.pool.Query(ctx, query, day)
+	if err != nil {
+		return nil, fmt.Errorf("list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []models.APIKey
+	for rows.Next() {
+		var k models.APIKey
+		if err := rows.Scan(
+			&k.ID, &k.Name, &k.OwnerEmail, &k.Prefix, &k.Tier, &k.RateLimit, &k.Burst, &k.DailyQuota,
+			&k.CreatedBy, &k.LastUsedAt, &k.RevokedAt, &k.UsageToday, &k.CreatedAt, &k.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan api key: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+func (r *APIKeyRepo) GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	query := `
+		SELECT id, name, owner_email, key_prefix, tier, rate_limit, burst, daily_quota,
+		       created_by, last_used_at, revoked_at, created_at, updated_at
+		FROM api_keys WHERE key_hash = $1`
+
+	var k models.APIKey
+	err := r.pool.QueryRow(ctx, query, keyHash).Scan(
+		&k.ID, &k.Name, &k.OwnerEmail, &k.Prefix, &k.Tier, &k.RateLimit, &k.Burst, &k.DailyQuota,
+		&k.CreatedBy, &k.LastUsedAt, &k.RevokedAt, &k.CreatedAt, &k.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get api key: %w", err)
+	}
+	return &k, nil
+}
+
+func (r *APIKeyRepo) Revoke(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE api_keys SET revoked_at = NOW(), updated_at = NOW() WHERE id = $1 AND revoked_at IS NULL`, id)
+	if err != nil {
+		return fmt.Errorf("revoke api key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *APIKeyRepo) GetUsage(ctx context.Context, id uuid.UUID, day time.Time) (int, error) {
+	var requests int
+	err := r.pool.QueryRow(ctx,
+		`SELECT requests FROM api_key_usage WHERE api_key_id = $1 AND day = $2`, id, day,
+	).Scan(&requests)
+	if err == pgx.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("get api key usage: %w", err)
+	}
+	return requests, nil
+}
+
+// AddUsage adds per-key request counts to the usage row for day.
+func (r *APIKeyRepo) AddUsage(ctx context.Context, day time.Time, counts map[uuid.UUID]int) error {
+	batch := &pgx.Batch{}
+	for id, n := range counts {
+		batch.Queue(
+			`INSERT INTO api_key_usage (api_key_id, day, requests) VALUES ($1, $2, $3)
+			 ON CONFLICT (api_key_id, day) DO UPDATE SET requests = api_key_usage.requests + EXCLUDED.requests`,
+			id, day, n,
+		)
+		batch.Queue(`UPDATE api_keys SET last_used_at = NOW() WHERE id = $1`, id)
+	}
+	if err := r.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("add api key usage: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"jalada/internal/audit"
+	"jalada/internal/models"
+)
+
+type txKey struct{}
+
+// joinTx returns a context whose audited writes run on tx instead of in a
+// transaction of their own, so they commit or roll back with it.
+func joinTx(ctx context.Context, tx pgx.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// conn returns the transaction joined by ctx, or pool when there is none.
+func conn(ctx context.Context, pool *pgxpool.Pool) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
+
+// withAudit runs fn in a transaction tagged with the actor and source from
+// ctx, which the audit_dossier_change trigger records alongside the change.
+// When ctx has joined a transaction fn runs on it and nothing is committed.
+func withAudit(ctx context.Context, pool *pgxpool.Pool, fn func(pgx.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		if err := setAuditContext(ctx, tx); err != nil {
+			return err
+		}
+		return fn(tx)
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin audited write: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := setAuditContext(ctx, tx); err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func setAuditContext(ctx context.Context, tx pgx.Tx) error {
+	source := ""
+	if id, ok := audit.Source(ctx); ok {
+		source = id.String()
+	}
+	if _, err := tx.Exec(ctx,
+		`SELECT set_config('jalada.actor', $1, true), set_config('jalada.source_id', $2, true)`,
+		audit.Actor(ctx), source,
+	); err != nil {
+		return fmt.Errorf("set audit context: %w", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) audited(ctx context.Context, fn func(pgx.Tx) error) error {
+	return withAudit(ctx, r.pool, fn)
+}
+
+type AuditRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuditRepo(pool *pgxpool.Pool) *AuditRepo {
+	return &AuditRepo{pool: pool}
+}
+
+const revisionColumns = `
+	a.id, a.table_name, a.record_id, a.politician_id, a.action, a.actor, a.source_id, s.name, s.url,
+	a.changed_fields, a.old_data, a.new_data, a.changed_at`
+
+// ListByPolitician returns changes to a politician and every record in
+// their dossier, newest first. table optionally restricts to one table.
+func (r *AuditRepo) ListByPolitician(ctx context.Context, politicianID uuid.UUID, table string, limit, offset int) ([]models.Revision, int, error) {
+	var total int
+	err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM audit_log WHERE politician_id = $1 AND ($2 = '' OR table_name = $2)`,
+		politicianID, table,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count politician history: %w", err)
+	}
+
+	query := `SELECT` + revisionColumns + `
+		FROM audit_log a
+		LEFT JOIN sources s ON s.id = a.source_id
+		WHERE a.politician_id = $1 AND ($2 = '' OR a.table_name = $2)
+		ORDER BY a.changed_at DESC, a.id DESC
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.pool.Query(ctx, query, politicianID, table, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list politician history: %w", err)
+	}
+	defer rows.Close()
+
+	revisions, err := scanRevisions(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return revisions, total, nil
+}
+
+// ListByRecord returns every revision of one record, oldest first.
+func (r *AuditRepo) ListByRecord(ctx context.Context, table string, recordID, politicianID uuid.UUID) ([]models.Revision, error) {
+	query := `SELECT` + revisionColumns + `
+		FROM audit_log a
+		LEFT JOIN sources s ON s.id = a.source_id
+		WHERE a.table_name = $1 AND a.record_id = $2 AND a.politician_id = $3
+		ORDER BY a.changed_at, a.id`
+
+	rows, err := r.pool.Query(ctx, query, table, recordID, politicianID)
+	if err != nil {
+		return nil, fmt.Errorf("list record revisions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRevisions(rows)
+}
+
+func scanRevisions(rows pgx.Rows) ([]models.Revision, error) {
+	var revisions []models.Revision
+	for rows.Next() {
+		var rev models.Revision
+		if err := rows.Scan(
+			&rev.ID, &rev.Table, &rev.RecordID, &rev.PoliticianID, &rev.Action, &rev.Actor,
+			&rev.SourceID, &rev.SourceName, &rev.SourceURL,
+			&rev.ChangedFields, &rev.OldData, &rev.NewData, &rev.ChangedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan revision: %w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"jalada/internal/models"
+)
+
+var AutocompleteTypes = []string{"politician", "party", "constituency", "county", "ward"}
+
+type AutocompleteRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewAutocompleteRepo(pool *pgxpool.Pool) *AutocompleteRepo {
+	return &AutocompleteRepo{pool: pool}
+}
+
+// suggestionSource lists every autocomplete candidate with its popularity.
+// The politician label matches the expression of idx_politicians_search.
+const suggestionSource = `
+	SELECT 'politician' AS type, p.id, p.first_name || ' ' || p.last_name || ' ' || COALESCE(p.other_names, '') AS match,
+	       p.first_name || ' ' || p.last_name AS label, cp.name AS subtitle, p.slug AS key,
+	       '/v1/politicians/' || p.slug AS url, COALESCE(m.mentions, 0)::int AS popularity
+	FROM politicians p
+	LEFT JOIN LATERAL (
+		SELECT COUNT(*) AS mentions FROM article_politician_mentions WHERE politician_id = p.id
+	) m ON true
+	LEFT JOIN LATERAL (
+		SELECT pp.name FROM party_memberships pm JOIN political_parties pp ON pp.id = pm.party_id
+		WHERE pm.politician_id = p.id AND pm.left_date IS NULL
+		ORDER BY pm.joined_date DESC NULLS LAST LIMIT 1
+	) cp ON true
+	UNION ALL
+	SELECT 'party', pp.id, pp.name, pp.name, pp.abbreviation, pp.slug, '/v1/parties/' || pp.slug,
+	       (SELECT COUNT(*) FROM party_memberships pm WHERE pm.party_id = pp.id AND pm.left_date IS NULL)::int
+	FROM political_parties pp
+	UNION ALL
+	SELECT 'county', co.id, co.name, co.name, NULL, co.code, '/v1/counties/' || co.code,
+	       (SELECT COALESCE(SUM(registered_voters), 0) FROM constituencies WHERE county_id = co.id)::int
+	FROM counties co
+	UNION ALL
+	SELECT 'constituency', cn.id, cn.name, cn.name, co.name || ' County', cn.code, '/v1/constituencies/' || cn.code,
+	       COALESCE(cn.registered_voters, 0)
+	FROM constituencies cn JOIN counties co ON co.id = cn.county_id
+	UNION ALL
+	SELECT 'ward', w.id, w.name, w.name, cn.name, w.code, '/v1/constituencies/' || cn.code || '/wards',
+	       COALESCE(w.registered_voters, 0)
+	FROM wards w JOIN constituencies cn ON cn.id = w.constituency_id`
+
+// LoadSuggestions returns every candidate, used to build the in-process
+// prefix index.
+func (r *AutocompleteRepo) LoadSuggestions(ctx context.Context) ([]models.Suggestion, error) {
+	query := `SELECT type, id, label, subtitle, key, url, popularity FROM (` + suggestionSource + `) s`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("load suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	var suggestions []models.Suggestion
+	for rows.Next() {
+		var s models.Suggestion
+		if err := rows.Scan(&s.Type, &s.ID, &s.Label, &s.Subtitle, &s.Key, &s.URL, &s.Popularity); err != nil {
+			return nil, fmt.Errorf("scan suggestion: %w", err)
+		}
+		suggestions = append(suggestions, s)
+	}
+	return suggestions, rows.Err()
+}
+
+// FuzzySuggest matches q by trigram similarity. It catches misspellings
+// that the prefix index cannot.
+func (r *AutocompleteRepo) FuzzySuggest(ctx context.Context, q string, types []string, limit int) ([]models.Suggestion, error) {
+	query := `
+		SELECT type, id, label, subtitle, key, url, popularity, similarity(match, $1) AS score
+		FROM (` + suggestionSource + `) s
+		WHERE type = ANY($2) AND match % $1
+		ORDER BY score DESC, popularity DESC, label
+		LIMIT $3`
+
+	rows, err := r.pool.Query(ctx, query, q, types, limit)
+	if err != nil {
+		return nil, fmt.Errorf("fuzzy suggest: %w", err)
+	}
+	defer rows.Close()
+
+	var suggestions []models.Suggestion
+	for rows.Next() {
+		var s models.Suggestion
+		var score float32
+		if err := rows.Scan(&s.Type, &s.ID, &s.Label, &s.Subtitle, &s.Key, &s.URL, &s.Popularity, &score); err != nil {
+			return nil, fmt.Errorf("scan suggestion: %w", err)
+		}
+		s.Score = float64(score)
+		suggestions = append(suggestions, s)
+	}
+	return suggestions, rows.Err()
+}
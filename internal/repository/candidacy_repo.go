@@ -0,0 +1,468 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"jalada/internal/models"
+)
+
+type CandidacyRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewCandidacyRepo(pool *pgxpool.Pool) *CandidacyRepo {
+	return &CandidacyRepo{pool: pool}
+}
+
+// seatCountyJoins resolves the county of the seat ep, whatever its level,
+// as co.
+const seatCountyJoins = `
+		LEFT JOIN wards sw ON sw.id = ep.ward_id
+		LEFT JOIN constituencies sc ON sc.id = COALESCE(ep.constituency_id, sw.constituency_id)
+		LEFT JOIN counties co ON co.id = COALESCE(ep.county_id, sc.county_id)`
+
+// runningMateJoins finds the other half of c's joint ticket, if any, as
+// rc and rp; runningMateColumns selects it for a runningMateRow.
+const runningMateJoins = `
+		LEFT JOIN joint_tickets jt ON c.id IN (jt.principal_candidacy_id, jt.deputy_candidacy_id)
+		LEFT JOIN candidacies rc ON rc.id = CASE WHEN jt.principal_candidacy_id = c.id
+		                                         THEN jt.deputy_candidacy_id ELSE jt.principal_candidacy_id END
+		LEFT JOIN politicians rp ON rp.id = rc.politician_id`
+
+const runningMateColumns = `rc.id, rp.id, rp.slug, rp.first_name || ' ' || rp.last_name, rc.status,
+		       CASE WHEN jt.principal_candidacy_id = c.id THEN 'deputy' ELSE 'principal' END`
+
+type runningMateRow struct {
+	candidacyID, politicianID *uuid.UUID
+	slug, name, status        *string
+	role                      string
+}
+
+func (m *runningMateRow) dest() []interface{} {
+	return []interface{}{&m.candidacyID, &m.politicianID, &m.slug, &m.name, &m.status, &m.role}
+}
+
+func (m *runningMateRow) mate() *models.RunningMate {
+	if m.candidacyID == nil {
+		return nil
+	}
+	return &models.RunningMate{
+		CandidacyID: *m.candidacyID, PoliticianID: *m.politicianID,
+		Slug: *m.slug, Name: *m.name, Status: *m.status, Role: m.role,
+	}
+}
+
+const candidacySelect = `
+		SELECT c.id, c.politician_id, c.election_id, c.position_id, c.party_id,
+		       c.status, c.declaration_date, c.clearance_date, c.created_at, c.updated_at,
+		       p.first_name || ' ' || p.last_name, p.slug,
+		       pp.name, ep.title, e.name, le.reason, le.occurred_on,
+		       ` + runningMateColumns + `
+		FROM candidacies c
+		JOIN politicians p ON p.id = c.politician_id
+		JOIN elective_positions ep ON ep.id = c.position_id
+		JOIN elections e ON e.id = c.election_id
+		LEFT JOIN political_parties pp ON pp.id = c.party_id
+		LEFT JOIN LATERAL (
+			SELECT ce.reason, ce.occurred_on FROM candidacy_events ce
+			WHERE ce.candidacy_id = c.id
+			ORDER BY ce.occurred_on DESC, ce.recorded_at DESC
+			LIMIT 1
+		) le ON true` + runningMateJoins + seatCountyJoins
+
+// List returns an election's candidacies with the reason for each one's
+// current status.
+func (r *CandidacyRepo) List(ctx context.Context, electionID uuid.UUID, f models.CandidateFilter) ([]models.CandidacyDetail, error) {
+	args := []interface{}{electionID}
+	where := " WHERE c.election_id = $1"
+	// add appends a condition whose placeholders are all written as %[1]d.
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		where += " AND " + fmt.Sprintf(cond, len(args))
+	}
+
+	if len(f.Statuses) > 0 {
+		add(`c.status = ANY($%[1]d)`, f.Statuses)
+	}
+	if f.Title != "" {
+		add(`ep.title = $%[1]d`, f.Title)
+	}
+	if f.Party != "" {
+		add(`(pp.slug = $%[1]d OR lower(pp.abbreviation) = lower($%[1]d))`, f.Party)
+	}
+	if f.County != "" {
+		add(`co.code = $%[1]d`, f.County)
+	}
+
+	rows, err := r.pool.Query(ctx, candidacySelect+where+`
+		ORDER BY ep.title, co.code NULLS FIRST, p.last_name`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list candidacies: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []models.CandidacyDetail
+	for rows.Next() {
+		cd, err := scanCandidacy(rows)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, *cd)
+	}
+	return candidates, rows.Err()
+}
+
+func (r *CandidacyRepo) Get(ctx context.Context, electionID, id uuid.UUID) (*models.CandidacyDetail, error) {
+	row := r.pool.QueryRow(ctx, candidacySelect+` WHERE c.id = $1 AND c.election_id = $2`, id, electionID)
+	cd, err := scanCandidacy(row)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return cd, err
+}
+
+func scanCandidacy(row pgx.Row) (*models.CandidacyDetail, error) {
+	var cd models.CandidacyDetail
+	var mate runningMateRow
+	err := row.Scan(append([]interface{}{
+		&cd.ID, &cd.PoliticianID, &cd.ElectionID, &cd.PositionID, &cd.PartyID,
+		&cd.Status, &cd.DeclarationDate, &cd.ClearanceDate, &cd.CreatedAt, &cd.UpdatedAt,
+		&cd.PoliticianName, &cd.PoliticianSlug,
+		&cd.PartyName, &cd.PositionTitle, &cd.ElectionName, &cd.StatusReason, &cd.StatusChangedOn,
+	}, mate.dest()...)...)
+	if err == pgx.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scan candidacy: %w", err)
+	}
+	cd.RunningMate = mate.mate()
+	return &cd, nil
+}
+
+// Create records a declared candidacy together with the declaration event
+// that starts its history.
+func (r *CandidacyRepo) Create(ctx context.Context, c *models.Candidacy, ev *models.CandidacyEvent) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx,
+			`INSERT INTO candidacies (politician_id, election_id, position_id, party_id, status, declaration_date)
+			 VALUES ($1, $2, $3, $4, $5, $6)
+			 RETURNING id, created_at, updated_at`,
+			c.PoliticianID, c.ElectionID, c.PositionID, c.PartyID, c.Status, c.DeclarationDate,
+		).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		ev.CandidacyID = c.ID
+		return insertEvent(ctx, tx, ev)
+	})
+	if err != nil {
+		return mapWriteError("create candidacy", err)
+	}
+	return nil
+}
+
+// RecordEvent moves each candidacy from ev.FromStatus to ev.Status and
+// logs the change, all in one transaction. It returns ErrConflict if a
+// candidacy's status is no longer ev.FromStatus, and ErrNotFound if it
+// does not exist.
+func (r *CandidacyRepo) RecordEvent(ctx context.Context, electionID uuid.UUID, evs ...*models.CandidacyEvent) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		for _, ev := range evs {
+			tag, err := tx.Exec(ctx,
+				`UPDATE candidacies
+				 SET status = $4,
+				     clearance_date = CASE WHEN $4 = 'cleared' THEN $5 ELSE clearance_date END
+				 WHERE id = $1 AND election_id = $2 AND status = $3`,
+				ev.CandidacyID, electionID, ev.FromStatus, ev.Status, ev.OccurredOn,
+			)
+			if err != nil {
+				return err
+			}
+			if tag.RowsAffected() == 0 {
+				var exists bool
+				err := tx.QueryRow(ctx,
+					`SELECT EXISTS (SELECT 1 FROM candidacies WHERE id = $1 AND election_id = $2)`,
+					ev.CandidacyID, electionID,
+				).Scan(&exists)
+				if err != nil {
+					return err
+				}
+				if !exists {
+					return ErrNotFound
+				}
+				return fmt.Errorf("%w: candidacy status has changed", ErrConflict)
+			}
+			if err := insertEvent(ctx, tx, ev); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == ErrNotFound {
+		return err
+	}
+	if err != nil {
+		return mapWriteError("record candidacy event", err)
+	}
+	return nil
+}
+
+func insertEvent(ctx context.Context, tx pgx.Tx, ev *models.CandidacyEvent) error {
+	return tx.QueryRow(ctx,
+		`INSERT INTO candidacy_events (candidacy_id, stage, from_status, to_status, reason, source_url,
+		                               source_id, occurred_on, recorded_by)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 RETURNING id, recorded_at`,
+		ev.CandidacyID, ev.Stage, ev.FromStatus, ev.Status, ev.Reason, ev.SourceURL,
+		ev.SourceID, ev.OccurredOn, ev.RecordedBy,
+	).Scan(&ev.ID, &ev.RecordedAt)
+}
+
+// Events returns a candidacy's status changes, oldest first.
+func (r *CandidacyRepo) Events(ctx context.Context, candidacyID uuid.UUID) ([]models.CandidacyEvent, error) {
+	query := `
+		SELECT id, candidacy_id, stage, from_status, to_status, reason, source_url,
+		       source_id, occurred_on, recorded_by, recorded_at
+		FROM candidacy_events
+		WHERE candidacy_id = $1
+		ORDER BY occurred_on, recorded_at`
+
+	rows, err := r.pool.Query(ctx, query, candidacyID)
+	if err != nil {
+		return nil, fmt.Errorf("get candidacy events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.CandidacyEvent
+	for rows.Next() {
+		var ev models.CandidacyEvent
+		if err := rows.Scan(&ev.ID, &ev.CandidacyID, &ev.Stage, &ev.FromStatus, &ev.Status, &ev.Reason,
+			&ev.SourceURL, &ev.SourceID, &ev.OccurredOn, &ev.RecordedBy, &ev.RecordedAt); err != nil {
+			return nil, fmt.Errorf("scan candidacy event: %w", err)
+		}
+		events = append(events, ev)
+	}
+	return events, rows.Err()
+}
+
+const primarySelect = `
+		SELECT pr.id, pr.election_id, pr.position_id, ep.title, pr.party_id, pp.name,
+		       pr.politician_id, p.first_name || ' ' || p.last_name, p.slug,
+		       pr.method, pr.outcome, pr.votes, pr.held_on, pr.notes, pr.source_url,
+		       pr.created_at, pr.updated_at
+		FROM party_primaries pr
+		JOIN elective_positions ep ON ep.id = pr.position_id
+		JOIN political_parties pp ON pp.id = pr.party_id
+		JOIN politicians p ON p.id = pr.politician_id` + seatCountyJoins
+
+// ListPrimaries returns an election's party primary outcomes grouped by
+// seat and party, winners first.
+func (r *CandidacyRepo) ListPrimaries(ctx context.Context, electionID uuid.UUID, f models.PrimaryFilter) ([]models.PartyPrimary, error) {
+	args := []interface{}{electionID}
+	where := " WHERE pr.election_id = $1"
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		where += " AND " + fmt.Sprintf(cond, len(args))
+	}
+
+	if len(f.Outcomes) > 0 {
+		add(`pr.outcome = ANY($%[1]d)`, f.Outcomes)
+	}
+	if f.Title != "" {
+		add(`ep.title = $%[1]d`, f.Title)
+	}
+	if f.Party != "" {
+		add(`(pp.slug = $%[1]d OR lower(pp.abbreviation) = lower($%[1]d))`, f.Party)
+	}
+	if f.County != "" {
+		add(`co.code = $%[1]d`, f.County)
+	}
+
+	return r.primaries(ctx, primarySelect+where+`
+		ORDER BY ep.title, co.code NULLS FIRST, pr.position_id, pp.name, pr.outcome = 'won' DESC, pr.votes DESC NULLS LAST`, args...)
+}
+
+// PrimariesFor returns a politician's primaries for one seat in an
+// election, across parties.
+func (r *CandidacyRepo) PrimariesFor(ctx context.Context, electionID, positionID, politicianID uuid.UUID) ([]models.PartyPrimary, error) {
+	return r.primaries(ctx, primarySelect+`
+		WHERE pr.election_id = $1 AND pr.position_id = $2 AND pr.politician_id = $3
+		ORDER BY pr.held_on NULLS LAST, pr.created_at`, electionID, positionID, politicianID)
+}
+
+func (r *CandidacyRepo) GetPrimary(ctx context.Context, electionID, id uuid.UUID) (*models.PartyPrimary, error) {
+	primaries, err := r.primaries(ctx, primarySelect+` WHERE pr.id = $1 AND pr.election_id = $2`, id, electionID)
+	if err != nil || len(primaries) == 0 {
+		return nil, err
+	}
+	return &primaries[0], nil
+}
+
+func (r *CandidacyRepo) primaries(ctx context.Context, query string, args ...interface{}) ([]models.PartyPrimary, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get party primaries: %w", err)
+	}
+	defer rows.Close()
+
+	var primaries []models.PartyPrimary
+	for rows.Next() {
+		var pr models.PartyPrimary
+		if err := rows.Scan(&pr.ID, &pr.ElectionID, &pr.PositionID, &pr.PositionTitle, &pr.PartyID, &pr.PartyName,
+			&pr.PoliticianID, &pr.PoliticianName, &pr.PoliticianSlug,
+			&pr.Method, &pr.Outcome, &pr.Votes, &pr.HeldOn, &pr.Notes, &pr.SourceURL,
+			&pr.CreatedAt, &pr.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan party primary: %w", err)
+		}
+		primaries = append(primaries, pr)
+	}
+	return primaries, rows.Err()
+}
+
+func (r *CandidacyRepo) CreatePrimary(ctx context.Context, pr *models.PartyPrimary) error {
+	err := r.pool.QueryRow(ctx,
+		`INSERT INTO party_primaries (election_id, position_id, party_id, politician_id, method, outcome,
+		                              votes, held_on, notes, source_url)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 RETURNING id, created_at, updated_at`,
+		pr.ElectionID, pr.PositionID, pr.PartyID, pr.PoliticianID, pr.Method, pr.Outcome,
+		pr.Votes, pr.HeldOn, pr.Notes, pr.SourceURL,
+	).Scan(&pr.ID, &pr.CreatedAt, &pr.UpdatedAt)
+	if err != nil {
+		return mapWriteError("create party primary", err)
+	}
+	return nil
+}
+
+func (r *CandidacyRepo) UpdatePrimary(ctx context.Context, pr *models.PartyPrimary) error {
+	err := r.pool.QueryRow(ctx,
+		`UPDATE party_primaries
+		 SET position_id = $3, party_id = $4, politician_id = $5, method = $6, outcome = $7,
+		     votes = $8, held_on = $9, notes = $10, source_url = $11
+		 WHERE id = $1 AND election_id = $2
+		 RETURNING created_at, updated_at`,
+		pr.ID, pr.ElectionID, pr.PositionID, pr.PartyID, pr.PoliticianID, pr.Method, pr.Outcome,
+		pr.Votes, pr.HeldOn, pr.Notes, pr.SourceURL,
+	).Scan(&pr.CreatedAt, &pr.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return ErrNotFound
+	}
+	if err != nil {
+		return mapWriteError("update party primary", err)
+	}
+	return nil
+}
+
+const ticketSelect = `
+		SELECT jt.id, jt.election_id, jt.principal_candidacy_id, jt.deputy_candidacy_id, ep.title,
+		       co.id, co.code, co.name, COALESCE(pp.abbreviation, pp.name),
+		       pc.politician_id, p.slug, p.first_name || ' ' || p.last_name, pc.status,
+		       dc.politician_id, dp.slug, dp.first_name || ' ' || dp.last_name, dc.status,
+		       jt.nominated_on, jt.source_url, jt.created_at
+		FROM joint_tickets jt
+		JOIN candidacies pc ON pc.id = jt.principal_candidacy_id
+		JOIN politicians p ON p.id = pc.politician_id
+		JOIN candidacies dc ON dc.id = jt.deputy_candidacy_id
+		JOIN politicians dp ON dp.id = dc.politician_id
+		JOIN elective_positions ep ON ep.id = pc.position_id
+		LEFT JOIN counties co ON co.id = ep.county_id
+		LEFT JOIN political_parties pp ON pp.id = pc.party_id`
+
+// ListTickets returns an election's joint tickets, presidential first and
+// then by county. title optionally keeps one kind.
+func (r *CandidacyRepo) ListTickets(ctx context.Context, electionID uuid.UUID, title, county string) ([]models.JointTicket, error) {
+	return r.tickets(ctx, ticketSelect+`
+		WHERE jt.election_id = $1 AND ($2 = '' OR ep.title = $2) AND ($3 = '' OR co.code = $3)
+		ORDER BY ep.level = 'national' DESC, co.code, p.last_name`, electionID, title, county)
+}
+
+func (r *CandidacyRepo) GetTicket(ctx context.Context, electionID, id uuid.UUID) (*models.JointTicket, error) {
+	tickets, err := r.tickets(ctx, ticketSelect+` WHERE jt.id = $1 AND jt.election_id = $2`, id, electionID)
+	if err != nil || len(tickets) == 0 {
+		return nil, err
+	}
+	return &tickets[0], nil
+}
+
+func (r *CandidacyRepo) tickets(ctx context.Context, query string, args ...interface{}) ([]models.JointTicket, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get joint tickets: %w", err)
+	}
+	defer rows.Close()
+
+	var tickets []models.JointTicket
+	for rows.Next() {
+		var (
+			t                      models.JointTicket
+			countyID               *uuid.UUID
+			countyCode, countyName *string
+		)
+		if err := rows.Scan(&t.ID, &t.ElectionID, &t.PrincipalCandidacyID, &t.DeputyCandidacyID, &t.Title,
+			&countyID, &countyCode, &countyName, &t.Party,
+			&t.Principal.PoliticianID, &t.Principal.Slug, &t.Principal.Name, &t.Principal.Status,
+			&t.Deputy.PoliticianID, &t.Deputy.Slug, &t.Deputy.Name, &t.Deputy.Status,
+			&t.NominatedOn, &t.SourceURL, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan joint ticket: %w", err)
+		}
+		t.Area = areaRef(countyID, countyCode, countyName)
+		t.Principal.CandidacyID, t.Principal.Role = t.PrincipalCandidacyID, "principal"
+		t.Deputy.CandidacyID, t.Deputy.Role = t.DeputyCandidacyID, "deputy"
+		tickets = append(tickets, t)
+	}
+	return tickets, rows.Err()
+}
+
+func (r *CandidacyRepo) CreateTicket(ctx context.Context, t *models.JointTicket) error {
+	err := r.pool.QueryRow(ctx,
+		`INSERT INTO joint_tickets (election_id, principal_candidacy_id, deputy_candidacy_id, nominated_on, source_url)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING id, created_at`,
+		t.ElectionID, t.PrincipalCandidacyID, t.DeputyCandidacyID, t.NominatedOn, t.SourceURL,
+	).Scan(&t.ID, &t.CreatedAt)
+	if err != nil {
+		return mapWriteError("create joint ticket", err)
+	}
+	return nil
+}
+
+func (r *CandidacyRepo) DeleteTicket(ctx context.Context, electionID, id uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM joint_tickets WHERE id = $1 AND election_id = $2`, id, electionID)
+	if err != nil {
+		return fmt.Errorf("delete joint ticket: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RunningMates maps every candidacy on a joint ticket in the election to
+// the other half of its ticket.
+func (r *CandidacyRepo) RunningMates(ctx context.Context, electionID uuid.UUID) (map[uuid.UUID]*models.RunningMate, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT c.id, `+runningMateColumns+`
+		FROM candidacies c`+runningMateJoins+`
+		WHERE c.election_id = $1 AND jt.id IS NOT NULL`, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("get running mates: %w", err)
+	}
+	defer rows.Close()
+
+	mates := make(map[uuid.UUID]*models.RunningMate)
+	for rows.Next() {
+		var id uuid.UUID
+		var mate runningMateRow
+		if err := rows.Scan(append([]interface{}{&id}, mate.dest()...)...); err != nil {
+			return nil, fmt.Errorf("scan running mate: %w", err)
+		}
+		mates[id] = mate.mate()
+	}
+	return mates, rows.Err()
+}
@@ -60,59 +60,28 @@ func (r *ElectionRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Elect
 	return &e, nil
 }
 
-func (r *ElectionRepo) GetCandidates(ctx context.Context, electionID uuid.UUID) ([]models.CandidacyDetail, error) {
-	query := `
-		SELECT c.id, c.politician_id, c.election_id, c.position_id, c.party_id,
-		       c.status, c.declaration_date, c.clearance_date, c.created_at, c.updated_at,
-		       p.first_name || ' ' || p.last_name, p.slug,
-		       pp.name, ep.title, e.name
-		FROM candidacies c
-		JOIN politicians p ON p.id = c.politician_id
-		JOIN elective_positions ep ON ep.id = c.position_id
-		JOIN elections e ON e.id = c.election_id
-		LEFT JOIN political_parties pp ON pp.id = c.party_id
-		WHERE c.election_id = $1
-		ORDER BY ep.title, p.last_name`
-
-	rows, err := r.pool.Query(ctx, query, electionID)
-	if err != nil {
-		return nil, fmt.Errorf("get election candidates: %w", err)
-	}
-	defer rows.Close()
-
-	var candidates []models.CandidacyDetail
-	for rows.Next() {
-		var cd models.CandidacyDetail
-		if err := rows.Scan(
-			&cd.ID, &cd.PoliticianID, &cd.ElectionID, &cd.PositionID, &cd.PartyID,
-			&cd.Status, &cd.DeclarationDate, &cd.ClearanceDate, &cd.CreatedAt, &cd.UpdatedAt,
-			&cd.PoliticianName, &cd.PoliticianSlug,
-			&cd.PartyName, &cd.PositionTitle, &cd.ElectionName,
-		); err != nil {
-			return nil, fmt.Errorf("scan candidacy: %w", err)
-		}
-		candidates = append(candidates, cd)
-	}
-	return candidates, nil
-}
-
 func (r *ElectionRepo) GetResults(ctx context.Context, electionID uuid.UUID) ([]models.ResultSummary, error) {
 	query := `
-		SELECT c.id,
+		SELECT c.id, ep.id, ep.title,
 		       p.first_name || ' ' || p.last_name,
 		       pp.name,
 		       COALESCE(SUM(er.votes), 0),
-		       CASE WHEN SUM(SUM(er.votes)) OVER () > 0
-		            THEN ROUND(SUM(er.votes)::numeric / SUM(SUM(er.votes)) OVER () * 100, 2)
+		       CASE WHEN SUM(SUM(er.votes)) OVER seat > 0
+		            THEN ROUND(COALESCE(SUM(er.votes), 0)::numeric / SUM(SUM(er.votes)) OVER seat * 100, 2)
 		            ELSE 0 END,
-		       BOOL_AND(er.is_final)
+		       COALESCE(BOOL_AND(er.is_final), false)
 		FROM candidacies c
+		JOIN elective_positions ep ON ep.id = c.position_id
 		JOIN politicians p ON p.id = c.politician_id
 		LEFT JOIN political_parties pp ON pp.id = c.party_id
-		LEFT JOIN election_results er ON er.candidacy_id = c.id
+		LEFT JOIN joint_tickets jt ON jt.deputy_candidacy_id = c.id
+		LEFT JOIN election_results er ON er.candidacy_id = COALESCE(jt.principal_candidacy_id, c.id) AND er.level = 'station'
+		     AND (er.tally_id IS NULL OR EXISTS (
+		         SELECT 1 FROM result_tallies t WHERE t.id = er.tally_id AND t.is_current))
 		WHERE c.election_id = $1
-		GROUP BY c.id, p.first_name, p.last_name, pp.name
-		ORDER BY COALESCE(SUM(er.votes), 0) DESC`
+		GROUP BY c.id, ep.id, ep.title, p.first_name, p.last_name, pp.name
+		WINDOW seat AS (PARTITION BY ep.id)
+		ORDER BY ep.title, ep.id, COALESCE(SUM(er.votes), 0) DESC`
 
 	rows, err := r.pool.Query(ctx, query, electionID)
 	if err != nil {
@@ -123,7 +92,7 @@ func (r *ElectionRepo) GetResults(ctx context.Context, electionID uuid.UUID) ([]
 	var results []models.ResultSummary
 	for rows.Next() {
 		var rs models.ResultSummary
-		if err := rows.Scan(&rs.CandidacyID, &rs.PoliticianName, &rs.PartyName, &rs.TotalVotes, &rs.Percentage, &rs.IsFinal); err != nil {
+		if err := rows.Scan(&rs.CandidacyID, &rs.PositionID, &rs.PositionTitle, &rs.PoliticianName, &rs.PartyName, &rs.TotalVotes, &rs.Percentage, &rs.IsFinal); err != nil {
 			return nil, fmt.Errorf("scan result: %w", err)
 		}
 		results = append(results, rs)
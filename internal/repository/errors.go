@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrNotFound         = errors.New("record not found")
+	ErrConflict         = errors.New("record conflicts with an existing record")
+	ErrInvalidReference = errors.New("referenced record does not exist")
+	ErrConstraint       = errors.New("value violates a database constraint")
+)
+
+// mapWriteError translates Postgres constraint violations into the
+// repository's sentinel errors so callers can pick a status code.
+func mapWriteError(op string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return fmt.Errorf("%s: %w (%s)", op, ErrConflict, pgErr.ConstraintName)
+		case "23503":
+			return fmt.Errorf("%s: %w (%s)", op, ErrInvalidReference, pgErr.ConstraintName)
+		case "23514", "23502", "22P02", "22007", "22008":
+			return fmt.Errorf("%s: %w (%s)", op, ErrConstraint, pgErr.Message)
+		}
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
@@ -4,12 +4,28 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"jalada/internal/geo"
 	"jalada/internal/models"
 )
 
+// stationDistanceSQL is the haversine distance in km from ($1, $2) to the
+// polling station aliased ps.
+const stationDistanceSQL = `6371 * 2 * ASIN(SQRT(
+		POWER(SIN(RADIANS(ps.latitude - $1) / 2), 2) +
+		COS(RADIANS($1)) * COS(RADIANS(ps.latitude)) *
+		POWER(SIN(RADIANS(ps.longitude - $2) / 2), 2)))`
+
+// stationBoxSQL keeps geolocated polling stations inside the box
+// ($3..$4 latitude, $5..$6 longitude), which idx_polling_stations_location
+// serves.
+const stationBoxSQL = `ps.latitude IS NOT NULL AND ps.longitude IS NOT NULL
+		  AND ps.latitude BETWEEN $3 AND $4
+		  AND ps.longitude BETWEEN $5 AND $6`
+
 type GeographyRepo struct {
 	pool *pgxpool.Pool
 }
@@ -93,7 +109,7 @@ func (r *GeographyRepo) GetConstituencyByCode(ctx context.Context, code string)
 
 func (r *GeographyRepo) GetWardsByConstituency(ctx context.Context, constituencyCode string) ([]models.Ward, error) {
 	query := `
-		SELECT w.id, w.constituency_id, w.code, w.name, w.slug, w.registered_voters, w.created_at
+		SELECT w.id, w.constituency_id, w.code, w.name, w.slug, COALESCE(w.registered_voters, 0), w.created_at
 		FROM wards w
 		JOIN constituencies c ON c.id = w.constituency_id
 		WHERE c.code = $1
@@ -118,7 +134,7 @@ func (r *GeographyRepo) GetWardsByConstituency(ctx context.Context, constituency
 
 func (r *GeographyRepo) GetPollingStationsByConstituency(ctx context.Context, constituencyCode string) ([]models.PollingStation, error) {
 	query := `
-		SELECT ps.id, ps.ward_id, ps.code, ps.name, ps.latitude, ps.longitude, ps.registered_voters, ps.created_at
+		SELECT ps.id, ps.ward_id, ps.code, ps.name, ps.latitude, ps.longitude, COALESCE(ps.registered_voters, 0), ps.created_at
 		FROM polling_stations ps
 		JOIN wards w ON w.id = ps.ward_id
 		JOIN constituencies c ON c.id = w.constituency_id
@@ -178,3 +194,135 @@ func (r *GeographyRepo) GetCandidatesByConstituency(ctx context.Context, constit
 	}
 	return candidates, nil
 }
+
+func (r *GeographyRepo) GetWardByCode(ctx context.Context, code string) (*models.Ward, error) {
+	query := `SELECT id, constituency_id, code, name, slug, COALESCE(registered_voters, 0), created_at FROM wards WHERE code = $1`
+
+	var w models.Ward
+	err := r.pool.QueryRow(ctx, query, code).Scan(&w.ID, &w.ConstituencyID, &w.Code, &w.Name, &w.Slug, &w.RegisteredVoters, &w.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get ward: %w", err)
+	}
+	return &w, nil
+}
+
+// boundaryTables maps a geo level to its table.
+var boundaryTables = map[string]string{
+	geo.LevelCounty:       "counties",
+	geo.LevelConstituency: "constituencies",
+	geo.LevelWard:         "wards",
+}
+
+// GetBoundary returns an area as a GeoJSON Feature. It returns nil if the
+// area does not exist; the geometry is null if no boundary is loaded.
+func (r *GeographyRepo) GetBoundary(ctx context.Context, level, code string) (*geo.Feature, error) {
+	table, ok := boundaryTables[level]
+	if !ok {
+		return nil, fmt.Errorf("get boundary: unknown level %q", level)
+	}
+	query := `SELECT id, code, name, boundary FROM ` + table + ` WHERE code = $1`
+
+	var (
+		id       uuid.UUID
+		c, name  string
+		boundary []byte
+	)
+	err := r.pool.QueryRow(ctx, query, code).Scan(&id, &c, &name, &boundary)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get boundary: %w", err)
+	}
+	return &geo.Feature{
+		Type:       "Feature",
+		Properties: map[string]interface{}{"id": id, "level": level, "code": c, "name": name},
+		Geometry:   boundary,
+	}, nil
+}
+
+// LoadBoundaries returns every area with a boundary at one level, with the
+// code of its parent area.
+func (r *GeographyRepo) LoadBoundaries(ctx context.Context, level string) ([]geo.Area, error) {
+	var query string
+	switch level {
+	case geo.LevelCounty:
+		query = `SELECT id, code, name, '', boundary FROM counties WHERE boundary IS NOT NULL`
+	case geo.LevelConstituency:
+		query = `
+			SELECT c.id, c.code, c.name, co.code, c.boundary
+			FROM constituencies c JOIN counties co ON co.id = c.county_id
+			WHERE c.boundary IS NOT NULL`
+	case geo.LevelWard:
+		query = `
+			SELECT w.id, w.code, w.name, c.code, w.boundary
+			FROM wards w JOIN constituencies c ON c.id = w.constituency_id
+			WHERE w.boundary IS NOT NULL`
+	default:
+		return nil, fmt.Errorf("load boundaries: unknown level %q", level)
+	}
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("load %s boundaries: %w", level, err)
+	}
+	defer rows.Close()
+
+	var areas []geo.Area
+	for rows.Next() {
+		a := geo.Area{Level: level}
+		var boundary []byte
+		if err := rows.Scan(&a.ID, &a.Code, &a.Name, &a.ParentCode, &boundary); err != nil {
+			return nil, fmt.Errorf("scan %s boundary: %w", level, err)
+		}
+		if a.Shape, err = geo.ParseGeometry(boundary); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", level, a.Code, err)
+		}
+		areas = append(areas, a)
+	}
+	return areas, rows.Err()
+}
+
+// NearbyPollingStations returns geolocated polling stations within
+// radiusKM of a coordinate, nearest first.
+func (r *GeographyRepo) NearbyPollingStations(ctx context.Context, lat, lng, radiusKM float64, limit int) ([]models.NearbyPollingStation, error) {
+	query := `
+		SELECT * FROM (
+			SELECT ps.id, ps.ward_id, ps.code, ps.name, ps.latitude, ps.longitude, COALESCE(ps.registered_voters, 0), ps.created_at,
+			       w.code AS ward_code, w.name AS ward_name, c.code AS constituency_code,
+			       c.name AS constituency_name, co.code AS county_code, co.name AS county_name,
+			       ` + stationDistanceSQL + ` AS distance_km
+			FROM polling_stations ps
+			JOIN wards w ON w.id = ps.ward_id
+			JOIN constituencies c ON c.id = w.constituency_id
+			JOIN counties co ON co.id = c.county_id
+			WHERE ` + stationBoxSQL + `
+		) nearby
+		WHERE distance_km <= $7
+		ORDER BY distance_km
+		LIMIT $8`
+
+	box := geo.BoundsAround(geo.Point{Lat: lat, Lng: lng}, radiusKM)
+	rows, err := r.pool.Query(ctx, query, lat, lng, box.MinLat, box.MaxLat, box.MinLng, box.MaxLng, radiusKM, limit)
+	if err != nil {
+		return nil, fmt.Errorf("nearby polling stations: %w", err)
+	}
+	defer rows.Close()
+
+	var stations []models.NearbyPollingStation
+	for rows.Next() {
+		var s models.NearbyPollingStation
+		if err := rows.Scan(
+			&s.ID, &s.WardID, &s.Code, &s.Name, &s.Latitude, &s.Longitude, &s.RegisteredVoters, &s.CreatedAt,
+			&s.WardCode, &s.WardName, &s.ConstituencyCode, &s.ConstituencyName, &s.CountyCode, &s.CountyName,
+			&s.DistanceKM,
+		); err != nil {
+			return nil, fmt.Errorf("scan nearby polling station: %w", err)
+		}
+		stations = append(stations, s)
+	}
+	return stations, rows.Err()
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -26,7 +27,7 @@ func (r *NewsRepo) ListArticles(ctx context.Context, f models.NewsFilter) ([]mod
 
 	countQuery := `SELECT COUNT(*) FROM news_articles na WHERE 1=1`
 	dataQuery := `
-		SELECT na.id, na.source_id, na.title, na.content, na.summary, na.url,
+		SELECT na.id, na.source_id, na.title, na.content, na.summary, na.url, na.canonical_url,
 		       na.author, na.image_url, na.published_at, na.scraped_at,
 		       na.category, na.is_election_related, na.created_at
 		FROM news_articles na WHERE 1=1`
@@ -78,7 +79,7 @@ func (r *NewsRepo) ListArticles(ctx context.Context, f models.NewsFilter) ([]mod
 	for rows.Next() {
 		var a models.NewsArticle
 		if err := rows.Scan(
-			&a.ID, &a.SourceID, &a.Title, &a.Content, &a.Summary, &a.URL,
+			&a.ID, &a.SourceID, &a.Title, &a.Content, &a.Summary, &a.URL, &a.CanonicalURL,
 			&a.Author, &a.ImageURL, &a.PublishedAt, &a.ScrapedAt,
 			&a.Category, &a.IsElectionRelated, &a.CreatedAt,
 		); err != nil {
@@ -91,13 +92,13 @@ func (r *NewsRepo) ListArticles(ctx context.Context, f models.NewsFilter) ([]mod
 
 func (r *NewsRepo) GetArticleByID(ctx context.Context, id uuid.UUID) (*models.NewsArticle, error) {
 	query := `
-		SELECT id, source_id, title, content, summary, url, author, image_url,
+		SELECT id, source_id, title, content, summary, url, canonical_url, author, image_url,
 		       published_at, scraped_at, category, is_election_related, created_at
 		FROM news_articles WHERE id = $1`
 
 	var a models.NewsArticle
 	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&a.ID, &a.SourceID, &a.Title, &a.Content, &a.Summary, &a.URL,
+		&a.ID, &a.SourceID, &a.Title, &a.Content, &a.Summary, &a.URL, &a.CanonicalURL,
 		&a.Author, &a.ImageURL, &a.PublishedAt, &a.ScrapedAt,
 		&a.Category, &a.IsElectionRelated, &a.CreatedAt,
 	)
@@ -119,56 +120,263 @@ func (r *NewsRepo) GetArticlesByPolitician(ctx context.Context, politicianID uui
 	return r.ListArticles(ctx, f)
 }
 
+const newsSourceSelect = `
+		SELECT ns.id, ns.name, ns.url, ns.feed_url, ns.type, ns.outlet, ns.active, ns.extract_full_text,
+		       ns.created_at, ns.updated_at,
+		       sc.list_url, sc.link_selector, sc.title_selector, sc.body_selector, sc.date_selector,
+		       sc.date_attribute, sc.date_layout, sc.author_selector, sc.max_articles, sc.updated_at,
+		       fs.etag, fs.last_modified, fs.last_fetched_at, fs.last_success_at, fs.last_status_code,
+		       fs.last_error, fs.consecutive_failures, fs.next_fetch_at, fs.deactivated_at
+		FROM news_sources ns
+		LEFT JOIN news_source_scrapers sc ON sc.source_id = ns.id
+		LEFT JOIN news_source_fetch_state fs ON fs.source_id = ns.id`
+
 func (r *NewsRepo) ListSources(ctx context.Context) ([]models.NewsSource, error) {
-	query := `
-		SELECT id, name, url, feed_url, type, outlet, active, created_at, updated_at
-		FROM news_sources ORDER BY name`
+	return r.sources(ctx, newsSourceSelect+` ORDER BY ns.name`)
+}
 
-	rows, err := r.pool.Query(ctx, query)
+// GetDueSources returns the active sources that are not backing off after
+// repeated failures.
+func (r *NewsRepo) GetDueSources(ctx context.Context) ([]models.NewsSource, error) {
+	return r.sources(ctx, newsSourceSelect+`
+		WHERE ns.active = true AND (fs.next_fetch_at IS NULL OR fs.next_fetch_at <= NOW())
+		ORDER BY ns.name`)
+}
+
+func (r *NewsRepo) GetSource(ctx context.Context, id uuid.UUID) (*models.NewsSource, error) {
+	sources, err := r.sources(ctx, newsSourceSelect+` WHERE ns.id = $1`, id)
+	if err != nil || len(sources) == 0 {
+		return nil, err
+	}
+	return &sources[0], nil
+}
+
+func (r *NewsRepo) sources(ctx context.Context, query string, args ...interface{}) ([]models.NewsSource, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("list sources: %w", err)
+		return nil, fmt.Errorf("get news sources: %w", err)
 	}
 	defer rows.Close()
 
 	var sources []models.NewsSource
 	for rows.Next() {
-		var s models.NewsSource
-		if err := rows.Scan(&s.ID, &s.Name, &s.URL, &s.FeedURL, &s.Type, &s.Outlet, &s.Active, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		var (
+			s                          models.NewsSource
+			listURL, link, title, body *string
+			sc                         models.ScraperConfig
+			maxArticles                *int
+			scraperUpdated             *time.Time
+			fs                         models.FetchState
+			lastFetched                *time.Time
+			failures                   *int
+		)
+		if err := rows.Scan(&s.ID, &s.Name, &s.URL, &s.FeedURL, &s.Type, &s.Outlet, &s.Active, &s.ExtractFullText,
+			&s.CreatedAt, &s.UpdatedAt,
+			&listURL, &link, &title, &body, &sc.DateSelector,
+			&sc.DateAttribute, &sc.DateLayout, &sc.AuthorSelector, &maxArticles, &scraperUpdated,
+			&fs.ETag, &fs.LastModified, &lastFetched, &fs.LastSuccessAt, &fs.LastStatusCode,
+			&fs.LastError, &failures, &fs.NextFetchAt, &fs.DeactivatedAt); err != nil {
 			return nil, fmt.Errorf("scan source: %w", err)
 		}
+		if listURL != nil {
+			sc.SourceID, sc.ListURL, sc.LinkSelector, sc.TitleSelector, sc.BodySelector = s.ID, *listURL, *link, *title, *body
+			sc.MaxArticles, sc.UpdatedAt = *maxArticles, *scraperUpdated
+			s.Scraper = &sc
+		}
+		if lastFetched != nil {
+			fs.LastFetchedAt, fs.ConsecutiveFailures = *lastFetched, *failures
+			s.FetchState = &fs
+		}
 		sources = append(sources, s)
 	}
-	return sources, nil
+	return sources, rows.Err()
 }
 
-func (r *NewsRepo) GetActiveSources(ctx context.Context) ([]models.NewsSource, error) {
-	query := `
-		SELECT id, name, url, feed_url, type, outlet, active, created_at, updated_at
-		FROM news_sources WHERE active = true ORDER BY name`
+// UpdateSource changes a source's aggregator settings. Switching a source
+// on clears its failures, so it is fetched on the next cycle.
+func (r *NewsRepo) UpdateSource(ctx context.Context, id uuid.UUID, u models.NewsSourceUpdate) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx,
+			`UPDATE news_sources
+			 SET active = COALESCE($2, active), extract_full_text = COALESCE($3, extract_full_text)
+			 WHERE id = $1`,
+			id, u.Active, u.ExtractFullText)
+		if err != nil {
+			return fmt.Errorf("update news source: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrNotFound
+		}
+		if u.Active != nil && *u.Active {
+			_, err = tx.Exec(ctx,
+				`UPDATE news_source_fetch_state
+				 SET consecutive_failures = 0, next_fetch_at = NULL, deactivated_at = NULL
+				 WHERE source_id = $1`, id)
+			if err != nil {
+				return fmt.Errorf("update news source: %w", err)
+			}
+		}
+		return nil
+	})
+}
 
-	rows, err := r.pool.Query(ctx, query)
+// SaveFetchState stores what the aggregator found on its last fetch of a
+// source.
+func (r *NewsRepo) SaveFetchState(ctx context.Context, sourceID uuid.UUID, fs models.FetchState) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO news_source_fetch_state (source_id, etag, last_modified, last_fetched_at, last_success_at,
+		                                      last_status_code, last_error, consecutive_failures, next_fetch_at, deactivated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 ON CONFLICT (source_id) DO UPDATE
+		 SET etag = EXCLUDED.etag, last_modified = EXCLUDED.last_modified, last_fetched_at = EXCLUDED.last_fetched_at,
+		     last_success_at = EXCLUDED.last_success_at, last_status_code = EXCLUDED.last_status_code,
+		     last_error = EXCLUDED.last_error, consecutive_failures = EXCLUDED.consecutive_failures,
+		     next_fetch_at = EXCLUDED.next_fetch_at, deactivated_at = EXCLUDED.deactivated_at`,
+		sourceID, fs.ETag, fs.LastModified, fs.LastFetchedAt, fs.LastSuccessAt,
+		fs.LastStatusCode, fs.LastError, fs.ConsecutiveFailures, fs.NextFetchAt, fs.DeactivatedAt)
+	if err != nil {
+		return fmt.Errorf("save fetch state: %w", err)
+	}
+	return nil
+}
+
+// DeactivateSource switches off a source the aggregator has given up on.
+// Unlike UpdateSource it keeps the source's failures.
+func (r *NewsRepo) DeactivateSource(ctx context.Context, id uuid.UUID) error {
+	_, err := r.pool.Exec(ctx, `UPDATE news_sources SET active = false WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("deactivate news source: %w", err)
+	}
+	return nil
+}
+
+func (r *NewsRepo) LogFetch(ctx context.Context, a *models.FetchAttempt) error {
+	err := r.pool.QueryRow(ctx,
+		`INSERT INTO news_fetch_log (source_id, started_at, duration_ms, status_code, items_seen, new_items, item_errors, error)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		 RETURNING id`,
+		a.SourceID, a.StartedAt, a.DurationMS, a.StatusCode, a.ItemsSeen, a.NewItems, a.ItemErrors, a.Error,
+	).Scan(&a.ID)
+	if err != nil {
+		return fmt.Errorf("log fetch: %w", err)
+	}
+	return nil
+}
+
+// ListFetches returns a source's most recent fetch attempts, newest first.
+func (r *NewsRepo) ListFetches(ctx context.Context, sourceID uuid.UUID, limit int) ([]models.FetchAttempt, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, source_id, started_at, duration_ms, status_code, items_seen, new_items, item_errors, error
+		 FROM news_fetch_log
+		 WHERE source_id = $1
+		 ORDER BY started_at DESC
+		 LIMIT $2`, sourceID, limit)
 	if err != nil {
-		return nil, fmt.Errorf("get active sources: %w", err)
+		return nil, fmt.Errorf("list fetches: %w", err)
 	}
 	defer rows.Close()
 
-	var sources []models.NewsSource
+	var attempts []models.FetchAttempt
 	for rows.Next() {
-		var s models.NewsSource
-		if err := rows.Scan(&s.ID, &s.Name, &s.URL, &s.FeedURL, &s.Type, &s.Outlet, &s.Active, &s.CreatedAt, &s.UpdatedAt); err != nil {
-			return nil, fmt.Errorf("scan source: %w", err)
+		var a models.FetchAttempt
+		if err := rows.Scan(&a.ID, &a.SourceID, &a.StartedAt, &a.DurationMS, &a.StatusCode,
+			&a.ItemsSeen, &a.NewItems, &a.ItemErrors, &a.Error); err != nil {
+			return nil, fmt.Errorf("scan fetch: %w", err)
 		}
-		sources = append(sources, s)
+		attempts = append(attempts, a)
 	}
-	return sources, nil
+	return attempts, rows.Err()
+}
+
+// PruneFetchLog deletes fetch attempts started before the cutoff.
+func (r *NewsRepo) PruneFetchLog(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM news_fetch_log WHERE started_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("prune fetch log: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// SourceStatuses returns every source's fetch state with its articles and
+// fetch attempts since the given time.
+func (r *NewsRepo) SourceStatuses(ctx context.Context, since time.Time) ([]models.NewsSourceStatus, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT ns.id, ns.name, ns.outlet, ns.type, ns.active,
+		       fs.last_fetched_at, fs.last_success_at, fs.last_status_code, fs.last_error,
+		       COALESCE(fs.consecutive_failures, 0), fs.next_fetch_at, fs.deactivated_at,
+		       a.last_article_at, a.recent, l.attempts, l.failures, l.avg_ms
+		FROM news_sources ns
+		LEFT JOIN news_source_fetch_state fs ON fs.source_id = ns.id
+		CROSS JOIN LATERAL (
+			SELECT MAX(na.scraped_at) AS last_article_at,
+			       COUNT(*) FILTER (WHERE na.scraped_at >= $1) AS recent
+			FROM news_articles na
+			WHERE na.source_id = ns.id
+		) a
+		CROSS JOIN LATERAL (
+			SELECT COUNT(*) AS attempts, COUNT(*) FILTER (WHERE fl.error IS NOT NULL) AS failures,
+			       ROUND(AVG(fl.duration_ms))::int AS avg_ms
+			FROM news_fetch_log fl
+			WHERE fl.source_id = ns.id AND fl.started_at >= $1
+		) l
+		ORDER BY ns.name`, since)
+	if err != nil {
+		return nil, fmt.Errorf("get source statuses: %w", err)
+	}
+	defer rows.Close()
+
+	var statuses []models.NewsSourceStatus
+	for rows.Next() {
+		var s models.NewsSourceStatus
+		if err := rows.Scan(&s.ID, &s.Name, &s.Outlet, &s.Type, &s.Active,
+			&s.LastFetchedAt, &s.LastSuccessAt, &s.LastStatusCode, &s.LastError,
+			&s.ConsecutiveFailures, &s.NextFetchAt, &s.DeactivatedAt,
+			&s.LastArticleAt, &s.RecentArticles, &s.Attempts, &s.Failures, &s.AvgDurationMS); err != nil {
+			return nil, fmt.Errorf("scan source status: %w", err)
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, rows.Err()
+}
+
+// SetScraperConfig saves a source's HTML scraper configuration and
+// switches the source to the scraper type.
+func (r *NewsRepo) SetScraperConfig(ctx context.Context, c *models.ScraperConfig) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `UPDATE news_sources SET type = 'scraper' WHERE id = $1`, c.SourceID)
+		if err != nil {
+			return fmt.Errorf("set scraper config: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrNotFound
+		}
+		err = tx.QueryRow(ctx,
+			`INSERT INTO news_source_scrapers (source_id, list_url, link_selector, title_selector, body_selector,
+			                                   date_selector, date_attribute, date_layout, author_selector, max_articles)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			 ON CONFLICT (source_id) DO UPDATE SET
+			     list_url = EXCLUDED.list_url, link_selector = EXCLUDED.link_selector,
+			     title_selector = EXCLUDED.title_selector, body_selector = EXCLUDED.body_selector,
+			     date_selector = EXCLUDED.date_selector, date_attribute = EXCLUDED.date_attribute,
+			     date_layout = EXCLUDED.date_layout, author_selector = EXCLUDED.author_selector,
+			     max_articles = EXCLUDED.max_articles
+			 RETURNING updated_at`,
+			c.SourceID, c.ListURL, c.LinkSelector, c.TitleSelector, c.BodySelector,
+			c.DateSelector, c.DateAttribute, c.DateLayout, c.AuthorSelector, c.MaxArticles,
+		).Scan(&c.UpdatedAt)
+		if err != nil {
+			return mapWriteError("set scraper config", err)
+		}
+		return nil
+	})
 }
 
 func (r *NewsRepo) InsertArticle(ctx context.Context, a *models.NewsArticle) error {
 	_, err := r.pool.Exec(ctx,
-		`INSERT INTO news_articles (source_id, title, content, summary, url, author, image_url, published_at, scraped_at, category, is_election_related)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), $9, $10)
+		`INSERT INTO news_articles (source_id, title, content, summary, url, canonical_url, author, image_url, published_at, scraped_at, category, is_election_related)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), $10, $11)
 		 ON CONFLICT (url) DO UPDATE SET image_url = COALESCE(EXCLUDED.image_url, news_articles.image_url)`,
-		a.SourceID, a.Title, a.Content, a.Summary, a.URL, a.Author, a.ImageURL, a.PublishedAt, a.Category, a.IsElectionRelated,
+		a.SourceID, a.Title, a.Content, a.Summary, a.URL, a.CanonicalURL, a.Author, a.ImageURL, a.PublishedAt, a.Category, a.IsElectionRelated,
 	)
 	if err != nil {
 		return fmt.Errorf("insert article: %w", err)
@@ -176,15 +384,33 @@ func (r *NewsRepo) InsertArticle(ctx context.Context, a *models.NewsArticle) err
 	return nil
 }
 
+// ArticleExistsByURL reports whether an article is stored under url, has
+// it as its canonical URL, or was reached through it as an alias.
 func (r *NewsRepo) ArticleExistsByURL(ctx context.Context, url string) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM news_articles WHERE url = $1)`, url).Scan(&exists)
+	err := r.pool.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM news_articles WHERE url = $1 OR canonical_url = $1)
+		     OR EXISTS(SELECT 1 FROM news_article_aliases WHERE url = $1)`, url).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("check article exists: %w", err)
 	}
 	return exists, nil
 }
 
+// InsertArticleAlias records alias as another link to the article stored
+// under articleURL, either as its URL or its canonical URL.
+func (r *NewsRepo) InsertArticleAlias(ctx context.Context, alias, articleURL string) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO news_article_aliases (url, article_id)
+		 SELECT $1, id FROM news_articles WHERE url = $2 OR canonical_url = $2
+		 ORDER BY scraped_at LIMIT 1
+		 ON CONFLICT (url) DO NOTHING`, alias, articleURL)
+	if err != nil {
+		return fmt.Errorf("insert article alias: %w", err)
+	}
+	return nil
+}
+
 func (r *NewsRepo) InsertMention(ctx context.Context, articleURL string, politicianID uuid.UUID) error {
 	_, err := r.pool.Exec(ctx,
 		`INSERT INTO article_politician_mentions (article_id, politician_id)
@@ -19,33 +19,98 @@ func NewPoliticianRepo(pool *pgxpool.Pool) *PoliticianRepo {
 	return &PoliticianRepo{pool: pool}
 }
 
+// PoliticianSorts maps the sort parameter to an ORDER BY clause.
+var PoliticianSorts = map[string]string{
+	"name":     "p.last_name, p.first_name",
+	"-name":    "p.last_name DESC, p.first_name DESC",
+	"age":      "p.date_of_birth DESC NULLS LAST, p.last_name",
+	"-age":     "p.date_of_birth ASC NULLS LAST, p.last_name",
+	"recent":   "p.created_at DESC, p.last_name",
+	"mentions": "(SELECT COUNT(*) FROM article_politician_mentions m WHERE m.politician_id = p.id) DESC, p.last_name",
+}
+
+// officeCTE resolves every sitting office holder once per query, with a
+// display label such as "governor, Nairobi".
+const officeCTE = `
+	WITH office AS MATERIALIZED (
+		SELECT o.politician_id, o.title, o.county_id, o.constituency_id, o.election_date,
+		       co.code AS county_code, cn.code AS constituency_code,
+		       o.title || COALESCE(', ' || CASE o.level
+		           WHEN 'ward' THEN w.name
+		           WHEN 'constituency' THEN cn.name
+		           WHEN 'county' THEN co.name
+		       END, '') AS label
+		FROM current_office_holders o
+		LEFT JOIN counties co ON co.id = o.county_id
+		LEFT JOIN constituencies cn ON cn.id = o.constituency_id
+		LEFT JOIN wards w ON w.id = o.ward_id
+	)`
+
 func (r *PoliticianRepo) List(ctx context.Context, f models.PoliticianFilter) ([]models.PoliticianSummary, int, error) {
 	if f.Limit <= 0 {
 		f.Limit = 20
 	}
+	orderBy, ok := PoliticianSorts[f.Sort]
+	if !ok {
+		orderBy = PoliticianSorts["name"]
+	}
 
-	countQuery := `SELECT COUNT(*) FROM politicians p WHERE 1=1`
-	dataQuery := `
+	countQuery := officeCTE + ` SELECT COUNT(*) FROM politicians p WHERE 1=1`
+	dataQuery := officeCTE + `
 		SELECT p.id, p.slug, p.first_name, p.last_name, p.status, p.photo_url,
 		       (SELECT pp.name FROM party_memberships pm
 		        JOIN political_parties pp ON pp.id = pm.party_id
 		        WHERE pm.politician_id = p.id AND pm.left_date IS NULL
-		        ORDER BY pm.joined_date DESC LIMIT 1) as current_party
+		        ORDER BY pm.joined_date DESC LIMIT 1) as current_party,
+		       (SELECT o.label FROM office o WHERE o.politician_id = p.id
+		        ORDER BY o.election_date DESC NULLS LAST LIMIT 1) as current_office
 		FROM politicians p WHERE 1=1`
 
 	var args []interface{}
 	argIdx := 1
 	where := ""
+	// add appends a condition whose single placeholder is written as %d.
+	add := func(cond string, arg interface{}) {
+		where += " AND " + fmt.Sprintf(cond, argIdx)
+		args = append(args, arg)
+		argIdx++
+	}
 
 	if f.Query != "" {
-		where += fmt.Sprintf(` AND (p.first_name || ' ' || p.last_name || ' ' || COALESCE(p.other_names, '')) ILIKE $%d`, argIdx)
-		args = append(args, "%"+f.Query+"%")
-		argIdx++
+		add(`(p.first_name || ' ' || p.last_name || ' ' || COALESCE(p.other_names, '')) ILIKE $%d`, "%"+f.Query+"%")
 	}
-	if f.PartyID != nil {
-		where += fmt.Sprintf(` AND EXISTS (SELECT 1 FROM party_memberships pm WHERE pm.politician_id = p.id AND pm.party_id = $%d AND pm.left_date IS NULL)`, argIdx)
-		args = append(args, *f.PartyID)
-		argIdx++
+	if len(f.PartyIDs) > 0 {
+		add(`EXISTS (SELECT 1 FROM party_memberships pm WHERE pm.politician_id = p.id AND pm.party_id = ANY($%d) AND pm.left_date IS NULL)`, f.PartyIDs)
+	}
+	if len(f.Coalitions) > 0 {
+		add(`EXISTS (SELECT 1 FROM party_memberships pm
+		             JOIN coalition_members cm ON cm.party_id = pm.party_id AND cm.left_at IS NULL
+		             JOIN coalitions c ON c.id = cm.coalition_id
+		             WHERE pm.politician_id = p.id AND pm.left_date IS NULL AND c.slug = ANY($%d))`, f.Coalitions)
+	}
+	if len(f.Positions) > 0 {
+		add(`EXISTS (SELECT 1 FROM office o WHERE o.politician_id = p.id AND o.title = ANY($%d))`, f.Positions)
+	}
+	if len(f.CountyIDs) > 0 {
+		add(`EXISTS (SELECT 1 FROM office o WHERE o.politician_id = p.id AND o.county_id = ANY($%d))`, f.CountyIDs)
+	}
+	if len(f.CountyCodes) > 0 {
+		add(`EXISTS (SELECT 1 FROM office o WHERE o.politician_id = p.id AND o.county_code = ANY($%d))`, f.CountyCodes)
+	}
+	if len(f.Constituencies) > 0 {
+		add(`EXISTS (SELECT 1 FROM office o WHERE o.politician_id = p.id AND o.constituency_code = ANY($%d))`, f.Constituencies)
+	}
+	if len(f.Genders) > 0 {
+		add(`p.gender = ANY($%d)`, f.Genders)
+	}
+	if len(f.Statuses) > 0 {
+		add(`p.status = ANY($%d)`, f.Statuses)
+	}
+	if f.MinAge != nil {
+		add(`p.date_of_birth <= CURRENT_DATE - make_interval(years => $%d)`, *f.MinAge)
+	}
+	if f.MaxAge != nil {
+		add(`p.date_of_birth > CURRENT_DATE - make_interval(years => $%d + 1)`, *f.MaxAge)
 	}
 
 	var total int
@@ -54,7 +119,7 @@ func (r *PoliticianRepo) List(ctx context.Context, f models.PoliticianFilter) ([
 		return nil, 0, fmt.Errorf("count politicians: %w", err)
 	}
 
-	dataQuery += where + fmt.Sprintf(` ORDER BY p.last_name, p.first_name LIMIT $%d OFFSET $%d`, argIdx, argIdx+1)
+	dataQuery += where + fmt.Sprintf(` ORDER BY %s LIMIT $%d OFFSET $%d`, orderBy, argIdx, argIdx+1)
 	args = append(args, f.Limit, f.Offset)
 
 	rows, err := r.pool.Query(ctx, dataQuery, args...)
@@ -66,7 +131,7 @@ func (r *PoliticianRepo) List(ctx context.Context, f models.PoliticianFilter) ([
 	var politicians []models.PoliticianSummary
 	for rows.Next() {
 		var p models.PoliticianSummary
-		if err := rows.Scan(&p.ID, &p.Slug, &p.FirstName, &p.LastName, &p.Status, &p.PhotoURL, &p.Party); err != nil {
+		if err := rows.Scan(&p.ID, &p.Slug, &p.FirstName, &p.LastName, &p.Status, &p.PhotoURL, &p.Party, &p.Office); err != nil {
 			return nil, 0, fmt.Errorf("scan politician: %w", err)
 		}
 		politicians = append(politicians, p)
@@ -147,12 +212,13 @@ func (r *PoliticianRepo) GetCandidacies(ctx context.Context, politicianID uuid.U
 		SELECT c.id, c.politician_id, c.election_id, c.position_id, c.party_id,
 		       c.status, c.declaration_date, c.clearance_date, c.created_at, c.updated_at,
 		       p.first_name || ' ' || p.last_name, p.slug,
-		       pp.name, ep.title, e.name
+		       pp.name, ep.title, e.name,
+		       ` + runningMateColumns + `
 		FROM candidacies c
 		JOIN politicians p ON p.id = c.politician_id
 		JOIN elective_positions ep ON ep.id = c.position_id
 		JOIN elections e ON e.id = c.election_id
-		LEFT JOIN political_parties pp ON pp.id = c.party_id
+		LEFT JOIN political_parties pp ON pp.id = c.party_id` + runningMateJoins + `
 		WHERE c.politician_id = $1
 		ORDER BY e.election_date DESC NULLS LAST`
 
@@ -165,14 +231,16 @@ func (r *PoliticianRepo) GetCandidacies(ctx context.Context, politicianID uuid.U
 	var candidacies []models.CandidacyDetail
 	for rows.Next() {
 		var cd models.CandidacyDetail
-		if err := rows.Scan(
+		var mate runningMateRow
+		if err := rows.Scan(append([]interface{}{
 			&cd.ID, &cd.PoliticianID, &cd.ElectionID, &cd.PositionID, &cd.PartyID,
 			&cd.Status, &cd.DeclarationDate, &cd.ClearanceDate, &cd.CreatedAt, &cd.UpdatedAt,
 			&cd.PoliticianName, &cd.PoliticianSlug,
 			&cd.PartyName, &cd.PositionTitle, &cd.ElectionName,
-		); err != nil {
+		}, mate.dest()...)...); err != nil {
 			return nil, fmt.Errorf("scan candidacy: %w", err)
 		}
+		cd.RunningMate = mate.mate()
 		candidacies = append(candidacies, cd)
 	}
 	return candidacies, nil
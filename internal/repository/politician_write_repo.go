@@ -0,0 +1,485 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"jalada/internal/models"
+)
+
+func (r *PoliticianRepo) CreatePolitician(ctx context.Context, p *models.Politician) error {
+	err := r.audited(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx,
+			`INSERT INTO politicians (slug, first_name, last_name, other_names, date_of_birth, date_of_death,
+			                          gender, status, bio, photo_url, education, career_history)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			 RETURNING id, created_at, updated_at`,
+			p.Slug, p.FirstName, p.LastName, p.OtherNames, p.DateOfBirth, p.DateOfDeath,
+			p.Gender, p.Status, p.Bio, p.PhotoURL, p.Education, p.CareerHistory,
+		).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+	})
+	if err != nil {
+		return mapWriteError("create politician", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) UpdatePolitician(ctx context.Context, p *models.Politician) error {
+	err := r.audited(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx,
+			`UPDATE politicians
+			 SET slug = $2, first_name = $3, last_name = $4, other_names = $5, date_of_birth = $6,
+			     date_of_death = $7, gender = $8, status = $9, bio = $10, photo_url = $11,
+			     education = $12, career_history = $13
+			 WHERE id = $1
+			 RETURNING created_at, updated_at`,
+			p.ID, p.Slug, p.FirstName, p.LastName, p.OtherNames, p.DateOfBirth,
+			p.DateOfDeath, p.Gender, p.Status, p.Bio, p.PhotoURL,
+			p.Education, p.CareerHistory,
+		).Scan(&p.CreatedAt, &p.UpdatedAt)
+	})
+	if err == pgx.ErrNoRows {
+		return ErrNotFound
+	}
+	if err != nil {
+		return mapWriteError("update politician", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) DeletePolitician(ctx context.Context, id uuid.UUID) error {
+	return r.deleteRow(ctx, "delete politician", `DELETE FROM politicians WHERE id = $1`, id)
+}
+
+func (r *PoliticianRepo) GetPartyMembership(ctx context.Context, politicianID, id uuid.UUID) (*models.PartyMembership, error) {
+	query := `
+		SELECT pm.id, pm.politician_id, pm.party_id, pp.name, pm.joined_date, pm.left_date, pm.role, pm.created_at
+		FROM party_memberships pm
+		JOIN political_parties pp ON pp.id = pm.party_id
+		WHERE pm.id = $1 AND pm.politician_id = $2`
+
+	var m models.PartyMembership
+	err := r.pool.QueryRow(ctx, query, id, politicianID).Scan(
+		&m.ID, &m.PoliticianID, &m.PartyID, &m.PartyName, &m.JoinedDate, &m.LeftDate, &m.Role, &m.CreatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get party membership: %w", err)
+	}
+	return &m, nil
+}
+
+func (r *PoliticianRepo) CreatePartyMembership(ctx context.Context, m *models.PartyMembership) error {
+	err := r.audited(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx,
+			`INSERT INTO party_memberships (politician_id, party_id, joined_date, left_date, role)
+			 VALUES ($1, $2, $3, $4, $5)
+			 RETURNING id, created_at, (SELECT name FROM political_parties WHERE id = party_id)`,
+			m.PoliticianID, m.PartyID, m.JoinedDate, m.LeftDate, m.Role,
+		).Scan(&m.ID, &m.CreatedAt, &m.PartyName)
+	})
+	if err != nil {
+		return mapWriteError("create party membership", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) UpdatePartyMembership(ctx context.Context, m *models.PartyMembership) error {
+	err := r.audited(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx,
+			`UPDATE party_memberships
+			 SET party_id = $3, joined_date = $4, left_date = $5, role = $6
+			 WHERE id = $1 AND politician_id = $2
+			 RETURNING created_at, (SELECT name FROM political_parties WHERE id = party_id)`,
+			m.ID, m.PoliticianID, m.PartyID, m.JoinedDate, m.LeftDate, m.Role,
+		).Scan(&m.CreatedAt, &m.PartyName)
+	})
+	if err == pgx.ErrNoRows {
+		return ErrNotFound
+	}
+	if err != nil {
+		return mapWriteError("update party membership", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) DeletePartyMembership(ctx context.Context, politicianID, id uuid.UUID) error {
+	return r.deleteRow(ctx, "delete party membership",
+		`DELETE FROM party_memberships WHERE id = $1 AND politician_id = $2`, id, politicianID)
+}
+
+func (r *PoliticianRepo) GetCourtCase(ctx context.Context, politicianID, id uuid.UUID) (*models.CourtCase, error) {
+	query := `
+		SELECT id, politician_id, case_number, court_name, case_type, title, description,
+		       filing_date, status, outcome, source_url, source_id, created_at, updated_at
+		FROM court_cases
+		WHERE id = $1 AND politician_id = $2`
+
+	var c models.CourtCase
+	err := r.pool.QueryRow(ctx, query, id, politicianID).Scan(
+		&c.ID, &c.PoliticianID, &c.CaseNumber, &c.CourtName, &c.CaseType, &c.Title, &c.Description,
+		&c.FilingDate, &c.Status, &c.Outcome, &c.SourceURL, &c.SourceID, &c.CreatedAt, &c.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get court case: %w", err)
+	}
+	return &c, nil
+}
+
+func (r *PoliticianRepo) CreateCourtCase(ctx context.Context, c *models.CourtCase) error {
+	err := r.audited(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx,
+			`INSERT INTO court_cases (politician_id, case_number, court_name, case_type, title, description,
+			                          filing_date, status, outcome, source_url, source_id)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			 RETURNING id, created_at, updated_at`,
+			c.PoliticianID, c.CaseNumber, c.CourtName, c.CaseType, c.Title, c.Description,
+			c.FilingDate, c.Status, c.Outcome, c.SourceURL, c.SourceID,
+		).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+	})
+	if err != nil {
+		return mapWriteError("create court case", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) UpdateCourtCase(ctx context.Context, c *models.CourtCase) error {
+	err := r.audited(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx,
+			`UPDATE court_cases
+			 SET case_number = $3, court_name = $4, case_type = $5, title = $6, description = $7,
+			     filing_date = $8, status = $9, outcome = $10, source_url = $11, source_id = $12
+			 WHERE id = $1 AND politician_id = $2
+			 RETURNING created_at, updated_at`,
+			c.ID, c.PoliticianID, c.CaseNumber, c.CourtName, c.CaseType, c.Title, c.Description,
+			c.FilingDate, c.Status, c.Outcome, c.SourceURL, c.SourceID,
+		).Scan(&c.CreatedAt, &c.UpdatedAt)
+	})
+	if err == pgx.ErrNoRows {
+		return ErrNotFound
+	}
+	if err != nil {
+		return mapWriteError("update court case", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) DeleteCourtCase(ctx context.Context, politicianID, id uuid.UUID) error {
+	return r.deleteRow(ctx, "delete court case",
+		`DELETE FROM court_cases WHERE id = $1 AND politician_id = $2`, id, politicianID)
+}
+
+func (r *PoliticianRepo) GetPromise(ctx context.Context, politicianID, id uuid.UUID) (*models.Promise, error) {
+	query := `
+		SELECT id, politician_id, description, sector, made_date, deadline, status,
+		       evidence, source_url, source_id, created_at, updated_at
+		FROM promises
+		WHERE id = $1 AND politician_id = $2`
+
+	var p models.Promise
+	err := r.pool.QueryRow(ctx, query, id, politicianID).Scan(
+		&p.ID, &p.PoliticianID, &p.Description, &p.Sector, &p.MadeDate, &p.Deadline,
+		&p.Status, &p.Evidence, &p.SourceURL, &p.SourceID, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get promise: %w", err)
+	}
+	return &p, nil
+}
+
+func (r *PoliticianRepo) CreatePromise(ctx context.Context, p *models.Promise) error {
+	err := r.audited(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx,
+			`INSERT INTO promises (politician_id, description, sector, made_date, deadline, status,
+			                       evidence, source_url, source_id)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			 RETURNING id, created_at, updated_at`,
+			p.PoliticianID, p.Description, p.Sector, p.MadeDate, p.Deadline, p.Status,
+			p.Evidence, p.SourceURL, p.SourceID,
+		).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+	})
+	if err != nil {
+		return mapWriteError("create promise", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) UpdatePromise(ctx context.Context, p *models.Promise) error {
+	err := r.audited(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx,
+			`UPDATE promises
+			 SET description = $3, sector = $4, made_date = $5, deadline = $6, status = $7,
+			     evidence = $8, source_url = $9, source_id = $10
+			 WHERE id = $1 AND politician_id = $2
+			 RETURNING created_at, updated_at`,
+			p.ID, p.PoliticianID, p.Description, p.Sector, p.MadeDate, p.Deadline, p.Status,
+			p.Evidence, p.SourceURL, p.SourceID,
+		).Scan(&p.CreatedAt, &p.UpdatedAt)
+	})
+	if err == pgx.ErrNoRows {
+		return ErrNotFound
+	}
+	if err != nil {
+		return mapWriteError("update promise", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) DeletePromise(ctx context.Context, politicianID, id uuid.UUID) error {
+	return r.deleteRow(ctx, "delete promise",
+		`DELETE FROM promises WHERE id = $1 AND politician_id = $2`, id, politicianID)
+}
+
+func (r *PoliticianRepo) GetAchievement(ctx context.Context, politicianID, id uuid.UUID) (*models.Achievement, error) {
+	query := `
+		SELECT id, politician_id, title, description, category, date, source_url, source_id, created_at
+		FROM achievements WHERE id = $1 AND politician_id = $2`
+
+	var a models.Achievement
+	err := r.pool.QueryRow(ctx, query, id, politicianID).Scan(
+		&a.ID, &a.PoliticianID, &a.Title, &a.Description, &a.Category, &a.Date, &a.SourceURL, &a.SourceID, &a.CreatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get achievement: %w", err)
+	}
+	return &a, nil
+}
+
+func (r *PoliticianRepo) CreateAchievement(ctx context.Context, a *models.Achievement) error {
+	err := r.audited(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx,
+			`INSERT INTO achievements (politician_id, title, description, category, date, source_url, source_id)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)
+			 RETURNING id, created_at`,
+			a.PoliticianID, a.Title, a.Description, a.Category, a.Date, a.SourceURL, a.SourceID,
+		).Scan(&a.ID, &a.CreatedAt)
+	})
+	if err != nil {
+		return mapWriteError("create achievement", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) UpdateAchievement(ctx context.Context, a *models.Achievement) error {
+	err := r.audited(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx,
+			`UPDATE achievements
+			 SET title = $3, description = $4, category = $5, date = $6, source_url = $7, source_id = $8
+			 WHERE id = $1 AND politician_id = $2
+			 RETURNING created_at`,
+			a.ID, a.PoliticianID, a.Title, a.Description, a.Category, a.Date, a.SourceURL, a.SourceID,
+		).Scan(&a.CreatedAt)
+	})
+	if err == pgx.ErrNoRows {
+		return ErrNotFound
+	}
+	if err != nil {
+		return mapWriteError("update achievement", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) DeleteAchievement(ctx context.Context, politicianID, id uuid.UUID) error {
+	return r.deleteRow(ctx, "delete achievement",
+		`DELETE FROM achievements WHERE id = $1 AND politician_id = $2`, id, politicianID)
+}
+
+func (r *PoliticianRepo) GetControversy(ctx context.Context, politicianID, id uuid.UUID) (*models.Controversy, error) {
+	query := `
+		SELECT id, politician_id, title, description, category, date, severity, source_url, source_id, created_at
+		FROM controversies WHERE id = $1 AND politician_id = $2`
+
+	var c models.Controversy
+	err := r.pool.QueryRow(ctx, query, id, politicianID).Scan(
+		&c.ID, &c.PoliticianID, &c.Title, &c.Description, &c.Category, &c.Date, &c.Severity, &c.SourceURL, &c.SourceID, &c.CreatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get controversy: %w", err)
+	}
+	return &c, nil
+}
+
+func (r *PoliticianRepo) CreateControversy(ctx context.Context, c *models.Controversy) error {
+	err := r.audited(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx,
+			`INSERT INTO controversies (politician_id, title, description, category, date, severity, source_url, source_id)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			 RETURNING id, created_at`,
+			c.PoliticianID, c.Title, c.Description, c.Category, c.Date, c.Severity, c.SourceURL, c.SourceID,
+		).Scan(&c.ID, &c.CreatedAt)
+	})
+	if err != nil {
+		return mapWriteError("create controversy", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) UpdateControversy(ctx context.Context, c *models.Controversy) error {
+	err := r.audited(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx,
+			`UPDATE controversies
+			 SET title = $3, description = $4, category = $5, date = $6, severity = $7, source_url = $8, source_id = $9
+			 WHERE id = $1 AND politician_id = $2
+			 RETURNING created_at`,
+			c.ID, c.PoliticianID, c.Title, c.Description, c.Category, c.Date, c.Severity, c.SourceURL, c.SourceID,
+		).Scan(&c.CreatedAt)
+	})
+	if err == pgx.ErrNoRows {
+		return ErrNotFound
+	}
+	if err != nil {
+		return mapWriteError("update controversy", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) DeleteControversy(ctx context.Context, politicianID, id uuid.UUID) error {
+	return r.deleteRow(ctx, "delete controversy",
+		`DELETE FROM controversies WHERE id = $1 AND politician_id = $2`, id, politicianID)
+}
+
+func (r *PoliticianRepo) GetAssetDeclaration(ctx context.Context, politicianID, id uuid.UUID) (*models.AssetDeclaration, error) {
+	query := `
+		SELECT id, politician_id, declaration_year, total_assets, total_liabilities, details,
+		       source_url, source_id, created_at
+		FROM asset_declarations WHERE id = $1 AND politician_id = $2`
+
+	var d models.AssetDeclaration
+	err := r.pool.QueryRow(ctx, query, id, politicianID).Scan(
+		&d.ID, &d.PoliticianID, &d.DeclarationYear, &d.TotalAssets, &d.TotalLiabilities, &d.Details, &d.SourceURL, &d.SourceID, &d.CreatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get asset declaration: %w", err)
+	}
+	return &d, nil
+}
+
+func (r *PoliticianRepo) CreateAssetDeclaration(ctx context.Context, d *models.AssetDeclaration) error {
+	err := r.audited(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx,
+			`INSERT INTO asset_declarations (politician_id, declaration_year, total_assets, total_liabilities, details, source_url, source_id)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)
+			 RETURNING id, created_at`,
+			d.PoliticianID, d.DeclarationYear, d.TotalAssets, d.TotalLiabilities, d.Details, d.SourceURL, d.SourceID,
+		).Scan(&d.ID, &d.CreatedAt)
+	})
+	if err != nil {
+		return mapWriteError("create asset declaration", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) UpdateAssetDeclaration(ctx context.Context, d *models.AssetDeclaration) error {
+	err := r.audited(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx,
+			`UPDATE asset_declarations
+			 SET declaration_year = $3, total_assets = $4, total_liabilities = $5, details = $6, source_url = $7, source_id = $8
+			 WHERE id = $1 AND politician_id = $2
+			 RETURNING created_at`,
+			d.ID, d.PoliticianID, d.DeclarationYear, d.TotalAssets, d.TotalLiabilities, d.Details, d.SourceURL, d.SourceID,
+		).Scan(&d.CreatedAt)
+	})
+	if err == pgx.ErrNoRows {
+		return ErrNotFound
+	}
+	if err != nil {
+		return mapWriteError("update asset declaration", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) DeleteAssetDeclaration(ctx context.Context, politicianID, id uuid.UUID) error {
+	return r.deleteRow(ctx, "delete asset declaration",
+		`DELETE FROM asset_declarations WHERE id = $1 AND politician_id = $2`, id, politicianID)
+}
+
+func (r *PoliticianRepo) GetIntegrityFlag(ctx context.Context, politicianID, id uuid.UUID) (*models.IntegrityFlag, error) {
+	query := `
+		SELECT id, politician_id, flag_type, description, status, source_url, source_id, flagged_at, created_at, updated_at
+		FROM integrity_flags
+		WHERE id = $1 AND politician_id = $2`
+
+	var f models.IntegrityFlag
+	err := r.pool.QueryRow(ctx, query, id, politicianID).Scan(
+		&f.ID, &f.PoliticianID, &f.FlagType, &f.Description, &f.Status, &f.SourceURL, &f.SourceID, &f.FlaggedAt, &f.CreatedAt, &f.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get integrity flag: %w", err)
+	}
+	return &f, nil
+}
+
+func (r *PoliticianRepo) CreateIntegrityFlag(ctx context.Context, f *models.IntegrityFlag) error {
+	err := r.audited(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx,
+			`INSERT INTO integrity_flags (politician_id, flag_type, description, status, source_url, source_id, flagged_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)
+			 RETURNING id, created_at, updated_at`,
+			f.PoliticianID, f.FlagType, f.Description, f.Status, f.SourceURL, f.SourceID, f.FlaggedAt,
+		).Scan(&f.ID, &f.CreatedAt, &f.UpdatedAt)
+	})
+	if err != nil {
+		return mapWriteError("create integrity flag", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) UpdateIntegrityFlag(ctx context.Context, f *models.IntegrityFlag) error {
+	err := r.audited(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx,
+			`UPDATE integrity_flags
+			 SET flag_type = $3, description = $4, status = $5, source_url = $6, source_id = $7, flagged_at = $8
+			 WHERE id = $1 AND politician_id = $2
+			 RETURNING created_at, updated_at`,
+			f.ID, f.PoliticianID, f.FlagType, f.Description, f.Status, f.SourceURL, f.SourceID, f.FlaggedAt,
+		).Scan(&f.CreatedAt, &f.UpdatedAt)
+	})
+	if err == pgx.ErrNoRows {
+		return ErrNotFound
+	}
+	if err != nil {
+		return mapWriteError("update integrity flag", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepo) DeleteIntegrityFlag(ctx context.Context, politicianID, id uuid.UUID) error {
+	return r.deleteRow(ctx, "delete integrity flag",
+		`DELETE FROM integrity_flags WHERE id = $1 AND politician_id = $2`, id, politicianID)
+}
+
+func (r *PoliticianRepo) deleteRow(ctx context.Context, op, query string, args ...interface{}) error {
+	return r.audited(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return mapWriteError(op, err)
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"jalada/internal/models"
+)
+
+type PollRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPollRepo(pool *pgxpool.Pool) *PollRepo {
+	return &PollRepo{pool: pool}
+}
+
+const pollSelect = `
+		SELECT po.id, po.election_id, po.scope, po.position_id, ep.title, co.id, co.code, co.name,
+		       po.pollster, po.commissioned_by, po.fieldwork_start, po.fieldwork_end, po.published_on,
+		       po.sample_size, po.margin_of_error, po.methodology, po.undecided,
+		       po.source_url, po.notes, po.created_at, po.updated_at
+		FROM polls po
+		LEFT JOIN elective_positions ep ON ep.id = po.position_id` + seatCountyJoins
+
+// List returns an election's polls with their shares, most recent
+// fieldwork first.
+func (r *PollRepo) List(ctx context.Context, electionID uuid.UUID, f models.PollFilter) ([]models.Poll, error) {
+	args := []interface{}{electionID}
+	where := " WHERE po.election_id = $1"
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		where += " AND " + fmt.Sprintf(cond, len(args))
+	}
+
+	if f.Scope != "" {
+		add(`po.scope = $%[1]d`, f.Scope)
+	}
+	if f.Title != "" {
+		add(`ep.title = $%[1]d`, f.Title)
+	}
+	if f.County != "" {
+		add(`co.code = $%[1]d`, f.County)
+	}
+	if f.PositionID != nil {
+		add(`po.position_id = $%[1]d`, *f.PositionID)
+	}
+	if f.Pollster != "" {
+		add(`lower(po.pollster) = lower($%[1]d)`, f.Pollster)
+	}
+	if f.Since != nil {
+		add(`po.fieldwork_end >= $%[1]d`, *f.Since)
+	}
+	if f.Until != nil {
+		add(`po.fieldwork_end <= $%[1]d`, *f.Until)
+	}
+
+	return r.polls(ctx, pollSelect+where+`
+		ORDER BY po.fieldwork_end DESC, po.pollster`, args...)
+}
+
+func (r *PollRepo) Get(ctx context.Context, electionID, id uuid.UUID) (*models.Poll, error) {
+	polls, err := r.polls(ctx, pollSelect+` WHERE po.id = $1 AND po.election_id = $2`, id, electionID)
+	if err != nil || len(polls) == 0 {
+		return nil, err
+	}
+	return &polls[0], nil
+}
+
+// polls runs a pollSelect query and loads the shares of every poll found.
+func (r *PollRepo) polls(ctx context.Context, query string, args ...interface{}) ([]models.Poll, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get polls: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		polls []models.Poll
+		ids   []uuid.UUID
+	)
+	for rows.Next() {
+		var (
+			p                      models.Poll
+			countyID               *uuid.UUID
+			countyCode, countyName *string
+		)
+		if err := rows.Scan(&p.ID, &p.ElectionID, &p.Scope, &p.PositionID, &p.PositionTitle,
+			&countyID, &countyCode, &countyName,
+			&p.Pollster, &p.CommissionedBy, &p.FieldworkStart, &p.FieldworkEnd, &p.PublishedOn,
+			&p.SampleSize, &p.MarginOfError, &p.Methodology, &p.Undecided,
+			&p.SourceURL, &p.Notes, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan poll: %w", err)
+		}
+		p.Area = areaRef(countyID, countyCode, countyName)
+		p.Shares = []models.PollShare{}
+		polls = append(polls, p)
+		ids = append(ids, p.ID)
+	}
+	if err := rows.Err(); err != nil || len(polls) == 0 {
+		return polls, err
+	}
+
+	shares, err := r.shares(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range polls {
+		if s, ok := shares[polls[i].ID]; ok {
+			polls[i].Shares = s
+		}
+	}
+	return polls, nil
+}
+
+// shares returns the shares of each poll, largest first. A politician's
+// candidacy for the polled seat is matched when there is one.
+func (r *PollRepo) shares(ctx context.Context, pollIDs []uuid.UUID) (map[uuid.UUID][]models.PollShare, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT ps.poll_id, ps.politician_id, ps.party_id, c.id,
+		       COALESCE(p.slug, pp.slug), COALESCE(p.first_name || ' ' || p.last_name, pp.name), ps.share
+		FROM poll_shares ps
+		JOIN polls po ON po.id = ps.poll_id
+		LEFT JOIN politicians p ON p.id = ps.politician_id
+		LEFT JOIN political_parties pp ON pp.id = ps.party_id
+		LEFT JOIN candidacies c ON c.election_id = po.election_id AND c.position_id = po.position_id
+		                       AND c.politician_id = ps.politician_id
+		WHERE ps.poll_id = ANY($1)
+		ORDER BY ps.share DESC, 6`, pollIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get poll shares: %w", err)
+	}
+	defer rows.Close()
+
+	shares := make(map[uuid.UUID][]models.PollShare)
+	for rows.Next() {
+		var (
+			pollID uuid.UUID
+			s      models.PollShare
+		)
+		if err := rows.Scan(&pollID, &s.PoliticianID, &s.PartyID, &s.CandidacyID, &s.Slug, &s.Name, &s.Share); err != nil {
+			return nil, fmt.Errorf("scan poll share: %w", err)
+		}
+		shares[pollID] = append(shares[pollID], s)
+	}
+	return shares, rows.Err()
+}
+
+// Create inserts a poll and its shares.
+func (r *PollRepo) Create(ctx context.Context, p *models.Poll) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx,
+			`INSERT INTO polls (election_id, scope, position_id, pollster, commissioned_by,
+			                    fieldwork_start, fieldwork_end, published_on, sample_size,
+			                    margin_of_error, methodology, undecided, source_url, notes)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			 RETURNING id, created_at, updated_at`,
+			p.ElectionID, p.Scope, p.PositionID, p.Pollster, p.CommissionedBy,
+			p.FieldworkStart, p.FieldworkEnd, p.PublishedOn, p.SampleSize,
+			p.MarginOfError, p.Methodology, p.Undecided, p.SourceURL, p.Notes,
+		).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+		if err != nil {
+			return mapWriteError("create poll", err)
+		}
+		return insertShares(ctx, tx, p)
+	})
+}
+
+// Update replaces a poll and all of its shares.
+func (r *PollRepo) Update(ctx context.Context, p *models.Poll) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx,
+			`UPDATE polls
+			 SET scope = $3, position_id = $4, pollster = $5, commissioned_by = $6,
+			     fieldwork_start = $7, fieldwork_end = $8, published_on = $9, sample_size = $10,
+			     margin_of_error = $11, methodology = $12, undecided = $13, source_url = $14, notes = $15
+			 WHERE id = $1 AND election_id = $2
+			 RETURNING created_at, updated_at`,
+			p.ID, p.ElectionID, p.Scope, p.PositionID, p.Pollster, p.CommissionedBy,
+			p.FieldworkStart, p.FieldworkEnd, p.PublishedOn, p.SampleSize,
+			p.MarginOfError, p.Methodology, p.Undecided, p.SourceURL, p.Notes,
+		).Scan(&p.CreatedAt, &p.UpdatedAt)
+		if err == pgx.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return mapWriteError("update poll", err)
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM poll_shares WHERE poll_id = $1`, p.ID); err != nil {
+			return fmt.Errorf("update poll: %w", err)
+		}
+		return insertShares(ctx, tx, p)
+	})
+}
+
+func insertShares(ctx context.Context, tx pgx.Tx, p *models.Poll) error {
+	for _, s := range p.Shares {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO poll_shares (poll_id, politician_id, party_id, share) VALUES ($1, $2, $3, $4)`,
+			p.ID, s.PoliticianID, s.PartyID, s.Share)
+		if err != nil {
+			return mapWriteError("save poll share", err)
+		}
+	}
+	return nil
+}
+
+func (r *PollRepo) Delete(ctx context.Context, electionID, id uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM polls WHERE id = $1 AND election_id = $2`, id, electionID)
+	if err != nil {
+		return fmt.Errorf("delete poll: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
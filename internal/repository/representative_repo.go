@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"jalada/internal/geo"
+	"jalada/internal/models"
+)
+
+// nearestStationRadiusKM bounds the polling station search around a
+// coordinate.
+const nearestStationRadiusKM = 50
+
+const locationColumns = `
+	w.id, w.code, w.name, cn.id, cn.code, cn.name, co.id, co.code, co.name`
+
+const locationJoins = `
+	JOIN constituencies cn ON cn.id = w.constituency_id
+	JOIN counties co ON co.id = cn.county_id`
+
+type RepresentativeRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepresentativeRepo(pool *pgxpool.Pool) *RepresentativeRepo {
+	return &RepresentativeRepo{pool: pool}
+}
+
+// LocateByPollingStation resolves a polling station code to its ward,
+// constituency and county.
+func (r *RepresentativeRepo) LocateByPollingStation(ctx context.Context, code string) (*models.VoterLocation, error) {
+	query := `
+		SELECT ps.id, ps.code, ps.name,` + locationColumns + `
+		FROM polling_stations ps
+		JOIN wards w ON w.id = ps.ward_id` + locationJoins + `
+		WHERE ps.code = $1`
+
+	loc := models.VoterLocation{ResolvedBy: "polling_station", PollingStation: &models.AreaRef{}}
+	err := r.pool.QueryRow(ctx, query, code).Scan(
+		&loc.PollingStation.ID, &loc.PollingStation.Code, &loc.PollingStation.Name,
+		&loc.Ward.ID, &loc.Ward.Code, &loc.Ward.Name,
+		&loc.Constituency.ID, &loc.Constituency.Code, &loc.Constituency.Name,
+		&loc.County.ID, &loc.County.Code, &loc.County.Name,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("locate polling station: %w", err)
+	}
+	return &loc, nil
+}
+
+func (r *RepresentativeRepo) LocateByWard(ctx context.Context, code string) (*models.VoterLocation, error) {
+	query := `
+		SELECT` + locationColumns + `
+		FROM wards w` + locationJoins + `
+		WHERE w.code = $1`
+
+	loc := models.VoterLocation{ResolvedBy: "ward"}
+	err := r.pool.QueryRow(ctx, query, code).Scan(
+		&loc.Ward.ID, &loc.Ward.Code, &loc.Ward.Name,
+		&loc.Constituency.ID, &loc.Constituency.Code, &loc.Constituency.Name,
+		&loc.County.ID, &loc.County.Code, &loc.County.Name,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("locate ward: %w", err)
+	}
+	return &loc, nil
+}
+
+// LocateNearest resolves a coordinate to the closest geolocated polling
+// station within nearestStationRadiusKM.
+func (r *RepresentativeRepo) LocateNearest(ctx context.Context, lat, lng float64) (*models.VoterLocation, error) {
+	query := `
+		SELECT ps.id, ps.code, ps.name,` + locationColumns + `, ` + stationDistanceSQL + ` AS distance_km
+		FROM polling_stations ps
+		JOIN wards w ON w.id = ps.ward_id` + locationJoins + `
+		WHERE ` + stationBoxSQL + `
+		ORDER BY distance_km
+		LIMIT 1`
+	box := geo.BoundsAround(geo.Point{Lat: lat, Lng: lng}, nearestStationRadiusKM)
+
+	loc := models.VoterLocation{ResolvedBy: "nearest_polling_station", PollingStation: &models.AreaRef{}}
+	var distance float64
+	err := r.pool.QueryRow(ctx, query, lat, lng, box.MinLat, box.MaxLat, box.MinLng, box.MaxLng).Scan(
+		&loc.PollingStation.ID, &loc.PollingStation.Code, &loc.PollingStation.Name,
+		&loc.Ward.ID, &loc.Ward.Code, &loc.Ward.Name,
+		&loc.Constituency.ID, &loc.Constituency.Code, &loc.Constituency.Name,
+		&loc.County.ID, &loc.County.Code, &loc.County.Name,
+		&distance,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("locate nearest polling station: %w", err)
+	}
+	loc.DistanceKM = &distance
+	return &loc, nil
+}
+
+// UpcomingElection returns the next general election that has not been
+// completed, or nil if none is scheduled.
+func (r *RepresentativeRepo) UpcomingElection(ctx context.Context) (*models.Election, error) {
+	query := `
+		SELECT id, name, election_date, type, status, created_at, updated_at
+		FROM elections
+		WHERE type = 'general' AND status <> 'completed'
+		ORDER BY election_date NULLS LAST
+		LIMIT 1`
+
+	var e models.Election
+	err := r.pool.QueryRow(ctx, query).Scan(
+		&e.ID, &e.Name, &e.ElectionDate, &e.Type, &e.Status, &e.CreatedAt, &e.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get upcoming election: %w", err)
+	}
+	return &e, nil
+}
+
+// Seats returns every elective position covering loc, local seats first,
+// with the sitting holder of each.
+func (r *RepresentativeRepo) Seats(ctx context.Context, loc *models.VoterLocation) ([]models.Seat, error) {
+	query := `
+		SELECT ep.id, ep.title, ep.level,
+		       CASE ep.level
+		           WHEN 'ward' THEN $4::text
+		           WHEN 'constituency' THEN $5::text
+		           WHEN 'county' THEN $6::text
+		       END,
+		       p.id, p.slug, p.first_name || ' ' || p.last_name, p.photo_url,
+		       COALESCE(pp.abbreviation, pp.name), p.status,
+		       e.name, e.election_date
+		FROM elective_positions ep
+		LEFT JOIN current_office_holders h ON h.position_id = ep.id
+		LEFT JOIN politicians p ON p.id = h.politician_id
+		LEFT JOIN political_parties pp ON pp.id = h.party_id
+		LEFT JOIN elections e ON e.id = h.election_id
+		WHERE ep.level = 'national'
+		   OR (ep.level = 'ward' AND ep.ward_id = $1)
+		   OR (ep.level = 'constituency' AND ep.constituency_id = $2)
+		   OR (ep.level = 'county' AND ep.county_id = $3)
+		ORDER BY CASE ep.title
+		             WHEN 'mca' THEN 1
+		             WHEN 'mp' THEN 2
+		             WHEN 'woman_rep' THEN 3
+		             WHEN 'senator' THEN 4
+		             WHEN 'governor' THEN 5
+		             WHEN 'deputy_governor' THEN 6
+		             WHEN 'president' THEN 7
+		             WHEN 'deputy_president' THEN 8
+		             ELSE 9
+		         END, ep.created_at`
+
+	rows, err := r.pool.Query(ctx, query,
+		loc.Ward.ID, loc.Constituency.ID, loc.County.ID,
+		loc.Ward.Name, loc.Constituency.Name, loc.County.Name,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list seats: %w", err)
+	}
+	defer rows.Close()
+
+	var seats []models.Seat
+	for rows.Next() {
+		var s models.Seat
+		var (
+			holderID           *uuid.UUID
+			slug, name, status *string
+			photo, party       *string
+			electionName       *string
+			holder             models.OfficeHolder
+		)
+		if err := rows.Scan(
+			&s.PositionID, &s.Title, &s.Level, &s.Area,
+			&holderID, &slug, &name, &photo, &party, &status,
+			&electionName, &holder.ElectionDate,
+		); err != nil {
+			return nil, fmt.Errorf("scan seat: %w", err)
+		}
+		if holderID != nil {
+			holder.PoliticianID = *holderID
+			holder.Slug = *slug
+			holder.Name = *name
+			holder.PhotoURL = photo
+			holder.Party = party
+			holder.Status = *status
+			if electionName != nil {
+				holder.ElectionName = *electionName
+			}
+			s.Holder = &holder
+		}
+		seats = append(seats, s)
+	}
+	return seats, nil
+}
+
+// Candidates returns declared and cleared candidates in an election for
+// the given positions, keyed by position.
+func (r *RepresentativeRepo) Candidates(ctx context.Context, electionID uuid.UUID, positionIDs []uuid.UUID) (map[uuid.UUID][]models.SeatPolitician, error) {
+	query := `
+		SELECT c.position_id, p.id, p.slug, p.first_name || ' ' || p.last_name, p.photo_url,
+		       COALESCE(pp.abbreviation, pp.name), p.status
+		FROM candidacies c
+		JOIN politicians p ON p.id = c.politician_id
+		LEFT JOIN political_parties pp ON pp.id = c.party_id
+		WHERE c.election_id = $1
+		  AND c.position_id = ANY($2)
+		  AND c.status IN ('declared', 'cleared')
+		ORDER BY c.position_id, c.status = 'cleared' DESC, p.last_name, p.first_name`
+
+	rows, err := r.pool.Query(ctx, query, electionID, positionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list seat candidates: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := make(map[uuid.UUID][]models.SeatPolitician)
+	for rows.Next() {
+		var positionID uuid.UUID
+		var p models.SeatPolitician
+		if err := rows.Scan(&positionID, &p.PoliticianID, &p.Slug, &p.Name, &p.PhotoURL, &p.Party, &p.Status); err != nil {
+			return nil, fmt.Errorf("scan seat candidate: %w", err)
+		}
+		candidates[positionID] = append(candidates[positionID], p)
+	}
+	return candidates, nil
+}
@@ -0,0 +1,1003 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"jalada/internal/models"
+)
+
+// ResultLevels are the reporting levels a tally can be filed at, smallest
+// first.
+var ResultLevels = []string{"station", "ward", "constituency", "county", "national"}
+
+// stationScopeColumns maps a reporting level to the column that places a
+// station inside an area of that level, in queries joining
+// polling_stations ps, wards w and constituencies cn.
+var stationScopeColumns = map[string]string{
+	"station":      "ps.id",
+	"ward":         "w.id",
+	"constituency": "cn.id",
+	"county":       "cn.county_id",
+}
+
+// tallyAreaColumns maps a reporting level to the result_tallies column
+// holding its area.
+var tallyAreaColumns = map[string]string{
+	"station":      "polling_station_id",
+	"ward":         "ward_id",
+	"constituency": "constituency_id",
+	"county":       "county_id",
+}
+
+// seatAreaSQL is the area of the seat ep as it appears in station_scopes,
+// so (ep.level, seatAreaSQL) picks out the stations whose voters elect it.
+const seatAreaSQL = `COALESCE(ep.ward_id, ep.constituency_id, ep.county_id,
+		'00000000-0000-0000-0000-000000000000'::uuid)`
+
+type ResultsRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewResultsRepo(pool *pgxpool.Pool) *ResultsRepo {
+	return &ResultsRepo{pool: pool}
+}
+
+func (r *ResultsRepo) GetPosition(ctx context.Context, id uuid.UUID) (*models.ElectivePosition, error) {
+	var p models.ElectivePosition
+	err := r.pool.QueryRow(ctx,
+		`SELECT id, title, level, county_id, constituency_id, ward_id, created_at FROM elective_positions WHERE id = $1`, id,
+	).Scan(&p.ID, &p.Title, &p.Level, &p.CountyID, &p.ConstituencyID, &p.WardID, &p.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get position: %w", err)
+	}
+	return &p, nil
+}
+
+// areaChain is a reporting area with every area above it. Levels above the
+// area's own are filled in; levels below are nil.
+type areaChain struct {
+	ref            models.AreaRef
+	stationID      *uuid.UUID
+	wardID         *uuid.UUID
+	constituencyID *uuid.UUID
+	countyID       *uuid.UUID
+}
+
+func (a *areaChain) id(level string) *uuid.UUID {
+	switch level {
+	case "station":
+		return a.stationID
+	case "ward":
+		return a.wardID
+	case "constituency":
+		return a.constituencyID
+	case "county":
+		return a.countyID
+	}
+	return nil
+}
+
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// resolveArea looks up a reporting area by level and code. It returns nil
+// if no area has the code; national resolves to an empty chain.
+func resolveArea(ctx context.Context, q querier, level, code string) (*areaChain, error) {
+	var query string
+	switch level {
+	case "national":
+		return &areaChain{}, nil
+	case "station":
+		query = `
+			SELECT ps.id, ps.code, ps.name, ps.id, w.id, cn.id, cn.county_id
+			FROM polling_stations ps
+			JOIN wards w ON w.id = ps.ward_id
+			JOIN constituencies cn ON cn.id = w.constituency_id
+			WHERE ps.code = $1`
+	case "ward":
+		query = `
+			SELECT w.id, w.code, w.name, NULL::uuid, w.id, cn.id, cn.county_id
+			FROM wards w JOIN constituencies cn ON cn.id = w.constituency_id
+			WHERE w.code = $1`
+	case "constituency":
+		query = `
+			SELECT cn.id, cn.code, cn.name, NULL::uuid, NULL::uuid, cn.id, cn.county_id
+			FROM constituencies cn WHERE cn.code = $1`
+	case "county":
+		query = `
+			SELECT co.id, co.code, co.name, NULL::uuid, NULL::uuid, NULL::uuid, co.id
+			FROM counties co WHERE co.code = $1`
+	default:
+		return nil, fmt.Errorf("resolve area: unknown level %q", level)
+	}
+
+	var a areaChain
+	err := q.QueryRow(ctx, query, code).Scan(
+		&a.ref.ID, &a.ref.Code, &a.ref.Name, &a.stationID, &a.wardID, &a.constituencyID, &a.countyID,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", level, err)
+	}
+	return &a, nil
+}
+
+// seatCovers reports whether voters in area elect the seat, and whether
+// the area is no larger than the seat.
+func seatCovers(p *models.ElectivePosition, level string, a *areaChain) bool {
+	switch p.Level {
+	case "national":
+		return true
+	case "county":
+		return level != "national" && p.CountyID != nil && a.countyID != nil && *p.CountyID == *a.countyID
+	case "constituency":
+		return (level == "station" || level == "ward" || level == "constituency") &&
+			p.ConstituencyID != nil && a.constituencyID != nil && *p.ConstituencyID == *a.constituencyID
+	case "ward":
+		return (level == "station" || level == "ward") && p.WardID != nil && a.wardID != nil && *p.WardID == *a.wardID
+	}
+	return false
+}
+
+// CreateTally records a results form, superseding the current tally for
+// the same seat and area. The area must lie within the seat and every
+// candidacy must be standing for the seat in the election.
+func (r *ResultsRepo) CreateTally(ctx context.Context, p *models.ElectivePosition, t *models.ResultTally) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin create tally: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	code := ""
+	if t.AreaCode != nil {
+		code = *t.AreaCode
+	}
+	area, err := resolveArea(ctx, tx, t.Level, code)
+	if err != nil {
+		return err
+	}
+	if area == nil {
+		return fmt.Errorf("create tally: %w: no %s with code %q", ErrInvalidReference, t.Level, code)
+	}
+	if !seatCovers(p, t.Level, area) {
+		return fmt.Errorf("create tally: %w: %s %q is not part of this %s seat", ErrConstraint, t.Level, code, p.Title)
+	}
+	if t.Level != "national" {
+		t.AreaID = &area.ref.ID
+	}
+
+	ids := make([]uuid.UUID, len(t.Results))
+	for i, v := range t.Results {
+		ids[i] = v.CandidacyID
+	}
+	var standing int
+	err = tx.QueryRow(ctx,
+		`SELECT COUNT(*) FROM candidacies WHERE election_id = $1 AND position_id = $2 AND id = ANY($3)`,
+		t.ElectionID, t.PositionID, ids,
+	).Scan(&standing)
+	if err != nil {
+		return fmt.Errorf("check candidacies: %w", err)
+	}
+	if standing != len(ids) {
+		return fmt.Errorf("create tally: %w: every candidacy must be standing for this seat in this election", ErrInvalidReference)
+	}
+
+	var stationID, wardID, constituencyID, countyID *uuid.UUID
+	switch t.Level {
+	case "station":
+		stationID = t.AreaID
+	case "ward":
+		wardID = t.AreaID
+	case "constituency":
+		constituencyID = t.AreaID
+	case "county":
+		countyID = t.AreaID
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE result_tallies SET is_current = false
+		 WHERE election_id = $1 AND position_id = $2 AND level = $3 AND is_current
+		   AND polling_station_id IS NOT DISTINCT FROM $4 AND ward_id IS NOT DISTINCT FROM $5
+		   AND constituency_id IS NOT DISTINCT FROM $6 AND county_id IS NOT DISTINCT FROM $7`,
+		t.ElectionID, t.PositionID, t.Level, stationID, wardID, constituencyID, countyID,
+	)
+	if err != nil {
+		return fmt.Errorf("supersede tally: %w", err)
+	}
+
+	// seq is drawn when the row is inserted, but stream clients resume from
+	// the last seq they saw in an election, so an election's tallies must
+	// become visible in seq order. Holding this lock from the insert to the
+	// commit makes them do so without serialising other elections.
+	if _, err := tx.Exec(ctx,
+		`SELECT pg_advisory_xact_lock(hashtext('result_tallies.seq'), hashtext($1::text))`, t.ElectionID,
+	); err != nil {
+		return fmt.Errorf("lock tally sequence: %w", err)
+	}
+	err = tx.QueryRow(ctx,
+		`INSERT INTO result_tallies (election_id, position_id, level, form, polling_station_id, ward_id,
+		     constituency_id, county_id, registered_voters, rejected_votes, is_final, reported_by, source_id)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		 RETURNING id, is_current, reported_at`,
+		t.ElectionID, t.PositionID, t.Level, t.Form, stationID, wardID, constituencyID, countyID,
+		t.RegisteredVoters, t.RejectedVotes, t.IsFinal, t.ReportedBy, t.SourceID,
+	).Scan(&t.ID, &t.IsCurrent, &t.ReportedAt)
+	if err != nil {
+		return mapWriteError("create tally", err)
+	}
+
+	batch := &pgx.Batch{}
+	t.ValidVotes = 0
+	for _, v := range t.Results {
+		batch.Queue(
+			`INSERT INTO election_results (candidacy_id, polling_station_id, votes, is_final, level, reported_at, tally_id)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			v.CandidacyID, stationID, v.Votes, t.IsFinal, t.Level, t.ReportedAt, t.ID,
+		)
+		t.ValidVotes += v.Votes
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return mapWriteError("create tally results", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tally: %w", err)
+	}
+	return nil
+}
+
+// GetAreaResults aggregates each seat's result over a reporting area. Seats
+// with current station tallies in the area are summed from them once every
+// expected station is in. Until then, and whenever it is final, the
+// current tally filed for the area itself is used instead if there is one.
+// It returns nil if the area does not exist.
+func (r *ResultsRepo) GetAreaResults(ctx context.Context, electionID uuid.UUID, f models.ResultFilter) (*models.AreaResults, error) {
+	area, err := resolveArea(ctx, r.pool, f.Level, f.Code)
+	if err != nil || area == nil {
+		return nil, err
+	}
+
+	out := &models.AreaResults{ElectionID: electionID, Level: f.Level}
+	var areaID *uuid.UUID
+	if f.Level != "national" {
+		out.Area = &area.ref
+		areaID = &area.ref.ID
+	}
+
+	stations := make(map[uuid.UUID]*models.PositionResult)
+	reported := make(map[uuid.UUID]*models.PositionResult)
+	var order []uuid.UUID
+	collect := func(into map[uuid.UUID]*models.PositionResult, basis string) func(uuid.UUID) *models.PositionResult {
+		return func(id uuid.UUID) *models.PositionResult {
+			p, ok := into[id]
+			if !ok {
+				if stations[id] == nil && reported[id] == nil {
+					order = append(order, id)
+				}
+				p = &models.PositionResult{PositionID: id, Basis: basis}
+				into[id] = p
+			}
+			return p
+		}
+	}
+
+	if err := r.sumStations(ctx, electionID, f, areaID, collect(stations, "stations")); err != nil {
+		return nil, err
+	}
+	if err := r.reportedTallies(ctx, electionID, f, areaID, collect(reported, "reported")); err != nil {
+		return nil, err
+	}
+	if len(order) == 0 {
+		out.Positions = []models.PositionResult{}
+		return out, nil
+	}
+	expected, err := r.expectedStations(ctx, order, f.Level, areaID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make(map[uuid.UUID]*models.PositionResult, len(order))
+	for _, id := range order {
+		s, filed := stations[id], reported[id]
+		p := s
+		if s != nil {
+			s.StationsExpected = expected[id]
+			s.IsFinal = s.IsFinal && s.StationsReported >= s.StationsExpected
+		}
+		if filed != nil && (s == nil || filed.IsFinal || s.StationsReported < s.StationsExpected) {
+			p = filed
+			p.StationsExpected = expected[id]
+			if s != nil {
+				p.StationsReported = s.StationsReported
+			}
+		}
+		positions[id] = p
+	}
+	if err := r.describePositions(ctx, order, positions); err != nil {
+		return nil, err
+	}
+
+	for _, id := range order {
+		p := positions[id]
+		p.TotalVotes = p.ValidVotes + p.RejectedVotes
+		if p.RegisteredVoters > 0 {
+			t := math.Round(float64(p.TotalVotes)/float64(p.RegisteredVoters)*10000) / 100
+			p.Turnout = &t
+		}
+		for i := range p.Candidates {
+			if p.ValidVotes > 0 {
+				p.Candidates[i].Share = math.Round(float64(p.Candidates[i].Votes)/float64(p.ValidVotes)*10000) / 100
+			}
+		}
+		out.Positions = append(out.Positions, *p)
+	}
+	sortPositionResults(out.Positions)
+	return out, nil
+}
+
+// stationScope returns the WHERE fragment limiting station tallies to the
+// area, using the given parameter number.
+func stationScope(level string, param int) string {
+	col, ok := stationScopeColumns[level]
+	if !ok {
+		return "TRUE"
+	}
+	return fmt.Sprintf("%s = $%d", col, param)
+}
+
+func (r *ResultsRepo) sumStations(ctx context.Context, electionID uuid.UUID, f models.ResultFilter, areaID *uuid.UUID, get func(uuid.UUID) *models.PositionResult) error {
+	query := `
+		WITH scoped AS (
+			SELECT t.id, t.position_id, t.registered_voters, t.rejected_votes, t.is_final
+			FROM result_tallies t
+			JOIN elective_positions ep ON ep.id = t.position_id
+			JOIN polling_stations ps ON ps.id = t.polling_station_id
+			JOIN wards w ON w.id = ps.ward_id
+			JOIN constituencies cn ON cn.id = w.constituency_id
+			WHERE t.election_id = $1 AND t.level = 'station' AND t.is_current
+			  AND ($2::uuid IS NULL OR ` + stationScope(f.Level, 2) + `)
+			  AND ($3::uuid IS NULL OR t.position_id = $3)
+			  AND ($4 = '' OR ep.title = $4)
+		), totals AS (
+			SELECT position_id, SUM(registered_voters) AS registered, SUM(rejected_votes) AS rejected,
+			       COUNT(*) AS stations, BOOL_AND(is_final) AS is_final
+			FROM scoped GROUP BY position_id
+		), votes AS (
+			SELECT s.position_id, er.candidacy_id, SUM(er.votes) AS votes
+			FROM scoped s JOIN election_results er ON er.tally_id = s.id
+			GROUP BY s.position_id, er.candidacy_id
+		)
+		SELECT t.position_id, t.registered, t.rejected, t.stations, t.is_final,
+		       v.candidacy_id, COALESCE(v.votes, 0), p.id, p.slug, p.first_name || ' ' || p.last_name,
+		       COALESCE(pp.abbreviation, pp.name)
+		FROM totals t
+		LEFT JOIN votes v ON v.position_id = t.position_id
+		LEFT JOIN candidacies c ON c.id = v.candidacy_id
+		LEFT JOIN politicians p ON p.id = c.politician_id
+		LEFT JOIN political_parties pp ON pp.id = c.party_id
+		ORDER BY t.position_id, v.votes DESC NULLS LAST`
+
+	rows, err := r.pool.Query(ctx, query, electionID, areaID, f.PositionID, f.Title)
+	if err != nil {
+		return fmt.Errorf("sum station results: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			positionID                  uuid.UUID
+			registered, rejected, count int
+			final                       bool
+			candidacyID, politicianID   *uuid.UUID
+			votes                       int
+			slug, name, party           *string
+		)
+		if err := rows.Scan(&positionID, &registered, &rejected, &count, &final,
+			&candidacyID, &votes, &politicianID, &slug, &name, &party); err != nil {
+			return fmt.Errorf("scan station result: %w", err)
+		}
+		p := get(positionID)
+		p.RegisteredVoters, p.RejectedVotes, p.StationsReported, p.IsFinal = registered, rejected, count, final
+		if candidacyID != nil {
+			p.ValidVotes += votes
+			p.Candidates = append(p.Candidates, models.CandidateResult{
+				CandidacyID: *candidacyID, PoliticianID: *politicianID, Slug: *slug, Name: *name, Party: party, Votes: votes,
+			})
+		}
+	}
+	return rows.Err()
+}
+
+func (r *ResultsRepo) reportedTallies(ctx context.Context, electionID uuid.UUID, f models.ResultFilter, areaID *uuid.UUID, get func(uuid.UUID) *models.PositionResult) error {
+	areaFilter := "TRUE"
+	if col, ok := tallyAreaColumns[f.Level]; ok {
+		areaFilter = "t." + col + " = $3"
+	}
+	query := `
+		SELECT t.position_id, t.form, t.registered_voters, t.rejected_votes, t.is_final,
+		       er.candidacy_id, COALESCE(er.votes, 0), p.id, p.slug, p.first_name || ' ' || p.last_name,
+		       COALESCE(pp.abbreviation, pp.name)
+		FROM result_tallies t
+		JOIN elective_positions ep ON ep.id = t.position_id
+		LEFT JOIN election_results er ON er.tally_id = t.id
+		LEFT JOIN candidacies c ON c.id = er.candidacy_id
+		LEFT JOIN politicians p ON p.id = c.politician_id
+		LEFT JOIN political_parties pp ON pp.id = c.party_id
+		WHERE t.election_id = $1 AND t.level = $2 AND t.is_current
+		  AND ($3::uuid IS NULL OR ` + areaFilter + `)
+		  AND ($4::uuid IS NULL OR t.position_id = $4)
+		  AND ($5 = '' OR ep.title = $5)
+		ORDER BY t.position_id, er.votes DESC NULLS LAST`
+
+	rows, err := r.pool.Query(ctx, query, electionID, f.Level, areaID, f.PositionID, f.Title)
+	if err != nil {
+		return fmt.Errorf("get reported results: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			positionID                uuid.UUID
+			form                      *string
+			registered, rejected      int
+			final                     bool
+			candidacyID, politicianID *uuid.UUID
+			votes                     int
+			slug, name, party         *string
+		)
+		if err := rows.Scan(&positionID, &form, &registered, &rejected, &final,
+			&candidacyID, &votes, &politicianID, &slug, &name, &party); err != nil {
+			return fmt.Errorf("scan reported result: %w", err)
+		}
+		p := get(positionID)
+		p.Form, p.RegisteredVoters, p.RejectedVotes, p.IsFinal = form, registered, rejected, final
+		if candidacyID != nil {
+			p.ValidVotes += votes
+			p.Candidates = append(p.Candidates, models.CandidateResult{
+				CandidacyID: *candidacyID, PoliticianID: *politicianID, Slug: *slug, Name: *name, Party: party, Votes: votes,
+			})
+		}
+	}
+	return rows.Err()
+}
+
+func (r *ResultsRepo) describePositions(ctx context.Context, ids []uuid.UUID, positions map[uuid.UUID]*models.PositionResult) error {
+	rows, err := r.pool.Query(ctx,
+		`SELECT ep.id, ep.title, ep.level, COALESCE(w.name, cn.name, co.name)
+		 FROM elective_positions ep
+		 LEFT JOIN wards w ON w.id = ep.ward_id
+		 LEFT JOIN constituencies cn ON cn.id = ep.constituency_id
+		 LEFT JOIN counties co ON co.id = ep.county_id
+		 WHERE ep.id = ANY($1)`, ids)
+	if err != nil {
+		return fmt.Errorf("describe positions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		var title, level string
+		var area *string
+		if err := rows.Scan(&id, &title, &level, &area); err != nil {
+			return fmt.Errorf("scan position: %w", err)
+		}
+		p := positions[id]
+		p.Title, p.SeatLevel, p.SeatArea = title, level, area
+	}
+	return rows.Err()
+}
+
+// expectedStations counts the polling stations in the area whose voters
+// elect each seat.
+func (r *ResultsRepo) expectedStations(ctx context.Context, ids []uuid.UUID, level string, areaID *uuid.UUID) (map[uuid.UUID]int, error) {
+	query := `
+		WITH ` + stationScopesSQL + `
+		SELECT ep.id, COUNT(*)
+		FROM elective_positions ep
+		JOIN station_scopes sc ON sc.level = ep.level AND sc.area_id = ` + seatAreaSQL + `
+		JOIN polling_stations ps ON ps.id = sc.polling_station_id
+		JOIN wards w ON w.id = ps.ward_id
+		JOIN constituencies cn ON cn.id = w.constituency_id
+		WHERE ep.id = ANY($1)
+		  AND ($2::uuid IS NULL OR ` + stationScope(level, 2) + `)
+		GROUP BY ep.id`
+
+	rows, err := r.pool.Query(ctx, query, ids, areaID)
+	if err != nil {
+		return nil, fmt.Errorf("count expected stations: %w", err)
+	}
+	defer rows.Close()
+
+	expected := make(map[uuid.UUID]int, len(ids))
+	for rows.Next() {
+		var id uuid.UUID
+		var n int
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, fmt.Errorf("scan expected stations: %w", err)
+		}
+		expected[id] = n
+	}
+	return expected, rows.Err()
+}
+
+func sortPositionResults(ps []models.PositionResult) {
+	sort.SliceStable(ps, func(i, j int) bool {
+		ri, rj := models.PositionRank[ps[i].Title], models.PositionRank[ps[j].Title]
+		if ri != rj {
+			return ri < rj
+		}
+		return derefString(ps[i].SeatArea) < derefString(ps[j].SeatArea)
+	})
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// ResultsChannel is the NOTIFY channel carrying the id of each tally as its
+// votes are committed.
+const ResultsChannel = "election_results"
+
+// Listen calls fn with each tally id announced on ResultsChannel until ctx
+// is cancelled or the connection fails.
+func (r *ResultsRepo) Listen(ctx context.Context, fn func(context.Context, uuid.UUID)) error {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire listener: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+ResultsChannel); err != nil {
+		return fmt.Errorf("listen for results: %w", err)
+	}
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("wait for results: %w", err)
+		}
+		id, err := uuid.Parse(n.Payload)
+		if err != nil {
+			continue
+		}
+		fn(ctx, id)
+	}
+}
+
+const resultUpdateSQL = `
+	SELECT t.seq, t.id, t.election_id, t.position_id, ep.title, t.level, t.form,
+	       COALESCE(ps.id, w.id, cn.id, co.id), COALESCE(ps.code, w.code, cn.code, co.code),
+	       COALESCE(ps.name, w.name, cn.name, co.name), w.code, cn.code, co.code,
+	       t.registered_voters, t.rejected_votes, t.is_final, t.reported_at
+	FROM result_tallies t
+	JOIN elective_positions ep ON ep.id = t.position_id
+	LEFT JOIN polling_stations ps ON ps.id = t.polling_station_id
+	LEFT JOIN wards w ON w.id = COALESCE(t.ward_id, ps.ward_id)
+	LEFT JOIN constituencies cn ON cn.id = COALESCE(t.constituency_id, w.constituency_id)
+	LEFT JOIN counties co ON co.id = COALESCE(t.county_id, cn.county_id)`
+
+// GetUpdate returns a tally as a live update, or nil if it does not exist.
+func (r *ResultsRepo) GetUpdate(ctx context.Context, tallyID uuid.UUID) (*models.ResultUpdate, error) {
+	updates, err := r.updates(ctx, resultUpdateSQL+` WHERE t.id = $1`, tallyID)
+	if err != nil || len(updates) == 0 {
+		return nil, err
+	}
+	return &updates[0], nil
+}
+
+// UpdatesSince returns up to limit tallies that arrived after seq, oldest
+// first. A nil electionID covers every election.
+func (r *ResultsRepo) UpdatesSince(ctx context.Context, electionID *uuid.UUID, seq int64, limit int) ([]models.ResultUpdate, error) {
+	return r.updates(ctx, resultUpdateSQL+`
+		WHERE t.seq > $1 AND ($2::uuid IS NULL OR t.election_id = $2)
+		ORDER BY t.seq LIMIT $3`, seq, electionID, limit)
+}
+
+// LatestSeq returns the sequence number of the newest tally, zero if none
+// has arrived. A nil electionID covers every election.
+func (r *ResultsRepo) LatestSeq(ctx context.Context, electionID *uuid.UUID) (int64, error) {
+	var seq int64
+	err := r.pool.QueryRow(ctx,
+		`SELECT COALESCE(MAX(seq), 0) FROM result_tallies WHERE $1::uuid IS NULL OR election_id = $1`, electionID,
+	).Scan(&seq)
+	if err != nil {
+		return 0, fmt.Errorf("get latest result seq: %w", err)
+	}
+	return seq, nil
+}
+
+// LatestSeqs returns the sequence number of the newest tally in each
+// election that has any.
+func (r *ResultsRepo) LatestSeqs(ctx context.Context) (map[uuid.UUID]int64, error) {
+	rows, err := r.pool.Query(ctx, `SELECT election_id, MAX(seq) FROM result_tallies GROUP BY election_id`)
+	if err != nil {
+		return nil, fmt.Errorf("get latest result seqs: %w", err)
+	}
+	defer rows.Close()
+
+	seqs := make(map[uuid.UUID]int64)
+	for rows.Next() {
+		var id uuid.UUID
+		var seq int64
+		if err := rows.Scan(&id, &seq); err != nil {
+			return nil, fmt.Errorf("scan latest result seq: %w", err)
+		}
+		seqs[id] = seq
+	}
+	return seqs, rows.Err()
+}
+
+func (r *ResultsRepo) updates(ctx context.Context, query string, args ...interface{}) ([]models.ResultUpdate, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get result updates: %w", err)
+	}
+	defer rows.Close()
+
+	var updates []models.ResultUpdate
+	index := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var u models.ResultUpdate
+		var areaID *uuid.UUID
+		var code, name *string
+		if err := rows.Scan(&u.Seq, &u.TallyID, &u.ElectionID, &u.PositionID, &u.Title, &u.Level, &u.Form,
+			&areaID, &code, &name, &u.WardCode, &u.ConstituencyCode, &u.CountyCode,
+			&u.RegisteredVoters, &u.RejectedVotes, &u.IsFinal, &u.ReportedAt); err != nil {
+			return nil, fmt.Errorf("scan result update: %w", err)
+		}
+		if u.Level != "national" && areaID != nil {
+			u.Area = &models.AreaRef{ID: *areaID, Code: *code, Name: *name}
+		}
+		u.Candidates = []models.CandidateResult{}
+		index[u.TallyID] = len(updates)
+		updates = append(updates, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(updates))
+	for _, u := range updates {
+		ids = append(ids, u.TallyID)
+	}
+	votes, err := r.pool.Query(ctx,
+		`SELECT er.tally_id, er.candidacy_id, er.votes, p.id, p.slug, p.first_name || ' ' || p.last_name,
+		        COALESCE(pp.abbreviation, pp.name), `+runningMateColumns+`
+		 FROM election_results er
+		 JOIN candidacies c ON c.id = er.candidacy_id
+		 JOIN politicians p ON p.id = c.politician_id
+		 LEFT JOIN political_parties pp ON pp.id = c.party_id`+runningMateJoins+`
+		 WHERE er.tally_id = ANY($1)
+		 ORDER BY er.votes DESC`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get result update votes: %w", err)
+	}
+	defer votes.Close()
+
+	for votes.Next() {
+		var tallyID uuid.UUID
+		var c models.CandidateResult
+		var mate runningMateRow
+		if err := votes.Scan(append([]interface{}{&tallyID, &c.CandidacyID, &c.Votes, &c.PoliticianID, &c.Slug, &c.Name, &c.Party},
+			mate.dest()...)...); err != nil {
+			return nil, fmt.Errorf("scan result update vote: %w", err)
+		}
+		c.RunningMate = mate.mate()
+		u := &updates[index[tallyID]]
+		u.ValidVotes += c.Votes
+		u.Candidates = append(u.Candidates, c)
+	}
+	if err := votes.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range updates {
+		u := &updates[i]
+		for j := range u.Candidates {
+			if u.ValidVotes > 0 {
+				u.Candidates[j].Share = math.Round(float64(u.Candidates[j].Votes)/float64(u.ValidVotes)*10000) / 100
+			}
+		}
+	}
+	return updates, nil
+}
+
+// PresidentialCountyVotes returns each presidential candidate's votes per
+// county. A constituency is summed from its station tallies once every one
+// of its stations has reported; until then its constituency tally is used
+// if it has one, and the stations so far if it does not. Counties are built
+// from their constituencies the same way, falling back to a tally filed for
+// the county while some constituency has nothing in. A partial sum is never
+// final.
+func (r *ResultsRepo) PresidentialCountyVotes(ctx context.Context, electionID uuid.UUID) ([]models.CountyVotes, error) {
+	query := `
+		WITH tallies AS (
+			SELECT t.id, t.level, t.polling_station_id, t.constituency_id, t.county_id, t.is_final
+			FROM result_tallies t
+			JOIN elective_positions ep ON ep.id = t.position_id
+			WHERE t.election_id = $1 AND t.is_current AND ep.title = 'president'
+		),
+		station_tallies AS (
+			SELECT t.id, t.is_final, w.constituency_id
+			FROM tallies t
+			JOIN polling_stations ps ON ps.id = t.polling_station_id
+			JOIN wards w ON w.id = ps.ward_id
+			WHERE t.level = 'station'
+		),
+		stations_in AS (
+			SELECT st.constituency_id, COUNT(*) = (
+				SELECT COUNT(*) FROM polling_stations ps JOIN wards w ON w.id = ps.ward_id
+				WHERE w.constituency_id = st.constituency_id) AS complete,
+			       BOOL_AND(st.is_final) AS is_final
+			FROM station_tallies st
+			GROUP BY st.constituency_id
+		),
+		constituency_basis AS (
+			SELECT cn.id AS constituency_id, cn.county_id, t.id AS tally_id,
+			       COALESCE(si.complete, false) OR t.id IS NULL AS from_stations,
+			       CASE WHEN si.complete THEN si.is_final ELSE COALESCE(t.is_final, false) END AS is_final
+			FROM constituencies cn
+			LEFT JOIN stations_in si ON si.constituency_id = cn.id
+			LEFT JOIN tallies t ON t.constituency_id = cn.id AND t.level = 'constituency'
+			WHERE si.constituency_id IS NOT NULL OR t.id IS NOT NULL
+		),
+		by_constituency AS (
+			SELECT b.constituency_id, b.county_id, er.candidacy_id, SUM(er.votes) AS votes, b.is_final
+			FROM constituency_basis b
+			JOIN station_tallies st ON st.constituency_id = b.constituency_id
+			JOIN election_results er ON er.tally_id = st.id
+			WHERE b.from_stations
+			GROUP BY b.constituency_id, b.county_id, er.candidacy_id, b.is_final
+			UNION ALL
+			SELECT b.constituency_id, b.county_id, er.candidacy_id, er.votes, b.is_final
+			FROM constituency_basis b JOIN election_results er ON er.tally_id = b.tally_id
+			WHERE NOT b.from_stations
+		),
+		constituencies_in AS (
+			SELECT cn.county_id, COUNT(b.constituency_id) = COUNT(*) AS complete,
+			       COALESCE(BOOL_AND(b.is_final), false) AS is_final
+			FROM constituencies cn
+			LEFT JOIN constituency_basis b ON b.constituency_id = cn.id
+			GROUP BY cn.county_id
+			HAVING COUNT(b.constituency_id) > 0
+		),
+		county_basis AS (
+			SELECT co.id AS county_id, t.id AS tally_id,
+			       COALESCE(ci.complete, false) OR t.id IS NULL AS from_constituencies,
+			       CASE WHEN ci.complete THEN ci.is_final ELSE COALESCE(t.is_final, false) END AS is_final
+			FROM counties co
+			LEFT JOIN constituencies_in ci ON ci.county_id = co.id
+			LEFT JOIN tallies t ON t.county_id = co.id AND t.level = 'county'
+			WHERE ci.county_id IS NOT NULL OR t.id IS NOT NULL
+		),
+		by_county AS (
+			SELECT b.county_id, c.candidacy_id, SUM(c.votes) AS votes, b.is_final
+			FROM county_basis b JOIN by_constituency c ON c.county_id = b.county_id
+			WHERE b.from_constituencies
+			GROUP BY b.county_id, c.candidacy_id, b.is_final
+			UNION ALL
+			SELECT b.county_id, er.candidacy_id, er.votes, b.is_final
+			FROM county_basis b JOIN election_results er ON er.tally_id = b.tally_id
+			WHERE NOT b.from_constituencies
+		)
+		SELECT co.id, co.code, co.name, b.candidacy_id, p.id, p.slug, p.first_name || ' ' || p.last_name,
+		       COALESCE(pp.abbreviation, pp.name), b.votes, b.is_final
+		FROM by_county b
+		JOIN counties co ON co.id = b.county_id
+		JOIN candidacies c ON c.id = b.candidacy_id
+		JOIN politicians p ON p.id = c.politician_id
+		LEFT JOIN political_parties pp ON pp.id = c.party_id
+		ORDER BY co.code, b.votes DESC`
+
+	rows, err := r.pool.Query(ctx, query, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("get presidential county votes: %w", err)
+	}
+	defer rows.Close()
+
+	var out []models.CountyVotes
+	for rows.Next() {
+		var v models.CountyVotes
+		c := &v.Candidate
+		if err := rows.Scan(&v.County.ID, &v.County.Code, &v.County.Name, &c.CandidacyID, &c.PoliticianID,
+			&c.Slug, &c.Name, &c.Party, &c.Votes, &v.IsFinal); err != nil {
+			return nil, fmt.Errorf("scan presidential county votes: %w", err)
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// PresidentialDeclaration returns the candidates' votes on the current,
+// final national presidential tally (Form 34C), or nil if none has been
+// filed.
+func (r *ResultsRepo) PresidentialDeclaration(ctx context.Context, electionID uuid.UUID) ([]models.CandidateResult, error) {
+	query := `
+		SELECT er.candidacy_id, p.id, p.slug, p.first_name || ' ' || p.last_name,
+		       COALESCE(pp.abbreviation, pp.name), er.votes
+		FROM result_tallies t
+		JOIN elective_positions ep ON ep.id = t.position_id
+		JOIN election_results er ON er.tally_id = t.id
+		JOIN candidacies c ON c.id = er.candidacy_id
+		JOIN politicians p ON p.id = c.politician_id
+		LEFT JOIN political_parties pp ON pp.id = c.party_id
+		WHERE t.election_id = $1 AND t.is_current AND t.is_final
+		  AND t.level = 'national' AND ep.title = 'president'
+		ORDER BY er.votes DESC`
+
+	rows, err := r.pool.Query(ctx, query, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("get presidential declaration: %w", err)
+	}
+	defer rows.Close()
+
+	var out []models.CandidateResult
+	for rows.Next() {
+		var c models.CandidateResult
+		if err := rows.Scan(&c.CandidacyID, &c.PoliticianID, &c.Slug, &c.Name, &c.Party, &c.Votes); err != nil {
+			return nil, fmt.Errorf("scan presidential declaration: %w", err)
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+func (r *ResultsRepo) CountCounties(ctx context.Context) (int, error) {
+	var n int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM counties`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count counties: %w", err)
+	}
+	return n, nil
+}
+
+// SeatCandidates returns every candidacy in an election with its votes
+// for its seat, ordered by seat. A seat's votes come from its declared
+// tally, filed at the seat's own level, when there is one; otherwise each
+// constituency of the seat is counted from its finest tallies, and the
+// count is only final once every polling station in the seat has a final
+// tally. Running mates take their principal's votes. Withdrawn and
+// disqualified candidacies are left out unless they received votes.
+func (r *ResultsRepo) SeatCandidates(ctx context.Context, electionID uuid.UUID, f models.SeatFilter) ([]models.SeatResult, error) {
+	query := `
+		WITH ` + stationScopesSQL + `,
+		placed AS (
+			SELECT t.id, t.position_id, t.level, t.is_final,
+			       COALESCE(t.constituency_id, w.constituency_id) AS constituency_id
+			FROM result_tallies t
+			LEFT JOIN polling_stations ps ON ps.id = t.polling_station_id
+			LEFT JOIN wards w ON w.id = COALESCE(t.ward_id, ps.ward_id)
+			WHERE t.election_id = $1 AND t.is_current
+		),
+		declared AS (
+			SELECT p.id, p.position_id, p.is_final
+			FROM placed p JOIN elective_positions ep ON ep.id = p.position_id
+			WHERE p.level = ep.level
+		),
+		finest AS (
+			SELECT p.* FROM placed p
+			WHERE p.level IN ('station', 'ward', 'constituency')
+			  AND p.position_id NOT IN (SELECT position_id FROM declared)
+			  AND p.level = (
+				SELECT CASE WHEN BOOL_OR(q.level = 'station') THEN 'station'
+				            WHEN BOOL_OR(q.level = 'ward') THEN 'ward'
+				            ELSE 'constituency' END
+				FROM placed q
+				WHERE q.position_id = p.position_id AND q.constituency_id = p.constituency_id
+				  AND q.level IN ('station', 'ward', 'constituency'))
+		),
+		stations_in AS (
+			SELECT p.position_id, COUNT(*) AS stations
+			FROM placed p
+			WHERE p.level = 'station' AND p.position_id IN (SELECT position_id FROM finest)
+			GROUP BY p.position_id
+		),
+		counted_complete AS (
+			SELECT ep.id AS position_id, COUNT(*) = MAX(si.stations) AS complete
+			FROM elective_positions ep
+			JOIN stations_in si ON si.position_id = ep.id
+			JOIN station_scopes sc ON sc.level = ep.level AND sc.area_id = ` + seatAreaSQL + `
+			GROUP BY ep.id
+		),
+		votes AS (
+			SELECT d.position_id, er.candidacy_id, er.votes, 'declared' AS basis, d.is_final
+			FROM declared d JOIN election_results er ON er.tally_id = d.id
+			UNION ALL
+			SELECT f.position_id, er.candidacy_id, SUM(er.votes), 'counted',
+			       BOOL_AND(f.is_final) AND BOOL_AND(COALESCE(cc.complete, false))
+			FROM finest f
+			JOIN election_results er ON er.tally_id = f.id
+			LEFT JOIN counted_complete cc ON cc.position_id = f.position_id
+			GROUP BY f.position_id, er.candidacy_id
+		)
+		SELECT ep.id, ep.title, ep.level,
+		       COALESCE(w.id, cn.id, co.id), COALESCE(w.code, cn.code, co.code), COALESCE(w.name, cn.name, co.name),
+		       co.id, co.code, co.name,
+		       c.id, p.id, p.slug, p.first_name || ' ' || p.last_name, COALESCE(pp.abbreviation, pp.name), pp.slug,
+		       c.status, v.votes, v.basis, v.is_final
+		FROM candidacies c
+		JOIN elective_positions ep ON ep.id = c.position_id
+		JOIN politicians p ON p.id = c.politician_id
+		LEFT JOIN political_parties pp ON pp.id = c.party_id
+		LEFT JOIN wards w ON w.id = ep.ward_id
+		LEFT JOIN constituencies cn ON cn.id = COALESCE(ep.constituency_id, w.constituency_id)
+		LEFT JOIN counties co ON co.id = COALESCE(ep.county_id, cn.county_id)
+		LEFT JOIN joint_tickets jt ON jt.deputy_candidacy_id = c.id
+		LEFT JOIN candidacies pc ON pc.id = jt.principal_candidacy_id
+		LEFT JOIN votes v ON v.position_id = COALESCE(pc.position_id, c.position_id)
+		                 AND v.candidacy_id = COALESCE(pc.id, c.id)
+		WHERE c.election_id = $1
+		  AND (v.votes IS NOT NULL OR c.status NOT IN ('withdrew', 'disqualified'))
+		  AND ($2::uuid IS NULL OR ep.id = $2)
+		  AND ($3 = '' OR ep.title = $3)
+		  AND ($4 = '' OR co.code = $4)
+		ORDER BY ep.id, v.votes DESC NULLS LAST, p.last_name`
+
+	rows, err := r.pool.Query(ctx, query, electionID, f.PositionID, f.Title, f.County)
+	if err != nil {
+		return nil, fmt.Errorf("get seat results: %w", err)
+	}
+	defer rows.Close()
+
+	var seats []models.SeatResult
+	for rows.Next() {
+		var (
+			s                      models.SeatResult
+			areaID, countyID       *uuid.UUID
+			areaCode, areaName     *string
+			countyCode, countyName *string
+			c                      models.SeatCandidate
+			votes                  *int
+			basis                  *string
+			final                  *bool
+		)
+		if err := rows.Scan(&s.PositionID, &s.Title, &s.Level, &areaID, &areaCode, &areaName,
+			&countyID, &countyCode, &countyName,
+			&c.CandidacyID, &c.PoliticianID, &c.Slug, &c.Name, &c.Party, &c.PartySlug,
+			&c.Status, &votes, &basis, &final); err != nil {
+			return nil, fmt.Errorf("scan seat result: %w", err)
+		}
+
+		if n := len(seats); n == 0 || seats[n-1].PositionID != s.PositionID {
+			s.Area = areaRef(areaID, areaCode, areaName)
+			if s.Level == "ward" || s.Level == "constituency" {
+				s.County = areaRef(countyID, countyCode, countyName)
+			}
+			s.Basis = "none"
+			s.Candidates = []models.SeatCandidate{}
+			seats = append(seats, s)
+		}
+		seat := &seats[len(seats)-1]
+		if votes != nil {
+			c.Votes = *votes
+			seat.ValidVotes += c.Votes
+			// Candidates with votes come first, so the first one sets the
+			// seat's basis and the rest can only clear is_final.
+			if seat.Basis == "none" {
+				seat.Basis, seat.IsFinal = *basis, *final
+			} else {
+				seat.IsFinal = seat.IsFinal && *final
+			}
+		}
+		seat.Candidates = append(seat.Candidates, c)
+	}
+	return seats, rows.Err()
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"jalada/internal/models"
+)
+
+var SearchTypes = []string{"politician", "party", "coalition", "county", "constituency", "article", "court_case", "promise"}
+
+type SearchRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewSearchRepo(pool *pgxpool.Pool) *SearchRepo {
+	return &SearchRepo{pool: pool}
+}
+
+// The query is parsed with both configs and OR-ed, so "wakulima" matches
+// verbatim through simple while "elections" also matches "election" through
+// english. Headlines are only computed for the rows that are returned.
+const searchQuery = `
+	WITH q AS (
+		SELECT websearch_to_tsquery('english', $1) || websearch_to_tsquery('simple', $1) AS query
+	),
+	hits AS (
+		SELECT 'politician' AS type, p.id, p.first_name || ' ' || p.last_name AS title, NULL::text AS subtitle,
+		       COALESCE(p.bio, '') AS body, '/v1/politicians/' || p.slug AS url,
+		       ts_rank_cd(p.search_vector, q.query) AS rank
+		FROM politicians p, q WHERE p.search_vector @@ q.query
+		UNION ALL
+		SELECT 'party', pp.id, pp.name, pp.abbreviation, COALESCE(pp.ideology, ''),
+		       '/v1/parties/' || pp.slug, ts_rank_cd(pp.search_vector, q.query)
+		FROM political_parties pp, q WHERE pp.search_vector @@ q.query
+		UNION ALL
+		SELECT 'coalition', c.id, c.name, NULL, '', '/v1/coalitions/' || c.slug, ts_rank_cd(c.search_vector, q.query)
+		FROM coalitions c, q WHERE c.search_vector @@ q.query
+		UNION ALL
+		SELECT 'county', co.id, co.name, NULL, '', '/v1/counties/' || co.code, ts_rank_cd(co.search_vector, q.query)
+		FROM counties co, q WHERE co.search_vector @@ q.query
+		UNION ALL
+		SELECT 'constituency', cn.id, cn.name, co.name || ' County', '',
+		       '/v1/constituencies/' || cn.code, ts_rank_cd(cn.search_vector, q.query)
+		FROM constituencies cn JOIN counties co ON co.id = cn.county_id, q
+		WHERE cn.search_vector @@ q.query
+		UNION ALL
+		SELECT 'article', a.id, a.title, ns.name, COALESCE(a.summary, a.content, ''),
+		       '/v1/news/' || a.id, ts_rank_cd(a.search_vector, q.query)
+		FROM news_articles a LEFT JOIN news_sources ns ON ns.id = a.source_id, q
+		WHERE a.search_vector @@ q.query
+		UNION ALL
+		SELECT 'court_case', cc.id, cc.title, p.first_name || ' ' || p.last_name,
+		       COALESCE(cc.description, '') || ' ' || COALESCE(cc.outcome, ''),
+		       '/v1/politicians/' || p.slug || '/court-cases', ts_rank_cd(cc.search_vector, q.query)
+		FROM court_cases cc JOIN politicians p ON p.id = cc.politician_id, q
+		WHERE cc.search_vector @@ q.query
+		UNION ALL
+		SELECT 'promise', pr.id, p.first_name || ' ' || p.last_name, pr.status, pr.description,
+		       '/v1/politicians/' || p.slug || '/promises', ts_rank_cd(pr.search_vector, q.query)
+		FROM promises pr JOIN politicians p ON p.id = pr.politician_id, q
+		WHERE pr.search_vector @@ q.query
+	),
+	ranked AS (
+		SELECT *,
+		       row_number() OVER (PARTITION BY type ORDER BY rank DESC, title) AS n,
+		       count(*) OVER (PARTITION BY type) AS total
+		FROM hits
+		WHERE type = ANY($2)
+	)
+	SELECT r.type, r.id, r.title, r.subtitle,
+	       CASE WHEN r.body = '' THEN ''
+	            ELSE ts_headline('english', r.body, q.query,
+	                             'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" … "')
+	       END,
+	       r.url, r.rank, r.total
+	FROM ranked r, q
+	WHERE r.n <= $3
+	ORDER BY r.type, r.rank DESC, r.title`
+
+// Search runs one full-text query over every searchable table and groups
+// the matches by type. Groups are ordered by their best match.
+func (r *SearchRepo) Search(ctx context.Context, f models.SearchFilter) (*models.SearchResponse, error) {
+	if f.Limit <= 0 {
+		f.Limit = 5
+	}
+	if len(f.Types) == 0 {
+		f.Types = SearchTypes
+	}
+
+	rows, err := r.pool.Query(ctx, searchQuery, f.Query, f.Types, f.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+	defer rows.Close()
+
+	resp := &models.SearchResponse{Query: f.Query, Groups: []models.SearchGroup{}}
+	groups := make(map[string]*models.SearchGroup)
+	for rows.Next() {
+		var res models.SearchResult
+		var total int
+		if err := rows.Scan(&res.Type, &res.ID, &res.Title, &res.Subtitle, &res.Snippet, &res.URL, &res.Rank, &total); err != nil {
+			return nil, fmt.Errorf("scan search result: %w", err)
+		}
+		g, ok := groups[res.Type]
+		if !ok {
+			g = &models.SearchGroup{Type: res.Type, Total: total}
+			groups[res.Type] = g
+			resp.Total += total
+		}
+		g.Results = append(g.Results, res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+
+	for _, g := range groups {
+		resp.Groups = append(resp.Groups, *g)
+	}
+	sort.Slice(resp.Groups, func(i, j int) bool {
+		a, b := resp.Groups[i], resp.Groups[j]
+		if a.Results[0].Rank != b.Results[0].Rank {
+			return a.Results[0].Rank > b.Results[0].Rank
+		}
+		return a.Type < b.Type
+	})
+	return resp, nil
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SourceRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewSourceRepo(pool *pgxpool.Pool) *SourceRepo {
+	return &SourceRepo{pool: pool}
+}
+
+// FindOrCreate returns the source with url, creating an unverified one
+// when none exists yet. It runs on the transaction joined by ctx, if any.
+func (r *SourceRepo) FindOrCreate(ctx context.Context, name, url, sourceType string) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := conn(ctx, r.pool).QueryRow(ctx,
+		`INSERT INTO sources (name, url, type, last_accessed_at) VALUES ($1, $2, $3, NOW())
+		 ON CONFLICT (url) DO UPDATE SET last_accessed_at = NOW()
+		 RETURNING id`,
+		name, url, sourceType,
+	).Scan(&id)
+	if err != nil {
+		return uuid.Nil, mapWriteError("find or create source", err)
+	}
+	return id, nil
+}
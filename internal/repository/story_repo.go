@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"jalada/internal/models"
+)
+
+type StoryRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewStoryRepo(pool *pgxpool.Pool) *StoryRepo {
+	return &StoryRepo{pool: pool}
+}
+
+const storySelect = `
+		SELECT sc.id, sc.headline, sc.lead_article_id, sc.first_seen_at, sc.last_seen_at,
+		       sc.article_count, sc.outlet_count, sc.is_election_related
+		FROM story_clusters sc`
+
+// List returns story clusters with their articles, most recently active
+// first or, sorted by coverage, the most outlets first.
+func (r *StoryRepo) List(ctx context.Context, f models.StoryFilter) ([]models.StoryCluster, int, error) {
+	var args []interface{}
+	where := " WHERE true"
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		where += " AND " + fmt.Sprintf(cond, len(args))
+	}
+
+	if f.Since != nil {
+		add(`sc.last_seen_at >= $%[1]d`, *f.Since)
+	}
+	if f.ElectionRelated {
+		where += " AND sc.is_election_related"
+	}
+	if f.MinOutlets > 1 {
+		add(`sc.outlet_count >= $%[1]d`, f.MinOutlets)
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM story_clusters sc`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count stories: %w", err)
+	}
+
+	order := " ORDER BY sc.last_seen_at DESC, sc.id"
+	if f.Sort == "coverage" {
+		order = " ORDER BY sc.outlet_count DESC, sc.article_count DESC, sc.last_seen_at DESC, sc.id"
+	}
+	args = append(args, f.Limit, f.Offset)
+	order += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	stories, err := r.stories(ctx, storySelect+where+order, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return stories, total, nil
+}
+
+func (r *StoryRepo) Get(ctx context.Context, id uuid.UUID) (*models.StoryCluster, error) {
+	stories, err := r.stories(ctx, storySelect+` WHERE sc.id = $1`, id)
+	if err != nil || len(stories) == 0 {
+		return nil, err
+	}
+	return &stories[0], nil
+}
+
+// stories runs a storySelect query and loads the articles of every
+// cluster found.
+func (r *StoryRepo) stories(ctx context.Context, query string, args ...interface{}) ([]models.StoryCluster, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get stories: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		stories []models.StoryCluster
+		ids     []uuid.UUID
+	)
+	for rows.Next() {
+		var s models.StoryCluster
+		if err := rows.Scan(&s.ID, &s.Headline, &s.LeadArticleID, &s.FirstSeenAt, &s.LastSeenAt,
+			&s.ArticleCount, &s.OutletCount, &s.IsElectionRelated); err != nil {
+			return nil, fmt.Errorf("scan story: %w", err)
+		}
+		s.Outlets, s.Articles = []string{}, []models.StoryArticle{}
+		stories = append(stories, s)
+		ids = append(ids, s.ID)
+	}
+	if err := rows.Err(); err != nil || len(stories) == 0 {
+		return stories, err
+	}
+
+	articles, err := r.articles(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range stories {
+		seen := make(map[string]bool)
+		for _, a := range articles[stories[i].ID] {
+			stories[i].Articles = append(stories[i].Articles, a)
+			if a.Outlet != nil && !seen[*a.Outlet] {
+				seen[*a.Outlet] = true
+				stories[i].Outlets = append(stories[i].Outlets, *a.Outlet)
+			}
+		}
+	}
+	return stories, nil
+}
+
+// articles returns each cluster's articles, earliest first. An article's
+// outlet is its source's outlet, or the source name when none is set.
+func (r *StoryRepo) articles(ctx context.Context, clusterIDs []uuid.UUID) (map[uuid.UUID][]models.StoryArticle, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT na.cluster_id, na.id, na.source_id, COALESCE(ns.outlet, ns.name), na.title, na.url, na.published_at
+		FROM news_articles na
+		LEFT JOIN news_sources ns ON ns.id = na.source_id
+		WHERE na.cluster_id = ANY($1)
+		ORDER BY COALESCE(na.published_at, na.scraped_at), na.id`, clusterIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get story articles: %w", err)
+	}
+	defer rows.Close()
+
+	articles := make(map[uuid.UUID][]models.StoryArticle)
+	for rows.Next() {
+		var (
+			clusterID uuid.UUID
+			a         models.StoryArticle
+		)
+		if err := rows.Scan(&clusterID, &a.ID, &a.SourceID, &a.Outlet, &a.Title, &a.URL, &a.PublishedAt); err != nil {
+			return nil, fmt.Errorf("scan story article: %w", err)
+		}
+		articles[clusterID] = append(articles[clusterID], a)
+	}
+	return articles, rows.Err()
+}
+
+// Unclustered returns up to limit articles waiting for the clusterer,
+// earliest first.
+func (r *StoryRepo) Unclustered(ctx context.Context, limit int) ([]models.ClusterCandidate, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, title, COALESCE(content, summary, ''), COALESCE(published_at, scraped_at)
+		FROM news_articles
+		WHERE cluster_id IS NULL
+		ORDER BY COALESCE(published_at, scraped_at), id
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get unclustered articles: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []models.ClusterCandidate
+	for rows.Next() {
+		var c models.ClusterCandidate
+		if err := rows.Scan(&c.ArticleID, &c.Title, &c.Text, &c.At); err != nil {
+			return nil, fmt.Errorf("scan unclustered article: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+// Clustered returns the signatures of clustered articles dated between
+// from and to.
+func (r *StoryRepo) Clustered(ctx context.Context, from, to time.Time) ([]models.ClusterCandidate, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, cluster_id, COALESCE(published_at, scraped_at), minhash
+		FROM news_articles
+		WHERE cluster_id IS NOT NULL AND minhash IS NOT NULL
+		  AND COALESCE(published_at, scraped_at) BETWEEN $1 AND $2`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("get clustered articles: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []models.ClusterCandidate
+	for rows.Next() {
+		var c models.ClusterCandidate
+		if err := rows.Scan(&c.ArticleID, &c.ClusterID, &c.At, &c.MinHash); err != nil {
+			return nil, fmt.Errorf("scan clustered article: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+// Assign stores an article's signature and puts it in a cluster, starting
+// a new cluster led by the article when clusterID is nil. It returns the
+// article's cluster.
+func (r *StoryRepo) Assign(ctx context.Context, articleID uuid.UUID, minhash []int64, clusterID *uuid.UUID) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		if clusterID != nil {
+			id = *clusterID
+		} else {
+			err := tx.QueryRow(ctx, `
+				INSERT INTO story_clusters (lead_article_id, headline, first_seen_at, last_seen_at, is_election_related)
+				SELECT id, title, COALESCE(published_at, scraped_at), COALESCE(published_at, scraped_at), is_election_related
+				FROM news_articles WHERE id = $1
+				RETURNING id`, articleID).Scan(&id)
+			if err == pgx.ErrNoRows {
+				return ErrNotFound
+			}
+			if err != nil {
+				return fmt.Errorf("create story cluster: %w", err)
+			}
+		}
+		_, err := tx.Exec(ctx, `UPDATE news_articles SET cluster_id = $2, minhash = $3 WHERE id = $1`,
+			articleID, id, minhash)
+		if err != nil {
+			return fmt.Errorf("assign story cluster: %w", err)
+		}
+		return nil
+	})
+	return id, err
+}
+
+// Refresh recomputes the lead article, dates and counts of clusters that
+// have gained articles.
+func (r *StoryRepo) Refresh(ctx context.Context, clusterIDs []uuid.UUID) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE story_clusters sc
+		SET lead_article_id = x.lead_id, headline = x.headline,
+		    first_seen_at = x.first_seen, last_seen_at = x.last_seen,
+		    article_count = x.articles, outlet_count = x.outlets, is_election_related = x.election
+		FROM (
+			SELECT na.cluster_id,
+			       (array_agg(na.id ORDER BY COALESCE(na.published_at, na.scraped_at), na.id))[1] AS lead_id,
+			       (array_agg(na.title ORDER BY COALESCE(na.published_at, na.scraped_at), na.id))[1] AS headline,
+			       MIN(COALESCE(na.published_at, na.scraped_at)) AS first_seen,
+			       MAX(COALESCE(na.published_at, na.scraped_at)) AS last_seen,
+			       COUNT(*) AS articles,
+			       GREATEST(COUNT(DISTINCT COALESCE(ns.outlet, ns.name)), 1) AS outlets,
+			       bool_or(na.is_election_related) AS election
+			FROM news_articles na
+			LEFT JOIN news_sources ns ON ns.id = na.source_id
+			WHERE na.cluster_id = ANY($1)
+			GROUP BY na.cluster_id
+		) x
+		WHERE sc.id = x.cluster_id`, clusterIDs)
+	if err != nil {
+		return fmt.Errorf("refresh story clusters: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"jalada/internal/models"
+)
+
+type SubmissionRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewSubmissionRepo(pool *pgxpool.Pool) *SubmissionRepo {
+	return &SubmissionRepo{pool: pool}
+}
+
+const submissionColumns = `
+	s.id, s.politician_id, p.first_name || ' ' || p.last_name, s.collection, s.operation, s.record_id,
+	s.payload, s.summary, s.evidence_url, s.evidence_name, s.evidence_type, s.submitter_name,
+	s.submitter_email, s.status, s.reviewer, s.review_comment, s.reviewed_at, s.source_id,
+	s.applied_record_id, s.created_at, s.updated_at`
+
+func scanSubmission(row pgx.Row, s *models.EditSubmission) error {
+	return row.Scan(
+		&s.ID, &s.PoliticianID, &s.PoliticianName, &s.Collection, &s.Operation, &s.RecordID,
+		&s.Payload, &s.Summary, &s.EvidenceURL, &s.EvidenceName, &s.EvidenceType, &s.SubmitterName,
+		&s.SubmitterEmail, &s.Status, &s.Reviewer, &s.ReviewComment, &s.ReviewedAt, &s.SourceID,
+		&s.AppliedRecordID, &s.CreatedAt, &s.UpdatedAt,
+	)
+}
+
+func (r *SubmissionRepo) Create(ctx context.Context, s *models.EditSubmission) error {
+	err := r.pool.QueryRow(ctx,
+		`INSERT INTO edit_submissions (politician_id, collection, operation, record_id, payload, summary,
+		                               evidence_url, evidence_name, evidence_type, submitter_name, submitter_email)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		 RETURNING id, status, created_at, updated_at`,
+		s.PoliticianID, s.Collection, s.Operation, s.RecordID, s.Payload, s.Summary,
+		s.EvidenceURL, s.EvidenceName, s.EvidenceType, s.SubmitterName, s.SubmitterEmail,
+	).Scan(&s.ID, &s.Status, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return mapWriteError("create submission", err)
+	}
+	return nil
+}
+
+func (r *SubmissionRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.EditSubmission, error) {
+	query := `SELECT` + submissionColumns + `
+		FROM edit_submissions s
+		JOIN politicians p ON p.id = s.politician_id
+		WHERE s.id = $1`
+
+	var s models.EditSubmission
+	err := scanSubmission(r.pool.QueryRow(ctx, query, id), &s)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get submission: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *SubmissionRepo) List(ctx context.Context, f models.SubmissionFilter) ([]models.EditSubmission, int, error) {
+	if f.Limit <= 0 {
+		f.Limit = 20
+	}
+
+	var total int
+	err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM edit_submissions WHERE $1 = '' OR status = $1`, f.Status,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count submissions: %w", err)
+	}
+
+	query := `SELECT` + submissionColumns + `
+		FROM edit_submissions s
+		JOIN politicians p ON p.id = s.politician_id
+		WHERE $1 = '' OR s.status = $1
+		ORDER BY s.created_at
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.pool.Query(ctx, query, f.Status, f.Limit, f.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list submissions: %w", err)
+	}
+	defer rows.Close()
+
+	var submissions []models.EditSubmission
+	for rows.Next() {
+		var s models.EditSubmission
+		if err := scanSubmission(rows, &s); err != nil {
+			return nil, 0, fmt.Errorf("scan submission: %w", err)
+		}
+		submissions = append(submissions, s)
+	}
+	return submissions, total, rows.Err()
+}
+
+// Transition locks a submission, lets fn move it to its next state and
+// saves the review fields. The row stays locked while fn runs, so two
+// reviewers cannot approve the same submission twice. fn's context joins
+// the transaction, so audited writes made with it commit together with the
+// new state, and if fn fails nothing is saved.
+func (r *SubmissionRepo) Transition(ctx context.Context, id uuid.UUID, fn func(context.Context, *models.EditSubmission) error) (*models.EditSubmission, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin submission transition: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `SELECT` + submissionColumns + `
+		FROM edit_submissions s
+		JOIN politicians p ON p.id = s.politician_id
+		WHERE s.id = $1
+		FOR UPDATE OF s`
+
+	var s models.EditSubmission
+	err = scanSubmission(tx.QueryRow(ctx, query, id), &s)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lock submission: %w", err)
+	}
+
+	if err := fn(joinTx(ctx, tx), &s); err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx,
+		`UPDATE edit_submissions
+		 SET status = $2, reviewer = $3, review_comment = $4, reviewed_at = $5,
+		     source_id = $6, applied_record_id = $7
+		 WHERE id = $1
+		 RETURNING updated_at`,
+		s.ID, s.Status, s.Reviewer, s.ReviewComment, s.ReviewedAt, s.SourceID, s.AppliedRecordID,
+	).Scan(&s.UpdatedAt)
+	if err != nil {
+		return nil, mapWriteError("update submission", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit submission transition: %w", err)
+	}
+	return &s, nil
+}
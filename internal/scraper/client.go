@@ -0,0 +1,248 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"jalada/internal/models"
+)
+
+const (
+	robotsTTL      = 24 * time.Hour
+	robotsRetryTTL = time.Hour
+)
+
+var (
+	// ErrNotModified is returned for a conditional request whose page has
+	// not changed since the validators were issued.
+	ErrNotModified = errors.New("not modified")
+	// ErrDisallowed is returned for a URL the host's robots.txt keeps the
+	// aggregator out of.
+	ErrDisallowed = errors.New("disallowed by robots.txt")
+)
+
+// StatusError is returned for a response other than a 200 or 304.
+type StatusError struct {
+	URL  string
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: unexpected status %d", e.URL, e.Code)
+}
+
+// Validators are the cache validators of an earlier response, sent back
+// so that an unchanged page costs a 304 instead of a download.
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+// Client makes the aggregator's requests. It sends the configured user
+// agent, keeps to each host's robots.txt, lets at most perHost requests
+// run against one host at a time and spaces them by the host delay or the
+// host's Crawl-delay, whichever is longer.
+type Client struct {
+	http      *http.Client
+	userAgent string
+	agent     string
+	perHost   int
+	delay     time.Duration
+
+	mu    sync.Mutex
+	hosts map[string]*hostState
+}
+
+// hostState is what the client knows about one host.
+type hostState struct {
+	slots chan struct{}
+
+	mu        sync.Mutex
+	robots    *robotsRules
+	robotsExp time.Time
+	limiter   *rate.Limiter
+}
+
+func NewClient(userAgent string, timeout time.Duration, perHost int, hostDelay time.Duration) *Client {
+	return &Client{
+		http:      &http.Client{Timeout: timeout},
+		userAgent: userAgent,
+		agent:     productToken(userAgent),
+		perHost:   max(perHost, 1),
+		delay:     hostDelay,
+		hosts:     make(map[string]*hostState),
+	}
+}
+
+// Get requests a URL and returns the response if it is a 200.
+func (c *Client) Get(ctx context.Context, rawURL string) (*http.Response, error) {
+	return c.GetConditional(ctx, rawURL, Validators{})
+}
+
+// GetConditional requests a URL with the validators of an earlier
+// response. It returns ErrNotModified on a 304, and the response if it is
+// a 200. The host's slot is held until the response body is closed.
+func (c *Client) GetConditional(ctx context.Context, rawURL string, v Validators) (*http.Response, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid url %q", rawURL)
+	}
+	h := c.host(u.Host)
+
+	rules, limiter := c.robots(ctx, u, h)
+	if !rules.allowed(u.RequestURI()) {
+		return nil, fmt.Errorf("%s: %w", rawURL, ErrDisallowed)
+	}
+	if limiter != nil {
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	release, err := h.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	if v.ETag != "" {
+		req.Header.Set("If-None-Match", v.ETag)
+	}
+	if v.LastModified != "" {
+		req.Header.Set("If-Modified-Since", v.LastModified)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+		return resp, nil
+	case http.StatusNotModified:
+		resp.Body.Close()
+		release()
+		return nil, ErrNotModified
+	default:
+		resp.Body.Close()
+		release()
+		return nil, &StatusError{URL: rawURL, Code: resp.StatusCode}
+	}
+}
+
+// sourceValidators returns the validators stored for a source's last
+// response.
+func sourceValidators(source models.NewsSource) Validators {
+	var v Validators
+	if fs := source.FetchState; fs != nil {
+		if fs.ETag != nil {
+			v.ETag = *fs.ETag
+		}
+		if fs.LastModified != nil {
+			v.LastModified = *fs.LastModified
+		}
+	}
+	return v
+}
+
+func responseValidators(resp *http.Response) Validators {
+	return Validators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+}
+
+func (c *Client) host(name string) *hostState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h, ok := c.hosts[name]
+	if !ok {
+		h = &hostState{slots: make(chan struct{}, c.perHost)}
+		c.hosts[name] = h
+	}
+	return h
+}
+
+// robots returns the host's rules and the limiter enforcing its
+// Crawl-delay, if it has one, reading its robots.txt when the cached copy
+// has expired. A robots.txt answering with a 4xx allows everything. One
+// that cannot be read, through a network error, a 5xx or a 429, disallows
+// everything until it is asked for again an hour later.
+func (c *Client) robots(ctx context.Context, u *url.URL, h *hostState) (*robotsRules, *rate.Limiter) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.robots != nil && time.Now().Before(h.robotsExp) {
+		return h.robots, h.limiter
+	}
+
+	rules, ttl := disallowAll, robotsRetryTTL
+	if release, err := h.acquire(ctx); err == nil {
+		robotsURL := (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/robots.txt"}).String()
+		if req, err := http.NewRequestWithContext(ctx, "GET", robotsURL, nil); err == nil {
+			req.Header.Set("User-Agent", c.userAgent)
+			if resp, err := c.http.Do(req); err == nil {
+				switch {
+				case resp.StatusCode == http.StatusOK:
+					rules, ttl = parseRobots(io.LimitReader(resp.Body, 512<<10), c.agent), robotsTTL
+				case resp.StatusCode == http.StatusTooManyRequests:
+					// Treated like a 5xx: unreachable for now.
+				case resp.StatusCode >= 400 && resp.StatusCode < 500:
+					// No robots.txt: everything is allowed.
+					rules, ttl = allowAll, robotsTTL
+				}
+				resp.Body.Close()
+			}
+		}
+		release()
+	}
+	if ctx.Err() != nil {
+		// Cancelled before robots.txt could be read; ask again next time.
+		return rules, nil
+	}
+
+	h.robots, h.robotsExp = rules, time.Now().Add(ttl)
+	h.limiter = nil
+	if delay := max(rules.crawlDelay, c.delay); delay > 0 {
+		h.limiter = rate.NewLimiter(rate.Every(delay), 1)
+	}
+	return rules, h.limiter
+}
+
+// acquire waits for a free request slot on the host. The returned func
+// gives the slot back.
+func (h *hostState) acquire(ctx context.Context) (func(), error) {
+	select {
+	case h.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	var once sync.Once
+	return func() { once.Do(func() { <-h.slots }) }, nil
+}
+
+// releasingBody gives the host's slot back when the body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
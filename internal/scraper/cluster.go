@@ -0,0 +1,209 @@
+package scraper
+
+import (
+	"context"
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"jalada/internal/repository"
+)
+
+const (
+	// minHashSize is the number of hash functions in a signature. The
+	// similarity estimate is off by about 0.04 at this size.
+	minHashSize = 128
+	// storyThreshold is the estimated Jaccard similarity of title and lead
+	// words above which two articles are taken to cover the same story.
+	// Rewrites of one event by different outlets usually share a third of
+	// their words; unrelated political stories share well under a fifth.
+	storyThreshold = 0.3
+	// storyWindow is how far apart two articles on one story can be.
+	storyWindow = 48 * time.Hour
+	// leadWords is how much of the body counts besides the title.
+	leadWords = 80
+	// clusterBatch caps the articles clustered in one cycle.
+	clusterBatch = 1000
+)
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// stopwords are left out of signatures. Words under three letters are
+// dropped anyway.
+var stopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "that": true, "with": true, "this": true, "from": true,
+	"was": true, "were": true, "are": true, "has": true, "have": true, "had": true, "not": true,
+	"but": true, "his": true, "her": true, "its": true, "their": true, "they": true, "them": true,
+	"who": true, "which": true, "will": true, "would": true, "said": true, "says": true, "say": true,
+	"also": true, "been": true, "being": true, "into": true, "over": true, "after": true, "before": true,
+	"than": true, "then": true, "there": true, "what": true, "when": true, "where": true, "while": true,
+	"about": true, "against": true, "all": true, "any": true, "can": true, "could": true, "did": true,
+	"does": true, "how": true, "more": true, "most": true, "one": true, "our": true, "out": true,
+	"she": true, "should": true, "some": true, "such": true, "these": true, "those": true, "very": true,
+	"you": true, "your": true, "may": true, "new": true, "now": true, "other": true, "only": true,
+	"kwa": true, "katika": true, "wakati": true, "hiyo": true, "hii": true, "kuwa": true,
+}
+
+// seeds pick the hash functions of a MinHash signature.
+var seeds = func() [minHashSize]uint64 {
+	var s [minHashSize]uint64
+	x := uint64(0x9e3779b97f4a7c15)
+	for i := range s {
+		x = splitmix(x)
+		s[i] = x
+	}
+	return s
+}()
+
+// ClusterStories puts each new article into a story cluster. An article
+// joins the cluster of its most similar article from the previous or next
+// two days when their MinHash similarity reaches storyThreshold, and
+// starts a cluster of its own otherwise.
+func ClusterStories(ctx context.Context, storyRepo *repository.StoryRepo) {
+	pending, err := storyRepo.Unclustered(ctx, clusterBatch)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to get unclustered articles")
+		return
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	from, to := pending[0].At.Add(-storyWindow), pending[len(pending)-1].At.Add(storyWindow)
+	clustered, err := storyRepo.Clustered(ctx, from, to)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to get clustered articles")
+		return
+	}
+
+	var (
+		joined  int
+		touched = make(map[uuid.UUID]bool)
+	)
+	for _, c := range pending {
+		c.MinHash = minHash(storyWords(c.Title, c.Text))
+
+		var (
+			best    *uuid.UUID
+			bestSim float64
+		)
+		for _, other := range clustered {
+			if d := c.At.Sub(other.At); d > storyWindow || d < -storyWindow {
+				continue
+			}
+			if sim := similarity(c.MinHash, other.MinHash); sim >= storyThreshold && sim > bestSim {
+				best, bestSim = other.ClusterID, sim
+			}
+		}
+
+		id, err := storyRepo.Assign(ctx, c.ArticleID, c.MinHash, best)
+		if err != nil {
+			log.Warn().Err(err).Str("article", c.ArticleID.String()).Msg("failed to cluster article")
+			continue
+		}
+		if best != nil {
+			joined++
+			touched[id] = true
+		}
+		if c.MinHash != nil {
+			c.ClusterID = &id
+			clustered = append(clustered, c)
+		}
+	}
+
+	if len(touched) > 0 {
+		ids := make([]uuid.UUID, 0, len(touched))
+		for id := range touched {
+			ids = append(ids, id)
+		}
+		if err := storyRepo.Refresh(ctx, ids); err != nil {
+			log.Error().Err(err).Msg("failed to refresh story clusters")
+			return
+		}
+	}
+	log.Info().Int("articles", len(pending)).Int("joined", joined).Int("clusters_grown", len(touched)).
+		Msg("story clustering complete")
+}
+
+// storyWords returns the distinct words of the title and the start of the
+// body, lowercased, without markup or stopwords.
+func storyWords(title, body string) []string {
+	seen := make(map[string]bool)
+	var words []string
+	add := func(text string, limit int) {
+		n := 0
+		for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if limit > 0 && n >= limit {
+				return
+			}
+			n++
+			if len([]rune(w)) < 3 || stopwords[w] || seen[w] {
+				continue
+			}
+			seen[w] = true
+			words = append(words, w)
+		}
+	}
+	add(title, 0)
+	add(htmlTag.ReplaceAllString(body, " "), leadWords)
+	return words
+}
+
+// minHash returns the MinHash signature of a set of words, or nil for an
+// empty set. Slot i holds the least value of the i-th hash function over
+// the words, so the share of slots two signatures agree on estimates the
+// Jaccard similarity of their sets.
+func minHash(words []string) []int64 {
+	if len(words) == 0 {
+		return nil
+	}
+	var mins [minHashSize]uint64
+	for i := range mins {
+		mins[i] = ^uint64(0)
+	}
+	for _, w := range words {
+		h := fnv.New64a()
+		h.Write([]byte(w))
+		base := h.Sum64()
+		for i, seed := range seeds {
+			if v := splitmix(base ^ seed); v < mins[i] {
+				mins[i] = v
+			}
+		}
+	}
+	sig := make([]int64, minHashSize)
+	for i, v := range mins {
+		sig[i] = int64(v)
+	}
+	return sig
+}
+
+// similarity is the share of slots two signatures agree on.
+func similarity(a, b []int64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var same int
+	for i := range a {
+		if a[i] == b[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(a))
+}
+
+// splitmix is the SplitMix64 finaliser, used to derive independent hash
+// functions from one word hash.
+func splitmix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
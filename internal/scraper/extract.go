@@ -0,0 +1,230 @@
+package scraper
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+const (
+	// minParagraphLen is the shortest paragraph that counts towards a
+	// container's score, which keeps captions and link lines out.
+	minParagraphLen = 25
+	maxBylineLen    = 100
+)
+
+// boilerplate is removed from article pages before looking for the story.
+const boilerplate = "script, style, noscript, nav, header, footer, aside, form, iframe, button, svg"
+
+var (
+	// unlikelyHints match the class and id of page furniture; likelyHints
+	// match those of story containers.
+	unlikelyHints = regexp.MustCompile(`(?i)comment|share|social|related|recommend|promo|sidebar|footer|header|menu|nav|advert|sponsor|newsletter|subscribe|popup|modal|cookie|breadcrumb|widget|trending|most-read`)
+	likelyHints   = regexp.MustCompile(`(?i)article|story|content|entry|post|body|text|main`)
+	bylinePrefix  = regexp.MustCompile(`(?i)^(written\s+)?by\s+`)
+)
+
+// Extractor downloads article pages and pulls out the story text, byline
+// and canonical URL. Its requests are paced by the Client like any other.
+type Extractor struct {
+	client *Client
+}
+
+func NewExtractor(client *Client) *Extractor {
+	return &Extractor{client: client}
+}
+
+// Extraction is what an Extractor finds on an article page. Fields it
+// could not find are empty.
+type Extraction struct {
+	Text         string
+	Byline       string
+	CanonicalURL string
+}
+
+// Extract fetches an article page and reads its story text, byline and
+// canonical URL.
+func (e *Extractor) Extract(ctx context.Context, articleURL string) (*Extraction, error) {
+	resp, err := e.client.Get(ctx, articleURL)
+	if err != nil {
+		return nil, err
+	}
+	doc, base, err := parseDocument(resp)
+	if err != nil {
+		return nil, err
+	}
+	// The byline and canonical link usually sit in the page header, so they
+	// are read before the boilerplate goes.
+	x := &Extraction{
+		Byline:       byline(doc),
+		CanonicalURL: canonicalURL(doc, base),
+	}
+	x.Text = storyText(doc)
+	return x, nil
+}
+
+// storyText finds the element holding the story the way readability tools
+// do: every paragraph scores its parent, and half as much its grandparent,
+// by its length and number of commas. The best-scoring container, less
+// its share of link text, is taken along with any sibling that scores
+// nearly as well. Its paragraphs are joined with blank lines.
+func storyText(doc *goquery.Document) string {
+	body := doc.Find("body")
+	body.Find(boilerplate).Remove()
+	body.Find("*").Each(func(_ int, sel *goquery.Selection) {
+		if sel.Is("article, main") {
+			return
+		}
+		hints := classAndID(sel)
+		if unlikelyHints.MatchString(hints) && !likelyHints.MatchString(hints) {
+			sel.Remove()
+		}
+	})
+
+	scores := make(map[*html.Node]float64)
+	addScore := func(sel *goquery.Selection, score float64) {
+		if sel.Length() == 0 || sel.Is("html") {
+			return
+		}
+		n := sel.Get(0)
+		if _, ok := scores[n]; !ok {
+			scores[n] = hintWeight(sel)
+		}
+		scores[n] += score
+	}
+	body.Find("p").Each(func(_ int, p *goquery.Selection) {
+		text := collapseSpace(p.Text())
+		if len(text) < minParagraphLen {
+			return
+		}
+		score := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len(text))/100, 3)
+		parent := p.Parent()
+		addScore(parent, score)
+		addScore(parent.Parent(), score/2)
+	})
+
+	var (
+		best      *goquery.Selection
+		bestScore float64
+	)
+	for n, score := range scores {
+		sel := doc.FindNodes(n)
+		score *= 1 - linkDensity(sel)
+		scores[n] = score
+		if best == nil || score > bestScore {
+			best, bestScore = sel, score
+		}
+	}
+	if best == nil {
+		return ""
+	}
+
+	threshold := math.Max(10, bestScore*0.2)
+	var parts []string
+	best.Parent().Children().Each(func(_ int, sel *goquery.Selection) {
+		n := sel.Get(0)
+		switch {
+		case n == best.Get(0) || scores[n] >= threshold:
+			parts = append(parts, paragraphs(sel)...)
+		case sel.Is("p") && linkDensity(sel) < 0.25:
+			if text := collapseSpace(sel.Text()); len(text) >= 80 {
+				parts = append(parts, text)
+			}
+		}
+	})
+	return strings.Join(parts, "\n\n")
+}
+
+// paragraphs returns the text of the container's paragraphs that are
+// mostly not links.
+func paragraphs(sel *goquery.Selection) []string {
+	var parts []string
+	ps := sel.Find("p")
+	if sel.Is("p") {
+		ps = sel
+	}
+	ps.Each(func(_ int, p *goquery.Selection) {
+		text := collapseSpace(p.Text())
+		if text != "" && linkDensity(p) < 0.5 {
+			parts = append(parts, text)
+		}
+	})
+	return parts
+}
+
+// hintWeight favours containers whose class or id names a story and
+// penalises page furniture.
+func hintWeight(sel *goquery.Selection) float64 {
+	hints := classAndID(sel)
+	var w float64
+	if likelyHints.MatchString(hints) {
+		w += 25
+	}
+	if unlikelyHints.MatchString(hints) {
+		w -= 25
+	}
+	return w
+}
+
+func classAndID(sel *goquery.Selection) string {
+	class, _ := sel.Attr("class")
+	id, _ := sel.Attr("id")
+	return class + " " + id
+}
+
+// linkDensity is the share of the selection's text inside links.
+func linkDensity(sel *goquery.Selection) float64 {
+	total := len(collapseSpace(sel.Text()))
+	if total == 0 {
+		return 0
+	}
+	var links int
+	sel.Find("a").Each(func(_ int, a *goquery.Selection) {
+		links += len(collapseSpace(a.Text()))
+	})
+	return math.Min(1, float64(links)/float64(total))
+}
+
+// byline reads the article's author from its metadata or the usual byline
+// markup, without a leading "By".
+func byline(doc *goquery.Document) string {
+	candidates := []string{meta(doc, "author"), meta(doc, "article:author")}
+	doc.Find(`[rel=author], [itemprop=author], .byline, .author`).EachWithBreak(func(_ int, sel *goquery.Selection) bool {
+		if name := sel.Find(`[itemprop=name]`).First(); name.Length() > 0 {
+			sel = name
+		}
+		candidates = append(candidates, collapseSpace(sel.Text()))
+		return len(candidates) < 6
+	})
+	for _, c := range candidates {
+		c = strings.TrimSpace(bylinePrefix.ReplaceAllString(c, ""))
+		// article:author is often a profile link rather than a name.
+		if c != "" && len(c) <= maxBylineLen && !strings.Contains(c, "://") {
+			return c
+		}
+	}
+	return ""
+}
+
+// canonicalURL returns the page's rel=canonical link, or its og:url,
+// resolved against the URL it was served from.
+func canonicalURL(doc *goquery.Document, base *url.URL) string {
+	href, _ := doc.Find(`link[rel=canonical]`).First().Attr("href")
+	if href = strings.TrimSpace(href); href == "" {
+		href = meta(doc, "og:url")
+	}
+	if href == "" {
+		return ""
+	}
+	u, err := base.Parse(href)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	u.Fragment = ""
+	return u.String()
+}
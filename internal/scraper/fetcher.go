@@ -0,0 +1,368 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"jalada/internal/config"
+	"jalada/internal/models"
+	"jalada/internal/repository"
+)
+
+// Fetcher collects articles from one type of news source. Fetch sends
+// the validators of the source's last response and returns the articles
+// not stored yet.
+type Fetcher interface {
+	Fetch(ctx context.Context, source models.NewsSource) (*FetchResult, error)
+}
+
+// FetchResult is what a Fetcher found for one source.
+type FetchResult struct {
+	Articles []*models.NewsArticle
+	// Seen counts the items on the feed or listing page.
+	Seen int
+	// Skipped counts items that were already stored.
+	Skipped int
+	// Errors counts items that could not be read.
+	Errors int
+	// NotModified is set when the feed or listing page had not changed
+	// since the last response, in which case there are no articles.
+	NotModified bool
+	// Validators are sent with the next request for the source.
+	Validators Validators
+}
+
+// fetchLogRetention is how long fetch attempts are kept.
+const fetchLogRetention = 30 * 24 * time.Hour
+
+// Aggregator runs the fetcher for each due source's type and stores what
+// they find. Sources are fetched by a pool of workers, and every attempt
+// is logged. For sources with extract_full_text set, each new article's
+// page is also read for its full text.
+type Aggregator struct {
+	newsRepo    *repository.NewsRepo
+	fetchers    map[string]Fetcher
+	extractor   *Extractor
+	workers     int
+	interval    time.Duration
+	maxBackoff  time.Duration
+	maxFailures int
+}
+
+// NewAggregator returns an Aggregator with fetchers keyed by
+// news_sources.type. Sources of other types are skipped.
+func NewAggregator(newsRepo *repository.NewsRepo, fetchers map[string]Fetcher, extractor *Extractor, cfg config.AggregationConfig) *Aggregator {
+	return &Aggregator{
+		newsRepo:    newsRepo,
+		fetchers:    fetchers,
+		extractor:   extractor,
+		workers:     max(cfg.Workers, 1),
+		interval:    cfg.Interval,
+		maxBackoff:  cfg.MaxBackoff,
+		maxFailures: cfg.MaxFailures,
+	}
+}
+
+// fetchTally adds up a cycle's results across workers.
+type fetchTally struct {
+	mu                                                sync.Mutex
+	new, skipped, fetched, unchanged, failed, blocked int
+}
+
+func (a *Aggregator) FetchAll(ctx context.Context) {
+	start := time.Now()
+	sources, err := a.newsRepo.GetDueSources(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to get due news sources")
+		return
+	}
+
+	jobs := make(chan models.NewsSource)
+	var (
+		tally fetchTally
+		wg    sync.WaitGroup
+	)
+	for i := 0; i < a.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for source := range jobs {
+				a.fetchSource(ctx, source, start, &tally)
+			}
+		}()
+	}
+	for _, source := range interleaveHosts(sources) {
+		if _, ok := a.fetchers[source.Type]; !ok {
+			continue
+		}
+		select {
+		case jobs <- source:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if n, err := a.newsRepo.PruneFetchLog(ctx, time.Now().Add(-fetchLogRetention)); err != nil {
+		log.Warn().Err(err).Msg("failed to prune news fetch log")
+	} else if n > 0 {
+		log.Debug().Int64("deleted", n).Msg("pruned news fetch log")
+	}
+
+	log.Info().Int("new", tally.new).Int("skipped", tally.skipped).Int("sources", tally.fetched).
+		Int("unchanged", tally.unchanged).Int("failed", tally.failed).Int("blocked", tally.blocked).
+		Dur("duration", time.Since(start)).
+		Msg("news fetch cycle complete")
+}
+
+// fetchSource fetches one source, stores its new articles and logs the
+// attempt. Attempts cut short by shutdown are not logged.
+func (a *Aggregator) fetchSource(ctx context.Context, source models.NewsSource, cycleStart time.Time, tally *fetchTally) {
+	if ctx.Err() != nil {
+		return
+	}
+	attempt := models.FetchAttempt{SourceID: source.ID, StartedAt: time.Now()}
+	res, err := a.fetchers[source.Type].Fetch(ctx, source)
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+
+	if err != nil {
+		msg := err.Error()
+		attempt.Error = &msg
+		var serr *StatusError
+		if errors.As(err, &serr) {
+			attempt.StatusCode = &serr.Code
+		}
+		attempt.DurationMS = time.Since(attempt.StartedAt).Milliseconds()
+		a.logAttempt(ctx, source, &attempt)
+
+		if errors.Is(err, ErrDisallowed) {
+			a.recordBlocked(ctx, source, &attempt)
+			log.Info().Err(err).Str("source", source.Name).Msg("skipped source disallowed by robots.txt")
+			tally.mu.Lock()
+			tally.fetched++
+			tally.blocked++
+			tally.mu.Unlock()
+			return
+		}
+
+		failures := a.recordFailure(ctx, source, cycleStart, &attempt)
+		log.Warn().Err(err).Str("source", source.Name).Str("type", source.Type).Int("failures", failures).
+			Msg("failed to fetch source")
+		tally.mu.Lock()
+		tally.fetched++
+		tally.failed++
+		tally.mu.Unlock()
+		return
+	}
+
+	code := http.StatusOK
+	if res.NotModified {
+		code = http.StatusNotModified
+	}
+	attempt.StatusCode = &code
+	attempt.ItemsSeen, attempt.ItemErrors = res.Seen, res.Errors
+
+	skipCount := res.Skipped
+	for _, article := range res.Articles {
+		if source.ExtractFullText && !a.extractFullText(ctx, article) {
+			skipCount++
+			continue
+		}
+		if err := a.newsRepo.InsertArticle(ctx, article); err != nil {
+			log.Warn().Err(err).Str("url", article.URL).Msg("failed to insert article")
+			attempt.ItemErrors++
+			continue
+		}
+		attempt.NewItems++
+	}
+	attempt.DurationMS = time.Since(attempt.StartedAt).Milliseconds()
+	a.logAttempt(ctx, source, &attempt)
+	a.recordSuccess(ctx, source, res.Validators, &attempt)
+
+	log.Debug().Str("source", source.Name).Int("new", attempt.NewItems).Int("skipped", skipCount).
+		Int("errors", attempt.ItemErrors).Bool("not_modified", res.NotModified).Msg("fetched source")
+	tally.mu.Lock()
+	tally.fetched++
+	tally.new += attempt.NewItems
+	tally.skipped += skipCount
+	if res.NotModified {
+		tally.unchanged++
+	}
+	tally.mu.Unlock()
+}
+
+func (a *Aggregator) logAttempt(ctx context.Context, source models.NewsSource, attempt *models.FetchAttempt) {
+	if err := a.newsRepo.LogFetch(ctx, attempt); err != nil {
+		log.Warn().Err(err).Str("source", source.Name).Msg("failed to log fetch")
+	}
+}
+
+// recordSuccess clears the source's failures and keeps the response's
+// validators, or the previous ones when the response gave none.
+func (a *Aggregator) recordSuccess(ctx context.Context, source models.NewsSource, v Validators, attempt *models.FetchAttempt) {
+	fs := models.FetchState{
+		LastFetchedAt:  attempt.StartedAt,
+		LastSuccessAt:  &attempt.StartedAt,
+		LastStatusCode: attempt.StatusCode,
+	}
+	if prev := source.FetchState; prev != nil && v == (Validators{}) {
+		fs.ETag, fs.LastModified = prev.ETag, prev.LastModified
+	} else {
+		fs.ETag, fs.LastModified = optional(v.ETag), optional(v.LastModified)
+	}
+	if err := a.newsRepo.SaveFetchState(ctx, source.ID, fs); err != nil {
+		log.Warn().Err(err).Str("source", source.Name).Msg("failed to save fetch state")
+	}
+}
+
+// recordBlocked notes an attempt that robots.txt kept from the host,
+// including while the host's robots.txt cannot be read. That says nothing
+// about the source itself, so its failure count is left alone and it is
+// tried again next cycle.
+func (a *Aggregator) recordBlocked(ctx context.Context, source models.NewsSource, attempt *models.FetchAttempt) {
+	var fs models.FetchState
+	if prev := source.FetchState; prev != nil {
+		fs = *prev
+	}
+	fs.LastFetchedAt, fs.LastStatusCode, fs.LastError = attempt.StartedAt, attempt.StatusCode, attempt.Error
+	fs.NextFetchAt = nil
+	if err := a.newsRepo.SaveFetchState(ctx, source.ID, fs); err != nil {
+		log.Warn().Err(err).Str("source", source.Name).Msg("failed to save fetch state")
+	}
+}
+
+// recordFailure counts another failure in a row and puts the source off.
+// After n failures it is next tried 2^(n-1) cycles after the one that
+// failed, up to the maximum backoff, and after maxFailures it is switched
+// off until an editor switches it back on. It returns the failure count.
+func (a *Aggregator) recordFailure(ctx context.Context, source models.NewsSource, cycleStart time.Time, attempt *models.FetchAttempt) int {
+	fs := models.FetchState{
+		LastFetchedAt:       attempt.StartedAt,
+		LastStatusCode:      attempt.StatusCode,
+		LastError:           attempt.Error,
+		ConsecutiveFailures: 1,
+	}
+	if prev := source.FetchState; prev != nil {
+		fs.ETag, fs.LastModified, fs.LastSuccessAt = prev.ETag, prev.LastModified, prev.LastSuccessAt
+		fs.ConsecutiveFailures = prev.ConsecutiveFailures + 1
+	}
+	wait := a.interval
+	for i := 1; i < fs.ConsecutiveFailures && wait < a.maxBackoff; i++ {
+		wait *= 2
+	}
+	// Cycles start an interval apart, so aim half an interval early to
+	// land on the intended one.
+	next := cycleStart.Add(min(wait, a.maxBackoff) - a.interval/2)
+	fs.NextFetchAt = &next
+	if fs.ConsecutiveFailures >= a.maxFailures {
+		now := time.Now()
+		fs.DeactivatedAt = &now
+	}
+
+	if err := a.newsRepo.SaveFetchState(ctx, source.ID, fs); err != nil {
+		log.Warn().Err(err).Str("source", source.Name).Msg("failed to save fetch state")
+	}
+	if fs.DeactivatedAt != nil {
+		if err := a.newsRepo.DeactivateSource(ctx, source.ID); err != nil {
+			log.Warn().Err(err).Str("source", source.Name).Msg("failed to deactivate news source")
+		} else {
+			log.Error().Str("source", source.Name).Int("failures", fs.ConsecutiveFailures).
+				Msg("deactivated news source after repeated failures")
+		}
+	}
+	return fs.ConsecutiveFailures
+}
+
+// interleaveHosts orders sources so that consecutive jobs go to different
+// hosts where possible, keeping workers from queueing on one host.
+func interleaveHosts(sources []models.NewsSource) []models.NewsSource {
+	var (
+		hosts  []string
+		byHost = make(map[string][]models.NewsSource)
+	)
+	for _, s := range sources {
+		host := sourceHost(s)
+		if _, ok := byHost[host]; !ok {
+			hosts = append(hosts, host)
+		}
+		byHost[host] = append(byHost[host], s)
+	}
+
+	ordered := make([]models.NewsSource, 0, len(sources))
+	for len(ordered) < len(sources) {
+		for _, host := range hosts {
+			if queue := byHost[host]; len(queue) > 0 {
+				ordered = append(ordered, queue[0])
+				byHost[host] = queue[1:]
+			}
+		}
+	}
+	return ordered
+}
+
+// sourceHost is the host a source's first request goes to.
+func sourceHost(s models.NewsSource) string {
+	raw := s.URL
+	switch {
+	case s.Scraper != nil:
+		raw = s.Scraper.ListURL
+	case s.FeedURL != nil:
+		raw = *s.FeedURL
+	}
+	if u, err := url.Parse(raw); err == nil {
+		return u.Host
+	}
+	return raw
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// extractFullText reads the article's page for its full text, byline and
+// canonical URL, keeping the feed's text when the page gives less. It
+// reports false when the canonical URL is already stored under another
+// link, and records the article's URL as an alias of it so the page is not
+// fetched again. An article whose page cannot be read is kept as the feed
+// gave it.
+func (a *Aggregator) extractFullText(ctx context.Context, article *models.NewsArticle) bool {
+	x, err := a.extractor.Extract(ctx, article.URL)
+	if err != nil {
+		log.Warn().Err(err).Str("url", article.URL).Msg("failed to extract article text")
+		return true
+	}
+
+	if x.CanonicalURL != "" && x.CanonicalURL != article.URL {
+		exists, err := a.newsRepo.ArticleExistsByURL(ctx, x.CanonicalURL)
+		if err != nil {
+			log.Warn().Err(err).Str("url", x.CanonicalURL).Msg("failed to check article existence")
+		} else if exists {
+			if err := a.newsRepo.InsertArticleAlias(ctx, article.URL, x.CanonicalURL); err != nil {
+				log.Warn().Err(err).Str("url", article.URL).Msg("failed to record article alias")
+			}
+			return false
+		}
+	}
+	if x.CanonicalURL != "" {
+		article.CanonicalURL = &x.CanonicalURL
+	}
+	if x.Text != "" && (article.Content == nil || len(x.Text) > len(*article.Content)) {
+		article.Content = &x.Text
+	}
+	if x.Byline != "" && (article.Author == nil || *article.Author == "") {
+		article.Author = &x.Byline
+	}
+	article.IsElectionRelated = article.IsElectionRelated || isElectionRelated(article.Title, x.Text)
+	return true
+}
@@ -0,0 +1,282 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/rs/zerolog/log"
+
+	"jalada/internal/models"
+	"jalada/internal/repository"
+)
+
+// eat is Kenyan time, used for article dates published without a zone.
+var eat = time.FixedZone("EAT", 3*60*60)
+
+// dateLayouts are tried in order for article dates when the source has no
+// date_layout of its own.
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	"Monday, January 2, 2006 3:04 PM",
+	"Monday, January 2, 2006",
+	"January 2, 2006 3:04 PM",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"02 Jan 2006",
+	"02/01/2006",
+}
+
+// HTMLScraper reads sources without a usable feed. It follows the article
+// links on the source's listing page and reads each article page with the
+// source's CSS selectors.
+type HTMLScraper struct {
+	newsRepo *repository.NewsRepo
+	client   *Client
+}
+
+func NewHTMLScraper(newsRepo *repository.NewsRepo, client *Client) *HTMLScraper {
+	return &HTMLScraper{newsRepo: newsRepo, client: client}
+}
+
+// Fetch returns the articles linked from the listing page that are not
+// stored yet. An unchanged listing page is not read again. Known links are
+// skipped before their pages are downloaded, and an article page that
+// fails is logged and left for the next cycle.
+func (s *HTMLScraper) Fetch(ctx context.Context, source models.NewsSource) (*FetchResult, error) {
+	cfg := source.Scraper
+	if cfg == nil {
+		return nil, errors.New("scraper source has no selector configuration")
+	}
+
+	v := sourceValidators(source)
+	resp, err := s.client.GetConditional(ctx, cfg.ListURL, v)
+	if errors.Is(err, ErrNotModified) {
+		return &FetchResult{NotModified: true, Validators: v}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetch listing page: %w", err)
+	}
+	res := &FetchResult{Validators: responseValidators(resp)}
+	links, err := listingLinks(resp, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Seen = len(links)
+	for _, link := range links {
+		exists, err := s.newsRepo.ArticleExistsByURL(ctx, link)
+		if err != nil {
+			log.Warn().Err(err).Str("url", link).Msg("failed to check article existence")
+			res.Errors++
+			continue
+		}
+		if exists {
+			res.Skipped++
+			continue
+		}
+
+		article, err := s.article(ctx, cfg, link)
+		if err != nil {
+			log.Warn().Err(err).Str("source", source.Name).Str("url", link).Msg("failed to scrape article")
+			res.Errors++
+			continue
+		}
+		article.SourceID = &source.ID
+		res.Articles = append(res.Articles, article)
+	}
+	return res, nil
+}
+
+// listingLinks returns the absolute article URLs on the listing page in
+// page order, without duplicates, up to the source's max_articles.
+func listingLinks(resp *http.Response, cfg *models.ScraperConfig) ([]string, error) {
+	doc, base, err := parseDocument(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []string
+	seen := make(map[string]bool)
+	doc.Find(cfg.LinkSelector).EachWithBreak(func(_ int, sel *goquery.Selection) bool {
+		if !sel.Is("a") {
+			sel = sel.Find("a[href]").First()
+		}
+		href, ok := sel.Attr("href")
+		if !ok {
+			return true
+		}
+		u, err := base.Parse(strings.TrimSpace(href))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return true
+		}
+		u.Fragment = ""
+		if link := u.String(); !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+		return len(links) < cfg.MaxArticles
+	})
+	if len(links) == 0 {
+		return nil, fmt.Errorf("no article links match %q on %s", cfg.LinkSelector, cfg.ListURL)
+	}
+	return links, nil
+}
+
+// article reads one article page. The title falls back to og:title and
+// the date, author and image to the page's article metadata.
+func (s *HTMLScraper) article(ctx context.Context, cfg *models.ScraperConfig, link string) (*models.NewsArticle, error) {
+	doc, _, err := s.document(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	title := firstText(doc, cfg.TitleSelector)
+	if title == "" {
+		title = meta(doc, "og:title")
+	}
+	if title == "" {
+		return nil, fmt.Errorf("no title matches %q", cfg.TitleSelector)
+	}
+
+	article := &models.NewsArticle{Title: title, URL: link}
+	if body := bodyText(doc, cfg.BodySelector); body != "" {
+		article.Content = &body
+	}
+	summary := meta(doc, "og:description")
+	if summary == "" {
+		summary = meta(doc, "description")
+	}
+	if summary != "" {
+		article.Summary = &summary
+	}
+
+	author := meta(doc, "author")
+	if cfg.AuthorSelector != nil {
+		if a := firstText(doc, *cfg.AuthorSelector); a != "" {
+			author = a
+		}
+	}
+	if author != "" {
+		article.Author = &author
+	}
+	if img := meta(doc, "og:image"); img != "" {
+		article.ImageURL = &img
+	}
+	article.PublishedAt = publishedAt(doc, cfg)
+
+	text := summary
+	if text == "" && article.Content != nil {
+		text = *article.Content
+	}
+	article.IsElectionRelated = isElectionRelated(title, text)
+	return article, nil
+}
+
+// document fetches and parses an HTML page, returning the URL it was
+// served from for resolving relative links.
+func (s *HTMLScraper) document(ctx context.Context, pageURL string) (*goquery.Document, *url.URL, error) {
+	resp, err := s.client.Get(ctx, pageURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parseDocument(resp)
+}
+
+// parseDocument parses and closes an HTML response body.
+func parseDocument(resp *http.Response) (*goquery.Document, *url.URL, error) {
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse %s: %w", resp.Request.URL, err)
+	}
+	return doc, resp.Request.URL, nil
+}
+
+// publishedAt reads the article date with the source's selector, then
+// from article:published_time. Unparseable dates are left empty.
+func publishedAt(doc *goquery.Document, cfg *models.ScraperConfig) *time.Time {
+	var raw string
+	if cfg.DateSelector != nil {
+		sel := doc.Find(*cfg.DateSelector).First()
+		if cfg.DateAttribute != nil {
+			raw, _ = sel.Attr(*cfg.DateAttribute)
+		} else {
+			raw = sel.Text()
+		}
+	}
+	if raw = strings.TrimSpace(raw); raw != "" {
+		layouts := dateLayouts
+		if cfg.DateLayout != nil {
+			layouts = []string{*cfg.DateLayout}
+		}
+		if t, ok := parseDate(raw, layouts); ok {
+			return &t
+		}
+	}
+	if t, ok := parseDate(meta(doc, "article:published_time"), dateLayouts); ok {
+		return &t
+	}
+	return nil
+}
+
+// parseDate parses a date in the first matching layout. Dates without a
+// zone are taken as East Africa Time.
+func parseDate(raw string, layouts []string) (time.Time, bool) {
+	if raw == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, raw, eat); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func firstText(doc *goquery.Document, selector string) string {
+	return collapseSpace(doc.Find(selector).First().Text())
+}
+
+// bodyText joins the paragraphs inside the body selector's matches with
+// blank lines, or their whole text if they have no paragraphs.
+func bodyText(doc *goquery.Document, selector string) string {
+	var parts []string
+	doc.Find(selector).Each(func(_ int, sel *goquery.Selection) {
+		sel.Find("script, style, noscript").Remove()
+		paragraphs := sel.Find("p")
+		if paragraphs.Length() == 0 {
+			paragraphs = sel
+		}
+		paragraphs.Each(func(_ int, p *goquery.Selection) {
+			if text := collapseSpace(p.Text()); text != "" {
+				parts = append(parts, text)
+			}
+		})
+	})
+	return strings.Join(parts, "\n\n")
+}
+
+// meta returns the content of the page's <meta> tag with the given
+// property or name.
+func meta(doc *goquery.Document, name string) string {
+	content, _ := doc.Find(fmt.Sprintf(`meta[property=%q], meta[name=%q]`, name, name)).First().Attr("content")
+	return strings.TrimSpace(content)
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,137 @@
+package scraper
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCrawlDelay caps the Crawl-delay a site can ask for, so one host
+// cannot hold a worker for a whole cycle.
+const maxCrawlDelay = 30 * time.Second
+
+// robotsRules are the robots.txt rules that apply to the aggregator on
+// one host.
+type robotsRules struct {
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+type robotsRule struct {
+	allow   bool
+	length  int
+	pattern *regexp.Regexp
+}
+
+// allowAll is used for hosts without a robots.txt.
+var allowAll = &robotsRules{}
+
+// disallowAll is used while a host's robots.txt is unreachable, as RFC
+// 9309 asks.
+var disallowAll = &robotsRules{rules: []robotsRule{{length: 1, pattern: robotsPattern("/")}}}
+
+// parseRobots reads the groups of a robots.txt that name the agent's
+// product token, or the * groups if none do. A group names the agent only
+// when its User-agent equals the token, ignoring case. Rules from every
+// matching group are merged.
+func parseRobots(r io.Reader, agent string) *robotsRules {
+	var (
+		named, wildcard robotsRules
+		foundNamed      bool
+		groupAgents     []string
+		inRules         bool
+	)
+	apply := func(fn func(*robotsRules)) {
+		for _, a := range groupAgents {
+			switch {
+			case a == "*":
+				fn(&wildcard)
+			case a == agent:
+				foundNamed = true
+				fn(&named)
+			}
+		}
+	}
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if inRules {
+				groupAgents, inRules = nil, false
+			}
+			groupAgents = append(groupAgents, strings.ToLower(value))
+		case "allow", "disallow":
+			inRules = true
+			if value == "" {
+				continue
+			}
+			rule := robotsRule{allow: key == "allow", length: len(value), pattern: robotsPattern(value)}
+			apply(func(rr *robotsRules) { rr.rules = append(rr.rules, rule) })
+		case "crawl-delay":
+			inRules = true
+			secs, err := strconv.ParseFloat(value, 64)
+			if err != nil || secs <= 0 {
+				continue
+			}
+			delay := min(time.Duration(secs*float64(time.Second)), maxCrawlDelay)
+			apply(func(rr *robotsRules) { rr.crawlDelay = max(rr.crawlDelay, delay) })
+		}
+	}
+	if foundNamed {
+		return &named
+	}
+	return &wildcard
+}
+
+// robotsPattern turns a robots.txt path pattern into a regexp anchored at
+// the start of the path. * matches any run of characters and a trailing $
+// anchors the end.
+func robotsPattern(p string) *regexp.Regexp {
+	end := strings.HasSuffix(p, "$")
+	p = strings.TrimSuffix(p, "$")
+	parts := strings.Split(p, "*")
+	for i := range parts {
+		parts[i] = regexp.QuoteMeta(parts[i])
+	}
+	expr := "^" + strings.Join(parts, ".*")
+	if end {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
+
+// allowed reports whether path, with its query, may be fetched. The
+// longest matching rule wins, and Allow wins a tie.
+func (rr *robotsRules) allowed(path string) bool {
+	allow, longest := true, -1
+	for _, rule := range rr.rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		if rule.length > longest || (rule.length == longest && rule.allow) {
+			allow, longest = rule.allow, rule.length
+		}
+	}
+	return allow
+}
+
+// productToken is the lowercased name robots.txt groups are matched
+// against, such as "jalada" for "Jalada/1.0".
+func productToken(userAgent string) string {
+	token, _, _ := strings.Cut(userAgent, "/")
+	if f := strings.Fields(token); len(f) > 0 {
+		token = f[0]
+	}
+	return strings.ToLower(token)
+}
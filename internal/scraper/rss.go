@@ -2,9 +2,9 @@ package scraper
 
 import (
 	"context"
-	"net/http"
+	"errors"
+	"fmt"
 	"strings"
-	"time"
 
 	"github.com/mmcdole/gofeed"
 	"github.com/rs/zerolog/log"
@@ -24,91 +24,61 @@ var electionKeywords = []string{
 }
 
 type RSSFetcher struct {
-	newsRepo  *repository.NewsRepo
-	parser    *gofeed.Parser
-	client    *http.Client
-	userAgent string
+	newsRepo *repository.NewsRepo
+	parser   *gofeed.Parser
+	client   *Client
 }
 
-func NewRSSFetcher(newsRepo *repository.NewsRepo, userAgent string, timeout time.Duration) *RSSFetcher {
+func NewRSSFetcher(newsRepo *repository.NewsRepo, client *Client) *RSSFetcher {
 	return &RSSFetcher{
-		newsRepo:  newsRepo,
-		parser:    gofeed.NewParser(),
-		client:    &http.Client{Timeout: timeout},
-		userAgent: userAgent,
+		newsRepo: newsRepo,
+		parser:   gofeed.NewParser(),
+		client:   client,
 	}
 }
 
-func (f *RSSFetcher) FetchAll(ctx context.Context) {
-	sources, err := f.newsRepo.GetActiveSources(ctx)
-	if err != nil {
-		log.Error().Err(err).Msg("failed to get active news sources")
-		return
+// Fetch reads the source's feed, unless it is unchanged since the last
+// response, and returns the items not stored yet.
+func (f *RSSFetcher) Fetch(ctx context.Context, source models.NewsSource) (*FetchResult, error) {
+	if source.FeedURL == nil || *source.FeedURL == "" {
+		return nil, errors.New("rss source has no feed_url")
 	}
 
-	var totalNew, totalSkipped int
-	for _, source := range sources {
-		if source.FeedURL == nil || *source.FeedURL == "" {
-			continue
-		}
-		newCount, skipCount := f.fetchSource(ctx, source)
-		totalNew += newCount
-		totalSkipped += skipCount
-	}
-	log.Info().Int("new", totalNew).Int("skipped", totalSkipped).Int("sources", len(sources)).Msg("RSS fetch cycle complete")
-}
-
-func (f *RSSFetcher) fetchSource(ctx context.Context, source models.NewsSource) (newCount, skipCount int) {
-	req, err := http.NewRequestWithContext(ctx, "GET", *source.FeedURL, nil)
-	if err != nil {
-		log.Warn().Err(err).Str("source", source.Name).Msg("failed to create request")
-		return 0, 0
+	v := sourceValidators(source)
+	resp, err := f.client.GetConditional(ctx, *source.FeedURL, v)
+	if errors.Is(err, ErrNotModified) {
+		return &FetchResult{NotModified: true, Validators: v}, nil
 	}
-	req.Header.Set("User-Agent", f.userAgent)
-
-	resp, err := f.client.Do(req)
 	if err != nil {
-		log.Warn().Err(err).Str("source", source.Name).Msg("failed to fetch feed")
-		return 0, 0
+		return nil, fmt.Errorf("fetch feed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		log.Warn().Int("status", resp.StatusCode).Str("source", source.Name).Msg("non-200 response from feed")
-		return 0, 0
-	}
-
 	feed, err := f.parser.Parse(resp.Body)
 	if err != nil {
-		log.Warn().Err(err).Str("source", source.Name).Msg("failed to parse feed")
-		return 0, 0
+		return nil, fmt.Errorf("parse feed: %w", err)
 	}
 
+	res := &FetchResult{Seen: len(feed.Items), Validators: responseValidators(resp)}
 	for _, item := range feed.Items {
 		if item.Link == "" {
+			res.Errors++
 			continue
 		}
 
 		exists, err := f.newsRepo.ArticleExistsByURL(ctx, item.Link)
 		if err != nil {
 			log.Warn().Err(err).Str("url", item.Link).Msg("failed to check article existence")
+			res.Errors++
 			continue
 		}
 		if exists {
-			skipCount++
+			res.Skipped++
 			continue
 		}
-
-		article := f.itemToArticle(item, &source)
-		if err := f.newsRepo.InsertArticle(ctx, article); err != nil {
-			log.Warn().Err(err).Str("url", item.Link).Msg("failed to insert article")
-			continue
-		}
-		newCount++
+		res.Articles = append(res.Articles, f.itemToArticle(item, &source))
 	}
-
-	log.Debug().Str("source", source.Name).Int("new", newCount).Int("skipped", skipCount).Msg("fetched feed")
-	return newCount, skipCount
+	return res, nil
 }
 
 func (f *RSSFetcher) itemToArticle(item *gofeed.Item, source *models.NewsSource) *models.NewsArticle {
@@ -11,17 +11,23 @@ import (
 )
 
 type Scheduler struct {
-	fetcher  *RSSFetcher
-	newsRepo *repository.NewsRepo
-	interval time.Duration
+	aggregator *Aggregator
+	newsRepo   *repository.NewsRepo
+	storyRepo  *repository.StoryRepo
+	interval   time.Duration
 }
 
-func NewScheduler(newsRepo *repository.NewsRepo, cfg config.AggregationConfig) *Scheduler {
-	fetcher := NewRSSFetcher(newsRepo, cfg.UserAgent, cfg.RequestTimeout)
+func NewScheduler(newsRepo *repository.NewsRepo, storyRepo *repository.StoryRepo, cfg config.AggregationConfig) *Scheduler {
+	client := NewClient(cfg.UserAgent, cfg.RequestTimeout, cfg.HostConcurrency, cfg.HostDelay)
+	aggregator := NewAggregator(newsRepo, map[string]Fetcher{
+		"rss":     NewRSSFetcher(newsRepo, client),
+		"scraper": NewHTMLScraper(newsRepo, client),
+	}, NewExtractor(client), cfg)
 	return &Scheduler{
-		fetcher:  fetcher,
-		newsRepo: newsRepo,
-		interval: cfg.Interval,
+		aggregator: aggregator,
+		newsRepo:   newsRepo,
+		storyRepo:  storyRepo,
+		interval:   cfg.Interval,
 	}
 }
 
@@ -48,8 +54,9 @@ func (s *Scheduler) run(ctx context.Context) {
 	log.Debug().Msg("starting news scrape cycle")
 	start := time.Now()
 
-	s.fetcher.FetchAll(ctx)
+	s.aggregator.FetchAll(ctx)
 	LinkMentions(ctx, s.newsRepo)
+	ClusterStories(ctx, s.storyRepo)
 
 	log.Debug().Dur("duration", time.Since(start)).Msg("news scrape cycle complete")
 }
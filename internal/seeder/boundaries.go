@@ -0,0 +1,135 @@
+package seeder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+
+	"jalada/internal/geo"
+)
+
+// boundaryLevel describes one boundary file. Public boundary datasets name
+// the code property differently, so each level accepts a few spellings
+// after "code". Numeric codes are zero-padded to the width used in the
+// geography tables.
+type boundaryLevel struct {
+	table    string
+	file     string
+	codeKeys []string
+	width    int
+}
+
+var boundaryLevels = []boundaryLevel{
+	{table: "counties", file: "counties.geojson", codeKeys: []string{"code", "county_code", "COUNTY_COD", "COUNTY_CODE"}, width: 3},
+	{table: "constituencies", file: "constituencies.geojson", codeKeys: []string{"code", "constituency_code", "CONST_CODE", "CONSTITUEN"}, width: 3},
+	{table: "wards", file: "wards.geojson", codeKeys: []string{"code", "ward_code", "WARD_CODE"}, width: 4},
+}
+
+// SeedBoundaries loads boundary polygons from GeoJSON FeatureCollections in
+// dir (counties.geojson, constituencies.geojson and wards.geojson). Missing
+// files are skipped, as are levels where every row already has a boundary.
+// Features whose code matches no row are counted and logged.
+func SeedBoundaries(ctx context.Context, pool *pgxpool.Pool, dir string) error {
+	if dir == "" {
+		return nil
+	}
+	for _, level := range boundaryLevels {
+		if err := seedBoundaryLevel(ctx, pool, dir, level); err != nil {
+			return fmt.Errorf("seed %s boundaries: %w", level.table, err)
+		}
+	}
+	return nil
+}
+
+func seedBoundaryLevel(ctx context.Context, pool *pgxpool.Pool, dir string, level boundaryLevel) error {
+	var missing int
+	err := pool.QueryRow(ctx, "SELECT COUNT(*) FROM "+level.table+" WHERE boundary IS NULL").Scan(&missing)
+	if err != nil {
+		return err
+	}
+	if missing == 0 {
+		log.Debug().Str("table", level.table).Msg("boundaries already seeded")
+		return nil
+	}
+
+	path := filepath.Join(dir, level.file)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Debug().Str("file", path).Msg("no boundary file")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var fc geo.FeatureCollection
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return fmt.Errorf("parse %s: %w", level.file, err)
+	}
+
+	var loaded, unmatched, invalid int
+	for i, f := range fc.Features {
+		code := featureCode(f, level)
+		if code == "" {
+			invalid++
+			log.Warn().Str("file", level.file).Int("feature", i).Msg("boundary feature has no code")
+			continue
+		}
+		if _, err := geo.ParseGeometry(f.Geometry); err != nil {
+			invalid++
+			log.Warn().Err(err).Str("file", level.file).Str("code", code).Msg("invalid boundary geometry")
+			continue
+		}
+		tag, err := pool.Exec(ctx,
+			"UPDATE "+level.table+" SET boundary = $2 WHERE code = $1",
+			code, f.Geometry,
+		)
+		if err != nil {
+			return fmt.Errorf("update boundary %s: %w", code, err)
+		}
+		if tag.RowsAffected() == 0 {
+			unmatched++
+			continue
+		}
+		loaded++
+	}
+
+	log.Info().Str("table", level.table).Int("loaded", loaded).Int("unmatched", unmatched).
+		Int("invalid", invalid).Msg("seeded boundaries")
+	return nil
+}
+
+func featureCode(f geo.Feature, level boundaryLevel) string {
+	for _, key := range level.codeKeys {
+		v, ok := f.Properties[key]
+		if !ok || v == nil {
+			continue
+		}
+		var code string
+		switch v := v.(type) {
+		case string:
+			code = strings.TrimSpace(v)
+		case float64:
+			code = strconv.FormatInt(int64(v), 10)
+		default:
+			continue
+		}
+		if code == "" {
+			continue
+		}
+		if _, err := strconv.Atoi(code); err == nil && len(code) < level.width {
+			code = strings.Repeat("0", level.width-len(code)) + code
+		}
+		return code
+	}
+	return ""
+}
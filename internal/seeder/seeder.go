@@ -65,12 +65,27 @@ type politicianData struct {
 }
 
 type newsSourceData struct {
-	Name    string  `json:"name"`
-	URL     string  `json:"url"`
-	FeedURL *string `json:"feed_url"`
-	Type    string  `json:"type"`
-	Outlet  *string `json:"outlet"`
-	Active  bool    `json:"active"`
+	Name            string       `json:"name"`
+	URL             string       `json:"url"`
+	FeedURL         *string      `json:"feed_url"`
+	Type            string       `json:"type"`
+	Outlet          *string      `json:"outlet"`
+	Active          bool         `json:"active"`
+	ExtractFullText bool         `json:"extract_full_text"`
+	Scraper         *scraperData `json:"scraper"`
+}
+
+// scraperData holds the CSS selectors for a source of type scraper.
+type scraperData struct {
+	ListURL        string  `json:"list_url"`
+	LinkSelector   string  `json:"link_selector"`
+	TitleSelector  string  `json:"title_selector"`
+	BodySelector   string  `json:"body_selector"`
+	DateSelector   *string `json:"date_selector"`
+	DateAttribute  *string `json:"date_attribute"`
+	DateLayout     *string `json:"date_layout"`
+	AuthorSelector *string `json:"author_selector"`
+	MaxArticles    int     `json:"max_articles"`
 }
 
 func loadJSON[T any](filename string) ([]T, error) {
@@ -472,15 +487,31 @@ func seedNewsSources(ctx context.Context, pool *pgxpool.Pool) error {
 	}
 
 	for _, s := range sources {
-		_, err := pool.Exec(ctx,
-			`INSERT INTO news_sources (name, url, feed_url, type, outlet, active)
-			 VALUES ($1, $2, $3, $4, $5, $6)
-			 ON CONFLICT DO NOTHING`,
-			s.Name, s.URL, s.FeedURL, s.Type, s.Outlet, s.Active,
-		)
+		var id string
+		err := pool.QueryRow(ctx,
+			`INSERT INTO news_sources (name, url, feed_url, type, outlet, active, extract_full_text)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)
+			 RETURNING id`,
+			s.Name, s.URL, s.FeedURL, s.Type, s.Outlet, s.Active, s.ExtractFullText,
+		).Scan(&id)
 		if err != nil {
 			return fmt.Errorf("insert news source %s: %w", s.Name, err)
 		}
+		if c := s.Scraper; c != nil {
+			if c.MaxArticles == 0 {
+				c.MaxArticles = 20
+			}
+			_, err = pool.Exec(ctx,
+				`INSERT INTO news_source_scrapers (source_id, list_url, link_selector, title_selector, body_selector,
+				                                   date_selector, date_attribute, date_layout, author_selector, max_articles)
+				 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+				id, c.ListURL, c.LinkSelector, c.TitleSelector, c.BodySelector,
+				c.DateSelector, c.DateAttribute, c.DateLayout, c.AuthorSelector, c.MaxArticles,
+			)
+			if err != nil {
+				return fmt.Errorf("insert scraper config for %s: %w", s.Name, err)
+			}
+		}
 	}
 	log.Info().Int("count", len(sources)).Msg("seeded news sources")
 	return nil
@@ -9,10 +9,11 @@ import (
 type AnalyticsService struct {
 	analyticsRepo *repository.AnalyticsRepo
 	sentimentRepo *repository.SentimentRepo
+	electionRepo  *repository.ElectionRepo
 }
 
-func NewAnalyticsService(ar *repository.AnalyticsRepo, sr *repository.SentimentRepo) *AnalyticsService {
-	return &AnalyticsService{analyticsRepo: ar, sentimentRepo: sr}
+func NewAnalyticsService(ar *repository.AnalyticsRepo, sr *repository.SentimentRepo, er *repository.ElectionRepo) *AnalyticsService {
+	return &AnalyticsService{analyticsRepo: ar, sentimentRepo: sr, electionRepo: er}
 }
 
 func (s *AnalyticsService) GetPromiseAnalytics(ctx context.Context) (*repository.PromiseAnalytics, error) {
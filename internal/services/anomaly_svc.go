@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"jalada/internal/models"
+)
+
+var (
+	anomalyTypes      = []string{"aggregate_mismatch", "turnout_over_100", "conflicting_resubmission"}
+	anomalySeverities = []string{"low", "medium", "high", "critical"}
+)
+
+// Anomalies reconciles an election's result tallies and returns the
+// discrepancies found, most severe first.
+//
+// An aggregate form that reports more votes than its stations sum to is
+// only flagged once every station in the area has reported, since the
+// difference may be stations still to come.
+func (s *ElectionService) Anomalies(ctx context.Context, electionID uuid.UUID, f models.AnomalyFilter) ([]models.Anomaly, error) {
+	if err := firstError(
+		allOneOf("type", f.Types, anomalyTypes),
+		allOneOf("severity", f.Severities, anomalySeverities),
+	); err != nil {
+		return nil, err
+	}
+	if f.Title != "" {
+		if err := requireOneOf("position", f.Title, positionTitles); err != nil {
+			return nil, err
+		}
+	}
+
+	var found []models.Anomaly
+	checks := map[string]func(context.Context, uuid.UUID, string) ([]models.Anomaly, error){
+		"aggregate_mismatch":       s.anomalyRepo.AggregateMismatches,
+		"turnout_over_100":         s.anomalyRepo.ExcessTurnout,
+		"conflicting_resubmission": s.anomalyRepo.ConflictingResubmissions,
+	}
+	for _, t := range anomalyTypes {
+		if len(f.Types) > 0 && !contains(f.Types, t) {
+			continue
+		}
+		anomalies, err := checks[t](ctx, electionID, f.Title)
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, anomalies...)
+	}
+
+	out := make([]models.Anomaly, 0, len(found))
+	for _, a := range found {
+		if !assess(&a) {
+			continue
+		}
+		if len(f.Severities) > 0 && !contains(f.Severities, a.Severity) {
+			continue
+		}
+		out = append(out, a)
+	}
+
+	rank := make(map[string]int, len(anomalySeverities))
+	for i, sev := range anomalySeverities {
+		rank[sev] = i
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if rank[out[i].Severity] != rank[out[j].Severity] {
+			return rank[out[i].Severity] > rank[out[j].Severity]
+		}
+		return out[i].ReportedAt.After(out[j].ReportedAt)
+	})
+	return out, nil
+}
+
+// assess sets an anomaly's severity and message. It reports false for
+// findings that are explained by results still arriving.
+func assess(a *models.Anomaly) bool {
+	area := "national tally"
+	if a.Area != nil {
+		area = fmt.Sprintf("%s %s (%s)", a.Level, a.Area.Name, a.Area.Code)
+	}
+	reported, expected := deref(a.Reported), deref(a.Expected)
+
+	switch a.Type {
+	case "aggregate_mismatch":
+		complete := deref(a.StationsReported) >= deref(a.StationsExpected)
+		if !complete && reported > expected {
+			return false
+		}
+		a.Severity = bySize(relativeChange(reported, expected), 0.01, 0.001)
+		a.Message = fmt.Sprintf("%s for %s reports %d votes for %s, but its %d station tallies sum to %d",
+			area, a.Title, reported, candidateName(a), deref(a.StationsReported), expected)
+	case "turnout_over_100":
+		a.Severity = "high"
+		if a.Turnout == nil || *a.Turnout > 110 {
+			a.Severity = "critical"
+		}
+		a.Message = fmt.Sprintf("%s for %s records %d votes cast against %d registered voters",
+			area, a.Title, reported, expected)
+	case "conflicting_resubmission":
+		a.Severity = bySize(relativeChange(reported, expected), 0.05, 0.01)
+		if a.SupersededFinal {
+			a.Severity = "high"
+		}
+		a.Message = fmt.Sprintf("%s for %s was resubmitted with different results: %s went from %d to %d votes",
+			area, a.Title, candidateName(a), expected, reported)
+	default:
+		return false
+	}
+	return true
+}
+
+// bySize grades a relative difference as high, medium or low against two
+// thresholds.
+func bySize(change, high, medium float64) string {
+	switch {
+	case change >= high:
+		return "high"
+	case change >= medium:
+		return "medium"
+	}
+	return "low"
+}
+
+func relativeChange(a, b int) float64 {
+	base := math.Max(float64(a), float64(b))
+	if base == 0 {
+		return 0
+	}
+	return math.Abs(float64(a-b)) / base
+}
+
+func candidateName(a *models.Anomaly) string {
+	if a.Candidate != nil {
+		return *a.Candidate
+	}
+	return "a candidate"
+}
+
+func deref(n *int) int {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"jalada/internal/models"
+	"jalada/internal/repository"
+)
+
+const (
+	apiKeyPrefix    = "jal_"
+	apiKeyBytes     = 24
+	apiKeyPrefixLen = len(apiKeyPrefix) + 8
+)
+
+var apiKeyTiers = []string{"registered", "partner"}
+
+type APIKeyService struct {
+	apiKeyRepo *repository.APIKeyRepo
+}
+
+func NewAPIKeyService(ar *repository.APIKeyRepo) *APIKeyService {
+	return &APIKeyService{apiKeyRepo: ar}
+}
+
+// List returns every key with its usage so far on the current UTC day.
+func (s *APIKeyService) List(ctx context.Context) ([]models.APIKey, error) {
+	y, m, d := time.Now().UTC().Date()
+	return s.apiKeyRepo.List(ctx, time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+}
+
+// Issue generates a new key for k and stores its hash. The plaintext key is
+// only ever available in the returned value.
+func (s *APIKeyService) Issue(ctx context.Context, k *models.APIKey) (*models.IssuedAPIKey, error) {
+	if k.Tier == "" {
+		k.Tier = "registered"
+	}
+	if err := validateAPIKey(k); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	k.Prefix = key[:apiKeyPrefixLen]
+
+	if err := s.apiKeyRepo.Create(ctx, k, repository.HashAPIKey(key)); err != nil {
+		return nil, err
+	}
+	return &models.IssuedAPIKey{APIKey: *k, Key: key}, nil
+}
+
+func (s *APIKeyService) Revoke(ctx context.Context, id uuid.UUID) error {
+	return s.apiKeyRepo.Revoke(ctx, id)
+}
+
+func validateAPIKey(k *models.APIKey) error {
+	var emailErr error
+	if k.OwnerEmail != nil && !strings.Contains(*k.OwnerEmail, "@") {
+		emailErr = invalid("owner_email", "must be an email address")
+	}
+	var rateErr error
+	if k.RateLimit != nil && *k.RateLimit <= 0 {
+		rateErr = invalid("rate_limit", "must be greater than zero")
+	}
+	var burstErr error
+	if k.Burst != nil && *k.Burst <= 0 {
+		burstErr = invalid("burst", "must be greater than zero")
+	}
+	var quotaErr error
+	if k.DailyQuota != nil && *k.DailyQuota < 0 {
+		quotaErr = invalid("daily_quota", "must not be negative")
+	}
+
+	return firstError(
+		requireText("name", k.Name),
+		emailErr,
+		requireOneOf("tier", k.Tier, apiKeyTiers),
+		rateErr,
+		burstErr,
+		quotaErr,
+	)
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"jalada/internal/models"
+)
+
+// auditTables maps the dossier collections in the URL to the audited table.
+var auditTables = map[string]string{
+	"politician":        "politicians",
+	"party-memberships": "party_memberships",
+	"court-cases":       "court_cases",
+	"promises":          "promises",
+	"achievements":      "achievements",
+	"controversies":     "controversies",
+	"assets":            "asset_declarations",
+	"integrity-flags":   "integrity_flags",
+	"voting-record":     "voting_records",
+	"affiliations":      "affiliations",
+}
+
+// GetHistory returns the audit trail for a politician's whole dossier.
+// collection optionally narrows it to one dossier collection.
+func (s *PoliticianService) GetHistory(ctx context.Context, politicianID uuid.UUID, collection string, limit, offset int) ([]models.Revision, int, error) {
+	table := ""
+	if collection != "" {
+		var ok bool
+		if table, ok = auditTables[collection]; !ok {
+			names := make([]string, 0, len(auditTables))
+			for name := range auditTables {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, 0, invalid("collection", "must be one of %s", strings.Join(names, ", "))
+		}
+	}
+	return s.auditRepo.ListByPolitician(ctx, politicianID, table, limit, offset)
+}
+
+// GetRevisions returns every revision of one dossier record, oldest first.
+func (s *PoliticianService) GetRevisions(ctx context.Context, politicianID uuid.UUID, collection string, id uuid.UUID) ([]models.Revision, error) {
+	return s.auditRepo.ListByRecord(ctx, auditTables[collection], id, politicianID)
+}
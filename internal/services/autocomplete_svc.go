@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/rs/zerolog/log"
+
+	"jalada/internal/models"
+	"jalada/internal/repository"
+)
+
+// prefixEntry maps one indexed key to a suggestion. Every suggestion is
+// indexed under its full normalised label and under each later word, so
+// "kisumu e" and "east" both find "Kisumu East".
+type prefixEntry struct {
+	key string
+	idx int
+}
+
+type prefixIndex struct {
+	suggestions []models.Suggestion
+	trigrams    []map[string]struct{}
+	entries     []prefixEntry
+}
+
+// AutocompleteService answers typeahead queries from an in-process prefix
+// index that is rebuilt from Postgres on a timer. Queries the index cannot
+// answer fall back to a pg_trgm similarity query.
+type AutocompleteService struct {
+	repo     *repository.AutocompleteRepo
+	interval time.Duration
+
+	mu    sync.RWMutex
+	index *prefixIndex
+}
+
+func NewAutocompleteService(repo *repository.AutocompleteRepo, interval time.Duration) *AutocompleteService {
+	return &AutocompleteService{repo: repo, interval: interval}
+}
+
+func (s *AutocompleteService) Start(ctx context.Context) {
+	s.refresh(ctx)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refresh(ctx)
+		}
+	}
+}
+
+func (s *AutocompleteService) refresh(ctx context.Context) {
+	start := time.Now()
+	suggestions, err := s.repo.LoadSuggestions(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to refresh autocomplete index")
+		return
+	}
+
+	idx := buildPrefixIndex(suggestions)
+	s.mu.Lock()
+	s.index = idx
+	s.mu.Unlock()
+
+	log.Debug().Int("suggestions", len(suggestions)).Dur("duration", time.Since(start)).Msg("autocomplete index refreshed")
+}
+
+func buildPrefixIndex(suggestions []models.Suggestion) *prefixIndex {
+	idx := &prefixIndex{
+		suggestions: suggestions,
+		trigrams:    make([]map[string]struct{}, len(suggestions)),
+	}
+	for i, sug := range suggestions {
+		label := normalizeQuery(sug.Label)
+		idx.trigrams[i] = trigrams(label)
+		words := strings.Fields(label)
+		for w := range words {
+			idx.entries = append(idx.entries, prefixEntry{key: strings.Join(words[w:], " "), idx: i})
+		}
+	}
+	sort.Slice(idx.entries, func(i, j int) bool { return idx.entries[i].key < idx.entries[j].key })
+	return idx
+}
+
+// Suggest returns up to limit suggestions of the given types for q, ranked
+// by similarity to the label and then by popularity.
+func (s *AutocompleteService) Suggest(ctx context.Context, q string, types []string, limit int) ([]models.Suggestion, error) {
+	if len(types) == 0 {
+		types = repository.AutocompleteTypes
+	}
+	norm := normalizeQuery(q)
+	if norm == "" {
+		return nil, nil
+	}
+
+	s.mu.RLock()
+	idx := s.index
+	s.mu.RUnlock()
+
+	if idx != nil {
+		if matches := idx.search(norm, types, limit); len(matches) > 0 {
+			return matches, nil
+		}
+	}
+	return s.repo.FuzzySuggest(ctx, norm, types, limit)
+}
+
+func (idx *prefixIndex) search(q string, types []string, limit int) []models.Suggestion {
+	allowed := make(map[string]bool, len(types))
+	for _, t := range types {
+		allowed[t] = true
+	}
+
+	start := sort.Search(len(idx.entries), func(i int) bool { return idx.entries[i].key >= q })
+	seen := make(map[int]bool)
+	var matches []models.Suggestion
+	qgrams := trigrams(q)
+	for i := start; i < len(idx.entries) && strings.HasPrefix(idx.entries[i].key, q); i++ {
+		e := idx.entries[i]
+		sug := idx.suggestions[e.idx]
+		if seen[e.idx] || !allowed[sug.Type] {
+			continue
+		}
+		seen[e.idx] = true
+		sug.Score = similarity(qgrams, idx.trigrams[e.idx])
+		matches = append(matches, sug)
+	}
+
+	// Similarity is compared at one decimal place so that popularity
+	// decides between labels of roughly the same length.
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := math.Round(matches[i].Score*10), math.Round(matches[j].Score*10)
+		if a != b {
+			return a > b
+		}
+		if matches[i].Popularity != matches[j].Popularity {
+			return matches[i].Popularity > matches[j].Popularity
+		}
+		return matches[i].Label < matches[j].Label
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// normalizeQuery lowercases s and collapses everything but letters and
+// digits to single spaces.
+func normalizeQuery(s string) string {
+	var b strings.Builder
+	space := true
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			space = false
+		} else if !space {
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// trigrams mirrors pg_trgm: each word is padded with two leading spaces and
+// one trailing space before being split into three-character grams.
+func trigrams(s string) map[string]struct{} {
+	grams := make(map[string]struct{})
+	for _, w := range strings.Fields(s) {
+		r := []rune("  " + w + " ")
+		for i := 0; i+3 <= len(r); i++ {
+			grams[string(r[i:i+3])] = struct{}{}
+		}
+	}
+	return grams
+}
+
+func similarity(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for g := range a {
+		if _, ok := b[g]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
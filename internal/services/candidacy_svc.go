@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"jalada/internal/audit"
+	"jalada/internal/models"
+	"jalada/internal/repository"
+)
+
+// candidacyTransitions lists the statuses a candidacy may move to from
+// each status. Disqualified candidates can be reinstated on appeal, and
+// petitions can overturn a declared result.
+var candidacyTransitions = map[string][]string{
+	"declared":     {"cleared", "disqualified", "withdrew"},
+	"cleared":      {"disqualified", "withdrew", "elected", "lost"},
+	"disqualified": {"cleared"},
+	"withdrew":     {},
+	"elected":      {"lost", "disqualified"},
+	"lost":         {"elected"},
+}
+
+// stageStatuses lists the statuses each stage can lead to. A dispute, at
+// the IEBC tribunal or in court, can lead anywhere.
+var stageStatuses = map[string][]string{
+	"party_primary":  {"disqualified", "withdrew"},
+	"iebc_clearance": {"cleared", "disqualified"},
+	"withdrawal":     {"withdrew"},
+	"result":         {"elected", "lost"},
+	"dispute":        candidacyStatuses,
+}
+
+// GetCandidates returns an election's candidacies, each with the reason
+// for its current status.
+func (s *ElectionService) GetCandidates(ctx context.Context, electionID uuid.UUID, f models.CandidateFilter) ([]models.CandidacyDetail, error) {
+	err := allOneOf("status", f.Statuses, candidacyStatuses)
+	if err == nil && f.Title != "" {
+		err = requireOneOf("position", f.Title, positionTitles)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.candidacyRepo.List(ctx, electionID, f)
+}
+
+// CreateCandidacy records a declared candidacy. Later statuses are reached
+// through RecordCandidacyEvent so that each change keeps its reason.
+func (s *ElectionService) CreateCandidacy(ctx context.Context, c *models.Candidacy) error {
+	if c.Status == "" {
+		c.Status = "declared"
+	}
+	err := firstError(
+		requireID("politician_id", c.PoliticianID),
+		requireID("position_id", c.PositionID),
+	)
+	if err == nil && c.Status != "declared" {
+		err = invalid("status", "must be declared; record later changes as events")
+	}
+	if err != nil {
+		return err
+	}
+
+	election, err := s.electionRepo.GetByID(ctx, c.ElectionID)
+	if err != nil {
+		return err
+	}
+	if election == nil {
+		return repository.ErrNotFound
+	}
+
+	ev := &models.CandidacyEvent{Stage: "declaration", Status: "declared", OccurredOn: today()}
+	if c.DeclarationDate != nil {
+		ev.OccurredOn = *c.DeclarationDate
+	}
+	attribute(ctx, ev)
+	return s.candidacyRepo.Create(ctx, c, ev)
+}
+
+// RecordCandidacyEvent moves a candidacy to a new status. The stage is
+// inferred from the change when it is not given. A principal's result is
+// recorded for their running mate too.
+func (s *ElectionService) RecordCandidacyEvent(ctx context.Context, electionID uuid.UUID, ev *models.CandidacyEvent) error {
+	if err := requireOneOf("status", ev.Status, candidacyStatuses); err != nil {
+		return err
+	}
+
+	c, err := s.candidacyRepo.Get(ctx, electionID, ev.CandidacyID)
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		return repository.ErrNotFound
+	}
+	if !contains(candidacyTransitions[c.Status], ev.Status) {
+		return invalid("status", "a %s candidacy cannot become %s", c.Status, ev.Status)
+	}
+	if ev.Stage == "" {
+		ev.Stage = inferStage(c.Status, ev.Status)
+	}
+	if err := validateCandidacyEvent(ev); err != nil {
+		return err
+	}
+
+	from := c.Status
+	ev.FromStatus = &from
+	attribute(ctx, ev)
+
+	// A ticket is elected or loses as one, so the running mate follows
+	// the principal's result.
+	evs := []*models.CandidacyEvent{ev}
+	mate := c.RunningMate
+	if mate != nil && mate.Role == "deputy" && (ev.Status == "elected" || ev.Status == "lost") && mate.Status != ev.Status {
+		if !contains(candidacyTransitions[mate.Status], ev.Status) {
+			return invalid("status", "running mate %s is %s and cannot become %s", mate.Name, mate.Status, ev.Status)
+		}
+		dev := *ev
+		dev.CandidacyID = mate.CandidacyID
+		dev.FromStatus = &mate.Status
+		evs = append(evs, &dev)
+	}
+	return s.candidacyRepo.RecordEvent(ctx, electionID, evs...)
+}
+
+func validateCandidacyEvent(ev *models.CandidacyEvent) error {
+	if ev.OccurredOn.IsZero() {
+		ev.OccurredOn = today()
+	}
+	if err := requireOneOf("stage", ev.Stage, candidacyStages); err != nil {
+		return err
+	}
+	if !contains(stageStatuses[ev.Stage], ev.Status) {
+		return invalid("stage", "%s does not lead to %s", ev.Stage, ev.Status)
+	}
+	if ev.Status == "disqualified" && (ev.Reason == nil || *ev.Reason == "") {
+		return invalid("reason", "is required when a candidacy is disqualified")
+	}
+	if ev.OccurredOn.After(time.Now()) {
+		return invalid("occurred_on", "must not be in the future")
+	}
+	return nil
+}
+
+// inferStage picks the stage for a change of status: moves out of a
+// disqualification or a declared result go through a dispute.
+func inferStage(from, to string) string {
+	switch {
+	case from == "disqualified" || from == "elected" || from == "lost":
+		return "dispute"
+	case to == "withdrew":
+		return "withdrawal"
+	case to == "elected" || to == "lost":
+		return "result"
+	default:
+		return "iebc_clearance"
+	}
+}
+
+// attribute records who made a change and the source behind it.
+func attribute(ctx context.Context, ev *models.CandidacyEvent) {
+	ev.RecordedBy = audit.Actor(ctx)
+	ev.SourceID = nil
+	if id, ok := audit.Source(ctx); ok {
+		ev.SourceID = &id
+	}
+}
+
+// CandidacyTimeline returns a candidacy with its party primaries and
+// status history, or nil if it is not part of the election.
+func (s *ElectionService) CandidacyTimeline(ctx context.Context, electionID, id uuid.UUID) (*models.CandidacyTimeline, error) {
+	c, err := s.candidacyRepo.Get(ctx, electionID, id)
+	if err != nil || c == nil {
+		return nil, err
+	}
+	primaries, err := s.candidacyRepo.PrimariesFor(ctx, electionID, c.PositionID, c.PoliticianID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.candidacyRepo.Events(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &models.CandidacyTimeline{Candidacy: *c, Primaries: primaries, Events: events}
+	if t.Primaries == nil {
+		t.Primaries = []models.PartyPrimary{}
+	}
+	if t.Events == nil {
+		t.Events = []models.CandidacyEvent{}
+	}
+	return t, nil
+}
+
+func (s *ElectionService) ListPrimaries(ctx context.Context, electionID uuid.UUID, f models.PrimaryFilter) ([]models.PartyPrimary, error) {
+	err := allOneOf("outcome", f.Outcomes, primaryOutcomes)
+	if err == nil && f.Title != "" {
+		err = requireOneOf("position", f.Title, positionTitles)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.candidacyRepo.ListPrimaries(ctx, electionID, f)
+}
+
+func (s *ElectionService) GetPrimary(ctx context.Context, electionID, id uuid.UUID) (*models.PartyPrimary, error) {
+	return s.candidacyRepo.GetPrimary(ctx, electionID, id)
+}
+
+func (s *ElectionService) CreatePrimary(ctx context.Context, pr *models.PartyPrimary) error {
+	if err := validatePrimary(pr); err != nil {
+		return err
+	}
+	election, err := s.electionRepo.GetByID(ctx, pr.ElectionID)
+	if err != nil {
+		return err
+	}
+	if election == nil {
+		return repository.ErrNotFound
+	}
+	return s.candidacyRepo.CreatePrimary(ctx, pr)
+}
+
+func (s *ElectionService) UpdatePrimary(ctx context.Context, pr *models.PartyPrimary) error {
+	if err := validatePrimary(pr); err != nil {
+		return err
+	}
+	return s.candidacyRepo.UpdatePrimary(ctx, pr)
+}
+
+func validatePrimary(pr *models.PartyPrimary) error {
+	if pr.Method == "" {
+		pr.Method = "vote"
+	}
+	var votesErr error
+	switch {
+	case pr.Votes != nil && *pr.Votes < 0:
+		votesErr = invalid("votes", "must not be negative")
+	case pr.Votes != nil && pr.Method != "vote":
+		votesErr = invalid("votes", "only apply to primaries decided by vote")
+	}
+	return firstError(
+		requireID("position_id", pr.PositionID),
+		requireID("party_id", pr.PartyID),
+		requireID("politician_id", pr.PoliticianID),
+		requireOneOf("method", pr.Method, primaryMethods),
+		requireOneOf("outcome", pr.Outcome, primaryOutcomes),
+		votesErr,
+	)
+}
+
+func today() time.Time {
+	y, m, d := time.Now().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
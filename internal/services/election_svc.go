@@ -10,11 +10,14 @@ import (
 )
 
 type ElectionService struct {
-	electionRepo *repository.ElectionRepo
+	electionRepo  *repository.ElectionRepo
+	resultsRepo   *repository.ResultsRepo
+	anomalyRepo   *repository.AnomalyRepo
+	candidacyRepo *repository.CandidacyRepo
 }
 
-func NewElectionService(er *repository.ElectionRepo) *ElectionService {
-	return &ElectionService{electionRepo: er}
+func NewElectionService(er *repository.ElectionRepo, rr *repository.ResultsRepo, ar *repository.AnomalyRepo, cr *repository.CandidacyRepo) *ElectionService {
+	return &ElectionService{electionRepo: er, resultsRepo: rr, anomalyRepo: ar, candidacyRepo: cr}
 }
 
 func (s *ElectionService) List(ctx context.Context) ([]models.Election, error) {
@@ -25,12 +28,19 @@ func (s *ElectionService) GetByID(ctx context.Context, id uuid.UUID) (*models.El
 	return s.electionRepo.GetByID(ctx, id)
 }
 
-func (s *ElectionService) GetCandidates(ctx context.Context, electionID uuid.UUID) ([]models.CandidacyDetail, error) {
-	return s.electionRepo.GetCandidates(ctx, electionID)
-}
-
 func (s *ElectionService) GetResults(ctx context.Context, electionID uuid.UUID) ([]models.ResultSummary, error) {
-	return s.electionRepo.GetResults(ctx, electionID)
+	results, err := s.electionRepo.GetResults(ctx, electionID)
+	if err != nil || len(results) == 0 {
+		return results, err
+	}
+	mates, err := s.candidacyRepo.RunningMates(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		results[i].RunningMate = mates[results[i].CandidacyID]
+	}
+	return results, nil
 }
 
 func (s *ElectionService) GetTimeline(ctx context.Context, electionID uuid.UUID) ([]models.TimelineEvent, error) {
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	"jalada/internal/geo"
+	"jalada/internal/models"
+	"jalada/internal/repository"
+)
+
+const maxNearbyRadiusKM = 50
+
+// GeoService resolves coordinates to wards, constituencies and counties
+// against boundaries held in memory. Boundaries only change when the
+// seeder runs, so they are loaded once at startup.
+type GeoService struct {
+	repo *repository.GeographyRepo
+
+	mu       sync.RWMutex
+	resolver *geo.Resolver
+}
+
+func NewGeoService(repo *repository.GeographyRepo) *GeoService {
+	return &GeoService{repo: repo, resolver: geo.NewResolver(nil, nil, nil)}
+}
+
+// Load reads every stored boundary and swaps in a new resolver.
+func (s *GeoService) Load(ctx context.Context) error {
+	counties, err := s.repo.LoadBoundaries(ctx, geo.LevelCounty)
+	if err != nil {
+		return err
+	}
+	constituencies, err := s.repo.LoadBoundaries(ctx, geo.LevelConstituency)
+	if err != nil {
+		return err
+	}
+	wards, err := s.repo.LoadBoundaries(ctx, geo.LevelWard)
+	if err != nil {
+		return err
+	}
+
+	resolver := geo.NewResolver(counties, constituencies, wards)
+	s.mu.Lock()
+	s.resolver = resolver
+	s.mu.Unlock()
+
+	log.Info().Int("counties", len(counties)).Int("constituencies", len(constituencies)).
+		Int("wards", len(wards)).Msg("loaded boundaries")
+	return nil
+}
+
+// Locate returns the areas containing a coordinate.
+func (s *GeoService) Locate(lat, lng float64) geo.Match {
+	s.mu.RLock()
+	resolver := s.resolver
+	s.mu.RUnlock()
+	return resolver.Locate(geo.Point{Lat: lat, Lng: lng})
+}
+
+// Resolve validates a coordinate and returns the areas containing it.
+func (s *GeoService) Resolve(lat, lng *float64) (*models.BoundaryMatch, error) {
+	if err := requireCoordinates(lat, lng); err != nil {
+		return nil, err
+	}
+	m := s.Locate(*lat, *lng)
+	return &models.BoundaryMatch{
+		Lat:          *lat,
+		Lng:          *lng,
+		Ward:         areaRef(m.Ward),
+		Constituency: areaRef(m.Constituency),
+		County:       areaRef(m.County),
+	}, nil
+}
+
+func areaRef(a *geo.Area) *models.AreaRef {
+	if a == nil {
+		return nil
+	}
+	return &models.AreaRef{ID: a.ID, Code: a.Code, Name: a.Name}
+}
+
+// NearbyPollingStations validates a proximity query and returns the
+// stations within radiusKM of the coordinate, nearest first.
+func (s *GeoService) NearbyPollingStations(ctx context.Context, lat, lng *float64, radiusKM float64, limit int) ([]models.NearbyPollingStation, error) {
+	if err := requireCoordinates(lat, lng); err != nil {
+		return nil, err
+	}
+	if radiusKM <= 0 || radiusKM > maxNearbyRadiusKM {
+		return nil, invalid("radius_km", "must be greater than 0 and at most %d", maxNearbyRadiusKM)
+	}
+	return s.repo.NearbyPollingStations(ctx, *lat, *lng, radiusKM, limit)
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/google/uuid"
+
+	"jalada/internal/models"
+	"jalada/internal/repository"
+)
+
+const (
+	defaultScraperMaxArticles = 20
+	defaultStatusWindow       = 24
+	defaultFetchLimit         = 50
+)
+
+type NewsSourceService struct {
+	newsRepo *repository.NewsRepo
+}
+
+func NewNewsSourceService(nr *repository.NewsRepo) *NewsSourceService {
+	return &NewsSourceService{newsRepo: nr}
+}
+
+func (s *NewsSourceService) List(ctx context.Context) ([]models.NewsSource, error) {
+	return s.newsRepo.ListSources(ctx)
+}
+
+func (s *NewsSourceService) Get(ctx context.Context, id uuid.UUID) (*models.NewsSource, error) {
+	return s.newsRepo.GetSource(ctx, id)
+}
+
+// Update switches a source on or off and toggles full-text extraction.
+func (s *NewsSourceService) Update(ctx context.Context, id uuid.UUID, u models.NewsSourceUpdate) error {
+	if u.Active == nil && u.ExtractFullText == nil {
+		return invalid("body", "set active or extract_full_text")
+	}
+	return s.newsRepo.UpdateSource(ctx, id, u)
+}
+
+// Fetches returns a source's most recent fetch attempts, or nil if the
+// source does not exist.
+func (s *NewsSourceService) Fetches(ctx context.Context, id uuid.UUID, limit int) ([]models.FetchAttempt, error) {
+	if limit == 0 {
+		limit = defaultFetchLimit
+	}
+	if limit < 1 || limit > 200 {
+		return nil, invalid("limit", "must be between 1 and 200")
+	}
+	source, err := s.newsRepo.GetSource(ctx, id)
+	if err != nil || source == nil {
+		return nil, err
+	}
+	attempts, err := s.newsRepo.ListFetches(ctx, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	if attempts == nil {
+		attempts = []models.FetchAttempt{}
+	}
+	return attempts, nil
+}
+
+// Status reports the health of every news source over the last
+// windowHours. A source that fetches fine but has had nothing new within
+// the window is stale rather than broken, so a quiet news day is not
+// mistaken for a dead feed.
+func (s *NewsSourceService) Status(ctx context.Context, windowHours int) (*models.NewsSourceStatusReport, error) {
+	if windowHours == 0 {
+		windowHours = defaultStatusWindow
+	}
+	if windowHours < 1 || windowHours > 720 {
+		return nil, invalid("window", "must be between 1 and 720 hours")
+	}
+
+	now := time.Now()
+	window := time.Duration(windowHours) * time.Hour
+	statuses, err := s.newsRepo.SourceStatuses(ctx, now.Add(-window))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.NewsSourceStatusReport{
+		GeneratedAt: now,
+		WindowHours: windowHours,
+		Summary:     map[string]int{"healthy": 0, "stale": 0, "broken": 0, "inactive": 0},
+		Sources:     []models.NewsSourceStatus{},
+	}
+	for _, st := range statuses {
+		st.Status, st.Reason = sourceHealth(st, now, window)
+		report.Summary[st.Status]++
+		report.Sources = append(report.Sources, st)
+	}
+	return report, nil
+}
+
+// sourceHealth classifies a source and says why.
+func sourceHealth(st models.NewsSourceStatus, now time.Time, window time.Duration) (status, reason string) {
+	lastError := ""
+	if st.LastError != nil {
+		lastError = ": " + *st.LastError
+	}
+	switch {
+	case !st.Active && st.DeactivatedAt != nil:
+		return "broken", fmt.Sprintf("switched off after %d failures in a row%s", st.ConsecutiveFailures, lastError)
+	case !st.Active:
+		return "inactive", ""
+	case st.ConsecutiveFailures > 0:
+		return "broken", fmt.Sprintf("%d failures in a row%s", st.ConsecutiveFailures, lastError)
+	case st.LastSuccessAt == nil:
+		return "stale", "not fetched yet"
+	case now.Sub(*st.LastSuccessAt) > window:
+		return "stale", "not fetched within the window"
+	case st.LastArticleAt == nil:
+		return "stale", "no articles yet"
+	case now.Sub(*st.LastArticleAt) > window:
+		return "stale", "no new articles within the window"
+	}
+	return "healthy", ""
+}
+
+// SetScraperConfig checks a source's selectors and saves them, switching
+// the source to the HTML scraper.
+func (s *NewsSourceService) SetScraperConfig(ctx context.Context, c *models.ScraperConfig) error {
+	if err := validateScraperConfig(c); err != nil {
+		return err
+	}
+	return s.newsRepo.SetScraperConfig(ctx, c)
+}
+
+func validateScraperConfig(c *models.ScraperConfig) error {
+	if c.MaxArticles == 0 {
+		c.MaxArticles = defaultScraperMaxArticles
+	}
+	if u, err := url.Parse(c.ListURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return invalid("list_url", "must be an absolute http or https URL")
+	}
+
+	selectors := []struct {
+		field    string
+		selector *string
+		required bool
+	}{
+		{"link_selector", &c.LinkSelector, true},
+		{"title_selector", &c.TitleSelector, true},
+		{"body_selector", &c.BodySelector, true},
+		{"date_selector", c.DateSelector, false},
+		{"author_selector", c.AuthorSelector, false},
+	}
+	for _, sel := range selectors {
+		if sel.selector == nil || *sel.selector == "" {
+			if sel.required {
+				return invalid(sel.field, "is required")
+			}
+			continue
+		}
+		if _, err := cascadia.Compile(*sel.selector); err != nil {
+			return invalid(sel.field, "is not a valid CSS selector: %v", err)
+		}
+	}
+
+	switch {
+	case c.DateSelector == nil && (c.DateAttribute != nil || c.DateLayout != nil):
+		return invalid("date_selector", "is required with date_attribute or date_layout")
+	case c.MaxArticles < 1 || c.MaxArticles > 100:
+		return invalid("max_articles", "must be between 1 and 100")
+	}
+	if c.DateLayout != nil {
+		ref := time.Date(2022, 8, 9, 18, 30, 0, 0, time.UTC)
+		formatted := ref.Format(*c.DateLayout)
+		if _, err := time.Parse(*c.DateLayout, formatted); err != nil || formatted == *c.DateLayout {
+			return invalid("date_layout", "is not a Go time layout such as \"January 2, 2006\"")
+		}
+	}
+	return nil
+}
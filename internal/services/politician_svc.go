@@ -15,6 +15,7 @@ type PoliticianService struct {
 	newsRepo       *repository.NewsRepo
 	sentimentRepo  *repository.SentimentRepo
 	eventRepo      *repository.EventRepo
+	auditRepo      *repository.AuditRepo
 }
 
 func NewPoliticianService(
@@ -22,19 +23,46 @@ func NewPoliticianService(
 	nr *repository.NewsRepo,
 	sr *repository.SentimentRepo,
 	er *repository.EventRepo,
+	ar *repository.AuditRepo,
 ) *PoliticianService {
 	return &PoliticianService{
 		politicianRepo: pr,
 		newsRepo:       nr,
 		sentimentRepo:  sr,
 		eventRepo:      er,
+		auditRepo:      ar,
 	}
 }
 
 func (s *PoliticianService) List(ctx context.Context, f models.PoliticianFilter) ([]models.PoliticianSummary, int, error) {
+	if err := validatePoliticianFilter(f); err != nil {
+		return nil, 0, err
+	}
 	return s.politicianRepo.List(ctx, f)
 }
 
+func validatePoliticianFilter(f models.PoliticianFilter) error {
+	var sortErr error
+	if _, ok := repository.PoliticianSorts[f.Sort]; f.Sort != "" && !ok {
+		sortErr = invalid("sort", "must be one of name, -name, age, -age, recent, mentions")
+	}
+	var ageErr error
+	switch {
+	case f.MinAge != nil && *f.MinAge < 0, f.MaxAge != nil && *f.MaxAge < 0:
+		ageErr = invalid("min_age", "ages must not be negative")
+	case f.MinAge != nil && f.MaxAge != nil && *f.MaxAge < *f.MinAge:
+		ageErr = invalid("max_age", "must not be less than min_age")
+	}
+
+	return firstError(
+		allOneOf("position", f.Positions, positionTitles),
+		allOneOf("gender", f.Genders, politicianGenders),
+		allOneOf("status", f.Statuses, politicianStatuses),
+		sortErr,
+		ageErr,
+	)
+}
+
 func (s *PoliticianService) GetDossier(ctx context.Context, slug string) (*models.PoliticianDossier, error) {
 	p, err := s.politicianRepo.GetBySlug(ctx, slug)
 	if err != nil {
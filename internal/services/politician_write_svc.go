@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"jalada/internal/models"
+	"jalada/internal/slug"
+)
+
+func (s *PoliticianService) CreatePolitician(ctx context.Context, p *models.Politician) error {
+	if p.Slug == "" {
+		p.Slug = slug.Make(p.FirstName, p.LastName)
+	}
+	if err := validatePolitician(p); err != nil {
+		return err
+	}
+	return s.politicianRepo.CreatePolitician(ctx, p)
+}
+
+func (s *PoliticianService) UpdatePolitician(ctx context.Context, p *models.Politician) error {
+	if err := validatePolitician(p); err != nil {
+		return err
+	}
+	return s.politicianRepo.UpdatePolitician(ctx, p)
+}
+
+func (s *PoliticianService) DeletePolitician(ctx context.Context, id uuid.UUID) error {
+	return s.politicianRepo.DeletePolitician(ctx, id)
+}
+
+func validatePolitician(p *models.Politician) error {
+	if p.Status == "" {
+		p.Status = "active"
+	}
+	if len(p.Education) == 0 || string(p.Education) == "null" {
+		p.Education = json.RawMessage("[]")
+	}
+	if len(p.CareerHistory) == 0 || string(p.CareerHistory) == "null" {
+		p.CareerHistory = json.RawMessage("[]")
+	}
+
+	var slugErr error
+	if !slugPattern.MatchString(p.Slug) {
+		slugErr = invalid("slug", "must be lowercase letters, digits and hyphens")
+	}
+	var deathErr error
+	if p.Status == "deceased" && p.DateOfDeath == nil {
+		deathErr = invalid("date_of_death", "is required when status is deceased")
+	}
+
+	return firstError(
+		requireText("first_name", p.FirstName),
+		requireText("last_name", p.LastName),
+		slugErr,
+		optionalOneOf("gender", p.Gender, politicianGenders),
+		requireOneOf("status", p.Status, politicianStatuses),
+		requireOrder("date_of_death", p.DateOfBirth, p.DateOfDeath),
+		deathErr,
+		requireJSONArray("education", p.Education),
+		requireJSONArray("career_history", p.CareerHistory),
+	)
+}
+
+func requireJSONArray(field string, raw json.RawMessage) error {
+	var v []json.RawMessage
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return invalid(field, "must be a JSON array")
+	}
+	return nil
+}
+
+func (s *PoliticianService) GetPartyMembership(ctx context.Context, politicianID, id uuid.UUID) (*models.PartyMembership, error) {
+	return s.politicianRepo.GetPartyMembership(ctx, politicianID, id)
+}
+
+func (s *PoliticianService) CreatePartyMembership(ctx context.Context, m *models.PartyMembership) error {
+	if err := validatePartyMembership(m); err != nil {
+		return err
+	}
+	return s.politicianRepo.CreatePartyMembership(ctx, m)
+}
+
+func (s *PoliticianService) UpdatePartyMembership(ctx context.Context, m *models.PartyMembership) error {
+	if err := validatePartyMembership(m); err != nil {
+		return err
+	}
+	return s.politicianRepo.UpdatePartyMembership(ctx, m)
+}
+
+func (s *PoliticianService) DeletePartyMembership(ctx context.Context, politicianID, id uuid.UUID) error {
+	return s.politicianRepo.DeletePartyMembership(ctx, politicianID, id)
+}
+
+func validatePartyMembership(m *models.PartyMembership) error {
+	if m.PartyID == uuid.Nil {
+		return invalid("party_id", "is required")
+	}
+	return requireOrder("left_date", m.JoinedDate, m.LeftDate)
+}
+
+func (s *PoliticianService) GetCourtCase(ctx context.Context, politicianID, id uuid.UUID) (*models.CourtCase, error) {
+	return s.politicianRepo.GetCourtCase(ctx, politicianID, id)
+}
+
+func (s *PoliticianService) CreateCourtCase(ctx context.Context, c *models.CourtCase) error {
+	if err := validateCourtCase(c); err != nil {
+		return err
+	}
+	return s.politicianRepo.CreateCourtCase(ctx, c)
+}
+
+func (s *PoliticianService) UpdateCourtCase(ctx context.Context, c *models.CourtCase) error {
+	if err := validateCourtCase(c); err != nil {
+		return err
+	}
+	return s.politicianRepo.UpdateCourtCase(ctx, c)
+}
+
+func (s *PoliticianService) DeleteCourtCase(ctx context.Context, politicianID, id uuid.UUID) error {
+	return s.politicianRepo.DeleteCourtCase(ctx, politicianID, id)
+}
+
+func validateCourtCase(c *models.CourtCase) error {
+	if c.Status == "" {
+		c.Status = "pending"
+	}
+	return firstError(
+		requireText("title", c.Title),
+		requireOneOf("case_type", c.CaseType, courtCaseTypes),
+		requireOneOf("status", c.Status, courtCaseStatuses),
+	)
+}
+
+func (s *PoliticianService) GetPromise(ctx context.Context, politicianID, id uuid.UUID) (*models.Promise, error) {
+	return s.politicianRepo.GetPromise(ctx, politicianID, id)
+}
+
+func (s *PoliticianService) CreatePromise(ctx context.Context, p *models.Promise) error {
+	if err := validatePromise(p); err != nil {
+		return err
+	}
+	return s.politicianRepo.CreatePromise(ctx, p)
+}
+
+func (s *PoliticianService) UpdatePromise(ctx context.Context, p *models.Promise) error {
+	if err := validatePromise(p); err != nil {
+		return err
+	}
+	return s.politicianRepo.UpdatePromise(ctx, p)
+}
+
+func (s *PoliticianService) DeletePromise(ctx context.Context, politicianID, id uuid.UUID) error {
+	return s.politicianRepo.DeletePromise(ctx, politicianID, id)
+}
+
+func validatePromise(p *models.Promise) error {
+	if p.Status == "" {
+		p.Status = "pending"
+	}
+	return firstError(
+		requireText("description", p.Description),
+		requireOneOf("status", p.Status, promiseStatuses),
+		requireOrder("deadline", p.MadeDate, p.Deadline),
+	)
+}
+
+func (s *PoliticianService) GetAchievement(ctx context.Context, politicianID, id uuid.UUID) (*models.Achievement, error) {
+	return s.politicianRepo.GetAchievement(ctx, politicianID, id)
+}
+
+func (s *PoliticianService) CreateAchievement(ctx context.Context, a *models.Achievement) error {
+	if err := requireText("title", a.Title); err != nil {
+		return err
+	}
+	return s.politicianRepo.CreateAchievement(ctx, a)
+}
+
+func (s *PoliticianService) UpdateAchievement(ctx context.Context, a *models.Achievement) error {
+	if err := requireText("title", a.Title); err != nil {
+		return err
+	}
+	return s.politicianRepo.UpdateAchievement(ctx, a)
+}
+
+func (s *PoliticianService) DeleteAchievement(ctx context.Context, politicianID, id uuid.UUID) error {
+	return s.politicianRepo.DeleteAchievement(ctx, politicianID, id)
+}
+
+func (s *PoliticianService) GetControversy(ctx context.Context, politicianID, id uuid.UUID) (*models.Controversy, error) {
+	return s.politicianRepo.GetControversy(ctx, politicianID, id)
+}
+
+func (s *PoliticianService) CreateControversy(ctx context.Context, c *models.Controversy) error {
+	if err := validateControversy(c); err != nil {
+		return err
+	}
+	return s.politicianRepo.CreateControversy(ctx, c)
+}
+
+func (s *PoliticianService) UpdateControversy(ctx context.Context, c *models.Controversy) error {
+	if err := validateControversy(c); err != nil {
+		return err
+	}
+	return s.politicianRepo.UpdateControversy(ctx, c)
+}
+
+func (s *PoliticianService) DeleteControversy(ctx context.Context, politicianID, id uuid.UUID) error {
+	return s.politicianRepo.DeleteControversy(ctx, politicianID, id)
+}
+
+func validateControversy(c *models.Controversy) error {
+	if c.Severity == "" {
+		c.Severity = "medium"
+	}
+	return firstError(
+		requireText("title", c.Title),
+		requireOneOf("severity", c.Severity, controversySeverity),
+	)
+}
+
+func (s *PoliticianService) GetAssetDeclaration(ctx context.Context, politicianID, id uuid.UUID) (*models.AssetDeclaration, error) {
+	return s.politicianRepo.GetAssetDeclaration(ctx, politicianID, id)
+}
+
+func (s *PoliticianService) CreateAssetDeclaration(ctx context.Context, d *models.AssetDeclaration) error {
+	if err := validateAssetDeclaration(d); err != nil {
+		return err
+	}
+	return s.politicianRepo.CreateAssetDeclaration(ctx, d)
+}
+
+func (s *PoliticianService) UpdateAssetDeclaration(ctx context.Context, d *models.AssetDeclaration) error {
+	if err := validateAssetDeclaration(d); err != nil {
+		return err
+	}
+	return s.politicianRepo.UpdateAssetDeclaration(ctx, d)
+}
+
+func (s *PoliticianService) DeleteAssetDeclaration(ctx context.Context, politicianID, id uuid.UUID) error {
+	return s.politicianRepo.DeleteAssetDeclaration(ctx, politicianID, id)
+}
+
+func validateAssetDeclaration(d *models.AssetDeclaration) error {
+	if len(d.Details) == 0 || string(d.Details) == "null" {
+		d.Details = json.RawMessage("{}")
+	}
+	if d.DeclarationYear < 1963 || d.DeclarationYear > time.Now().Year()+1 {
+		return invalid("declaration_year", "must be a year between 1963 and %d", time.Now().Year()+1)
+	}
+	if d.TotalAssets != nil && *d.TotalAssets < 0 {
+		return invalid("total_assets", "must not be negative")
+	}
+	if d.TotalLiabilities != nil && *d.TotalLiabilities < 0 {
+		return invalid("total_liabilities", "must not be negative")
+	}
+	var details map[string]json.RawMessage
+	if err := json.Unmarshal(d.Details, &details); err != nil {
+		return invalid("details", "must be a JSON object")
+	}
+	return nil
+}
+
+func (s *PoliticianService) GetIntegrityFlag(ctx context.Context, politicianID, id uuid.UUID) (*models.IntegrityFlag, error) {
+	return s.politicianRepo.GetIntegrityFlag(ctx, politicianID, id)
+}
+
+func (s *PoliticianService) CreateIntegrityFlag(ctx context.Context, f *models.IntegrityFlag) error {
+	if err := validateIntegrityFlag(f); err != nil {
+		return err
+	}
+	return s.politicianRepo.CreateIntegrityFlag(ctx, f)
+}
+
+func (s *PoliticianService) UpdateIntegrityFlag(ctx context.Context, f *models.IntegrityFlag) error {
+	if err := validateIntegrityFlag(f); err != nil {
+		return err
+	}
+	return s.politicianRepo.UpdateIntegrityFlag(ctx, f)
+}
+
+func (s *PoliticianService) DeleteIntegrityFlag(ctx context.Context, politicianID, id uuid.UUID) error {
+	return s.politicianRepo.DeleteIntegrityFlag(ctx, politicianID, id)
+}
+
+func validateIntegrityFlag(f *models.IntegrityFlag) error {
+	if f.Status == "" {
+		f.Status = "active"
+	}
+	if f.FlaggedAt.IsZero() {
+		f.FlaggedAt = time.Now()
+	}
+	return firstError(
+		requireOneOf("flag_type", f.FlagType, integrityFlagTypes),
+		requireText("description", f.Description),
+		requireOneOf("status", f.Status, integrityStatuses),
+	)
+}
@@ -0,0 +1,339 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"jalada/internal/models"
+	"jalada/internal/repository"
+)
+
+const (
+	defaultPollHalfLife = 14
+	defaultPollWindow   = 90
+)
+
+type PollService struct {
+	pollRepo     *repository.PollRepo
+	electionRepo *repository.ElectionRepo
+}
+
+func NewPollService(pr *repository.PollRepo, er *repository.ElectionRepo) *PollService {
+	return &PollService{pollRepo: pr, electionRepo: er}
+}
+
+func (s *PollService) List(ctx context.Context, electionID uuid.UUID, f models.PollFilter) ([]models.Poll, error) {
+	if err := validatePollRace(&f.Scope, f.Title, f.County, f.PositionID, false); err != nil {
+		return nil, err
+	}
+	return s.pollRepo.List(ctx, electionID, f)
+}
+
+func (s *PollService) Get(ctx context.Context, electionID, id uuid.UUID) (*models.Poll, error) {
+	return s.pollRepo.Get(ctx, electionID, id)
+}
+
+func (s *PollService) Create(ctx context.Context, p *models.Poll) error {
+	if err := validatePoll(p); err != nil {
+		return err
+	}
+	election, err := s.electionRepo.GetByID(ctx, p.ElectionID)
+	if err != nil {
+		return err
+	}
+	if election == nil {
+		return repository.ErrNotFound
+	}
+	return s.pollRepo.Create(ctx, p)
+}
+
+func (s *PollService) Update(ctx context.Context, p *models.Poll) error {
+	if err := validatePoll(p); err != nil {
+		return err
+	}
+	return s.pollRepo.Update(ctx, p)
+}
+
+func (s *PollService) Delete(ctx context.Context, electionID, id uuid.UUID) error {
+	return s.pollRepo.Delete(ctx, electionID, id)
+}
+
+// validatePollRace checks the race filters of a poll listing or average.
+// Seat filters only apply to candidate polls; when defaultScope is set a
+// missing scope is taken to be candidate polls.
+func validatePollRace(scope *string, title, county string, positionID *uuid.UUID, defaultScope bool) error {
+	if *scope == "" && defaultScope {
+		*scope = "candidate"
+	}
+	if *scope == "party" && (title != "" || county != "" || positionID != nil) {
+		return invalid("scope", "party polls do not cover a seat; drop position, county and position_id")
+	}
+	var scopeErr, titleErr error
+	if *scope != "" {
+		scopeErr = requireOneOf("scope", *scope, pollScopes)
+	}
+	if title != "" {
+		titleErr = requireOneOf("position", title, positionTitles)
+	}
+	return firstError(scopeErr, titleErr)
+}
+
+// validatePoll checks a poll release. The scope follows from the seat when
+// it is not given, and shares must name politicians for candidate polls
+// and parties for party polls.
+func validatePoll(p *models.Poll) error {
+	if p.Scope == "" {
+		p.Scope = "party"
+		if p.PositionID != nil {
+			p.Scope = "candidate"
+		}
+	}
+	if err := firstError(
+		requireText("pollster", p.Pollster),
+		requireOneOf("scope", p.Scope, pollScopes),
+		requireOneOf("methodology", p.Methodology, pollMethodologies),
+	); err != nil {
+		return err
+	}
+
+	switch {
+	case p.Scope == "candidate" && p.PositionID == nil:
+		return invalid("position_id", "is required for candidate polls")
+	case p.Scope == "party" && p.PositionID != nil:
+		return invalid("position_id", "must be empty for party polls")
+	case p.FieldworkStart.IsZero():
+		return invalid("fieldwork_start", "is required")
+	case p.FieldworkEnd.IsZero():
+		return invalid("fieldwork_end", "is required")
+	case p.FieldworkEnd.After(time.Now()):
+		return invalid("fieldwork_end", "must not be in the future")
+	case p.SampleSize <= 0:
+		return invalid("sample_size", "must be positive")
+	case p.MarginOfError != nil && (*p.MarginOfError <= 0 || *p.MarginOfError >= 100):
+		return invalid("margin_of_error", "must be between 0 and 100")
+	case p.Undecided != nil && (*p.Undecided < 0 || *p.Undecided > 100):
+		return invalid("undecided", "must be between 0 and 100")
+	case len(p.Shares) == 0:
+		return invalid("shares", "at least one share is required")
+	}
+	if err := requireOrder("fieldwork_end", &p.FieldworkStart, &p.FieldworkEnd); err != nil {
+		return err
+	}
+
+	total := 0.0
+	if p.Undecided != nil {
+		total = *p.Undecided
+	}
+	seen := make(map[uuid.UUID]bool)
+	for _, sh := range p.Shares {
+		id := sh.PartyID
+		switch {
+		case p.Scope == "candidate" && (sh.PoliticianID == nil || sh.PartyID != nil):
+			return invalid("shares", "candidate poll shares must each name a politician_id only")
+		case p.Scope == "party" && (sh.PartyID == nil || sh.PoliticianID != nil):
+			return invalid("shares", "party poll shares must each name a party_id only")
+		case sh.Share < 0 || sh.Share > 100:
+			return invalid("shares", "each share must be between 0 and 100")
+		}
+		if sh.PoliticianID != nil {
+			id = sh.PoliticianID
+		}
+		if seen[*id] {
+			return invalid("shares", "%s is listed more than once", id)
+		}
+		seen[*id] = true
+		total += sh.Share
+	}
+	// Published shares are rounded, so allow a little over 100.
+	if total > 101 {
+		return invalid("shares", "shares and undecided add up to %.1f, more than 100", total)
+	}
+	return nil
+}
+
+// pollOptionTally collects one option's share in each poll that named it.
+type pollOptionTally struct {
+	option models.PollOption
+	points []pollPoint
+}
+
+// pollPoint is one poll's share for an option with the poll's weight and
+// the sampling variance of the share, in percentage points squared.
+type pollPoint struct {
+	weight   float64
+	share    float64
+	variance float64
+}
+
+// Average computes a poll of polls for one race. Polls whose fieldwork
+// ended within the window before the as-of date are weighted by the
+// square root of their sample size, halving every half-life days after the
+// middle of fieldwork. The band combines each poll's sampling error,
+// widened by the design effect implied by its margin of error, with the
+// spread between polls. It returns nil if the election does not exist.
+func (s *PollService) Average(ctx context.Context, electionID uuid.UUID, f models.PollAverageFilter) (*models.PollAverage, error) {
+	if f.HalfLifeDays == 0 {
+		f.HalfLifeDays = defaultPollHalfLife
+	}
+	if f.WindowDays == 0 {
+		f.WindowDays = defaultPollWindow
+	}
+	if err := validatePollRace(&f.Scope, f.Title, f.County, f.PositionID, true); err != nil {
+		return nil, err
+	}
+	if f.Scope == "candidate" && f.Title == "" && f.PositionID == nil {
+		f.Title = "president"
+	}
+	switch {
+	case f.HalfLifeDays < 1 || f.HalfLifeDays > 365:
+		return nil, invalid("half_life", "must be between 1 and 365 days")
+	case f.WindowDays < 1 || f.WindowDays > 730:
+		return nil, invalid("window", "must be between 1 and 730 days")
+	}
+
+	election, err := s.electionRepo.GetByID(ctx, electionID)
+	if err != nil || election == nil {
+		return nil, err
+	}
+
+	asOf := today()
+	if f.AsOf != nil {
+		asOf = *f.AsOf
+	}
+	since := asOf.AddDate(0, 0, -f.WindowDays)
+	polls, err := s.pollRepo.List(ctx, electionID, models.PollFilter{
+		Scope: f.Scope, Title: f.Title, County: f.County, PositionID: f.PositionID,
+		Since: &since, Until: &asOf,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	avg := &models.PollAverage{
+		ElectionID:   electionID,
+		Scope:        f.Scope,
+		AsOf:         asOf,
+		HalfLifeDays: f.HalfLifeDays,
+		WindowDays:   f.WindowDays,
+		Polls:        len(polls),
+		Pollsters:    []string{},
+		Options:      []models.PollOption{},
+	}
+	if len(polls) == 0 {
+		return avg, nil
+	}
+	seats := make(map[uuid.UUID]bool)
+	for _, p := range polls {
+		if p.PositionID != nil {
+			seats[*p.PositionID] = true
+		}
+	}
+	if len(seats) > 1 {
+		return nil, invalid("position_id", "polls cover %d seats; pick one with county or position_id", len(seats))
+	}
+	avg.PositionID, avg.Position, avg.Area = polls[0].PositionID, polls[0].PositionTitle, polls[0].Area
+
+	options := make(map[uuid.UUID]*pollOptionTally)
+	pollsters := make(map[string]bool)
+	var undecidedW, undecidedSum float64
+	for _, p := range polls {
+		pollsters[p.Pollster] = true
+		w := pollWeight(p, asOf, f.HalfLifeDays)
+		deff := designEffect(p)
+		if p.Undecided != nil {
+			undecidedW += w
+			undecidedSum += w * *p.Undecided
+		}
+		for _, sh := range p.Shares {
+			key := sh.PartyID
+			if sh.PoliticianID != nil {
+				key = sh.PoliticianID
+			}
+			t, ok := options[*key]
+			if !ok {
+				t = &pollOptionTally{option: models.PollOption{
+					PoliticianID: sh.PoliticianID, PartyID: sh.PartyID, Slug: sh.Slug, Name: sh.Name,
+				}}
+				options[*key] = t
+			}
+			if t.option.CandidacyID == nil {
+				t.option.CandidacyID = sh.CandidacyID
+			}
+			t.points = append(t.points, pollPoint{
+				weight:   w,
+				share:    sh.Share,
+				variance: deff * sh.Share * (100 - sh.Share) / float64(p.SampleSize),
+			})
+		}
+	}
+
+	for _, t := range options {
+		avg.Options = append(avg.Options, t.average())
+	}
+	sort.Slice(avg.Options, func(i, j int) bool {
+		if avg.Options[i].Share != avg.Options[j].Share {
+			return avg.Options[i].Share > avg.Options[j].Share
+		}
+		return avg.Options[i].Name < avg.Options[j].Name
+	})
+	for name := range pollsters {
+		avg.Pollsters = append(avg.Pollsters, name)
+	}
+	sort.Strings(avg.Pollsters)
+	if undecidedW > 0 {
+		u := roundPoints(undecidedSum / undecidedW)
+		avg.Undecided = &u
+	}
+	return avg, nil
+}
+
+// average returns the option's weighted mean share and its 95% band.
+func (t *pollOptionTally) average() models.PollOption {
+	var sumW, sumW2, sumWP, sumW2V float64
+	for _, pt := range t.points {
+		sumW += pt.weight
+		sumW2 += pt.weight * pt.weight
+		sumWP += pt.weight * pt.share
+		sumW2V += pt.weight * pt.weight * pt.variance
+	}
+	mean := sumWP / sumW
+
+	var spread float64
+	for _, pt := range t.points {
+		spread += pt.weight * (pt.share - mean) * (pt.share - mean)
+	}
+	spread /= sumW
+	effective := sumW * sumW / sumW2
+	se := math.Sqrt(sumW2V/(sumW*sumW) + spread/effective)
+
+	o := t.option
+	o.Share = roundPoints(mean)
+	o.Low = roundPoints(math.Max(0, mean-1.96*se))
+	o.High = roundPoints(math.Min(100, mean+1.96*se))
+	o.Polls = len(t.points)
+	return o
+}
+
+// pollWeight is the square root of the sample size, halved for every
+// half-life between the middle of fieldwork and asOf.
+func pollWeight(p models.Poll, asOf time.Time, halfLife int) float64 {
+	mid := p.FieldworkStart.Add(p.FieldworkEnd.Sub(p.FieldworkStart) / 2)
+	age := math.Max(0, asOf.Sub(mid).Hours()/24)
+	return math.Sqrt(float64(p.SampleSize)) * math.Pow(0.5, age/float64(halfLife))
+}
+
+// designEffect is how much wider a poll's reported margin of error is
+// than simple random sampling would give, taking the margin as quoted for
+// a 50% share at 95% confidence. Polls without a margin count as simple
+// random samples.
+func designEffect(p models.Poll) float64 {
+	if p.MarginOfError == nil {
+		return 1
+	}
+	se := *p.MarginOfError / 1.96
+	return math.Max(1, se*se*float64(p.SampleSize)/2500)
+}
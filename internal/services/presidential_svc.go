@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"jalada/internal/models"
+)
+
+// Article 138(4) thresholds for electing a president in the first round.
+const (
+	presidentialMajority     = 50.0
+	presidentialCountyShare  = 25.0
+	presidentialCountiesNeed = 24 // more than half of the 47 counties
+)
+
+// PresidentialStatus evaluates the constitutional thresholds against the
+// presidential results counted so far. It returns nil if the election does
+// not exist.
+func (s *ElectionService) PresidentialStatus(ctx context.Context, electionID uuid.UUID) (*models.PresidentialStatus, error) {
+	election, err := s.electionRepo.GetByID(ctx, electionID)
+	if err != nil || election == nil {
+		return nil, err
+	}
+	votes, err := s.resultsRepo.PresidentialCountyVotes(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	declared, err := s.resultsRepo.PresidentialDeclaration(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	counties, err := s.resultsRepo.CountCounties(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mates, err := s.candidacyRepo.RunningMates(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	st := evaluatePresidential(electionID, votes, declared, counties)
+	for i := range st.Candidates {
+		st.Candidates[i].RunningMate = mates[st.Candidates[i].CandidacyID]
+	}
+	return st, nil
+}
+
+// evaluatePresidential applies the thresholds to per-county votes. A
+// candidate clears a county with at least a quarter of its valid votes and
+// wins outright with more than half of the national valid votes as well.
+// A final national declaration, when given, settles the national totals,
+// but the county spread still comes from votes, so the result is only
+// decided once every county's count is complete and final as well.
+func evaluatePresidential(electionID uuid.UUID, votes []models.CountyVotes, declared []models.CandidateResult, countiesTotal int) *models.PresidentialStatus {
+	st := &models.PresidentialStatus{
+		ElectionID:        electionID,
+		MajorityThreshold: presidentialMajority,
+		CountyThreshold:   presidentialCountyShare,
+		CountiesRequired:  presidentialCountiesNeed,
+		CountiesTotal:     countiesTotal,
+		RunOffCandidates:  []uuid.UUID{},
+		Candidates:        []models.PresidentialCandidateStatus{},
+	}
+
+	countyValid := make(map[uuid.UUID]int)
+	var counties []models.AreaRef
+	final := true
+	for _, v := range votes {
+		if _, ok := countyValid[v.County.ID]; !ok {
+			counties = append(counties, v.County)
+		}
+		countyValid[v.County.ID] += v.Candidate.Votes
+		st.ValidVotes += v.Candidate.Votes
+		final = final && v.IsFinal
+	}
+	st.CountiesReporting = len(counties)
+	sort.Slice(counties, func(i, j int) bool { return counties[i].Code < counties[j].Code })
+
+	if len(votes) == 0 && len(declared) == 0 {
+		st.Status = "no_results"
+		return st
+	}
+	st.IsFinal = final && countiesTotal > 0 && st.CountiesReporting == countiesTotal
+
+	byCandidate := make(map[uuid.UUID]*models.PresidentialCandidateStatus)
+	countyVotes := make(map[uuid.UUID]map[uuid.UUID]int)
+	for _, v := range votes {
+		c, ok := byCandidate[v.Candidate.CandidacyID]
+		if !ok {
+			c = &models.PresidentialCandidateStatus{CandidateResult: v.Candidate}
+			c.Votes = 0
+			byCandidate[v.Candidate.CandidacyID] = c
+			countyVotes[v.Candidate.CandidacyID] = make(map[uuid.UUID]int)
+		}
+		c.Votes += v.Candidate.Votes
+		countyVotes[v.Candidate.CandidacyID][v.County.ID] += v.Candidate.Votes
+	}
+	if len(declared) > 0 {
+		for _, c := range byCandidate {
+			c.Votes = 0
+		}
+		st.ValidVotes = 0
+		for _, d := range declared {
+			c, ok := byCandidate[d.CandidacyID]
+			if !ok {
+				c = &models.PresidentialCandidateStatus{CandidateResult: d}
+				byCandidate[d.CandidacyID] = c
+			}
+			c.Votes = d.Votes
+			st.ValidVotes += d.Votes
+		}
+	}
+
+	for id, c := range byCandidate {
+		c.Share = percent(c.Votes, st.ValidVotes)
+		c.MeetsMajority = float64(c.Votes) > float64(st.ValidVotes)*presidentialMajority/100
+		c.Counties = make([]models.CountyShare, 0, len(counties))
+		for _, county := range counties {
+			n := countyVotes[id][county.ID]
+			meets := countyValid[county.ID] > 0 && float64(n) >= float64(countyValid[county.ID])*presidentialCountyShare/100
+			if meets {
+				c.CountiesAtThreshold++
+			}
+			c.Counties = append(c.Counties, models.CountyShare{
+				Code: county.Code, Name: county.Name, Votes: n,
+				Share: percent(n, countyValid[county.ID]), MeetsThreshold: meets,
+			})
+		}
+		c.CountiesShort = presidentialCountiesNeed - c.CountiesAtThreshold
+		if c.CountiesShort < 0 {
+			c.CountiesShort = 0
+		}
+		c.MeetsCountySpread = c.CountiesShort == 0
+		c.OnTrack = c.MeetsMajority && c.MeetsCountySpread
+		st.Candidates = append(st.Candidates, *c)
+	}
+	sort.Slice(st.Candidates, func(i, j int) bool {
+		if st.Candidates[i].Votes != st.Candidates[j].Votes {
+			return st.Candidates[i].Votes > st.Candidates[j].Votes
+		}
+		return st.Candidates[i].Name < st.Candidates[j].Name
+	})
+
+	leader := st.Candidates[0]
+	st.Leader = &leader.CandidacyID
+
+	switch {
+	case !st.IsFinal && len(declared) > 0:
+		st.Status = "declared_pending_counties"
+	case !st.IsFinal:
+		st.Status = "counting"
+	case !leader.OnTrack:
+		st.Status = "run_off"
+		st.RunOff = true
+		for i := 0; i < len(st.Candidates) && i < 2; i++ {
+			st.RunOffCandidates = append(st.RunOffCandidates, st.Candidates[i].CandidacyID)
+		}
+	default:
+		st.Status = "elected"
+		st.Winner = st.Leader
+	}
+	return st
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(n)/float64(total)*10000) / 100
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"jalada/internal/models"
+	"jalada/internal/repository"
+)
+
+// RepresentativeQuery identifies a voter's location. Exactly one of a
+// polling station code, a ward code or a coordinate pair is expected.
+type RepresentativeQuery struct {
+	PollingStation string
+	Ward           string
+	Lat            *float64
+	Lng            *float64
+}
+
+type RepresentativeService struct {
+	repo *repository.RepresentativeRepo
+	geo  *GeoService
+}
+
+func NewRepresentativeService(repo *repository.RepresentativeRepo, geo *GeoService) *RepresentativeService {
+	return &RepresentativeService{repo: repo, geo: geo}
+}
+
+// Lookup resolves q to a ward and returns every seat covering it, with the
+// sitting holder and the candidates declared for the upcoming general
+// election. It returns nil if the location cannot be resolved.
+func (s *RepresentativeService) Lookup(ctx context.Context, q RepresentativeQuery) (*models.Representatives, error) {
+	if err := validateRepresentativeQuery(q); err != nil {
+		return nil, err
+	}
+
+	var (
+		loc *models.VoterLocation
+		err error
+	)
+	switch {
+	case q.PollingStation != "":
+		loc, err = s.repo.LocateByPollingStation(ctx, q.PollingStation)
+	case q.Ward != "":
+		loc, err = s.repo.LocateByWard(ctx, q.Ward)
+	default:
+		loc, err = s.locatePoint(ctx, *q.Lat, *q.Lng)
+	}
+	if err != nil || loc == nil {
+		return nil, err
+	}
+
+	seats, err := s.repo.Seats(ctx, loc)
+	if err != nil {
+		return nil, err
+	}
+	election, err := s.repo.UpcomingElection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if election != nil && len(seats) > 0 {
+		ids := make([]uuid.UUID, len(seats))
+		for i, seat := range seats {
+			ids[i] = seat.PositionID
+		}
+		candidates, err := s.repo.Candidates(ctx, election.ID, ids)
+		if err != nil {
+			return nil, err
+		}
+		for i := range seats {
+			seats[i].Candidates = candidates[seats[i].PositionID]
+		}
+	}
+	for i := range seats {
+		if seats[i].Candidates == nil {
+			seats[i].Candidates = []models.SeatPolitician{}
+		}
+	}
+	if seats == nil {
+		seats = []models.Seat{}
+	}
+
+	return &models.Representatives{Location: *loc, Election: election, Seats: seats}, nil
+}
+
+// locatePoint resolves a coordinate to the ward whose boundary contains
+// it, falling back to the nearest polling station where ward boundaries
+// are not loaded.
+func (s *RepresentativeService) locatePoint(ctx context.Context, lat, lng float64) (*models.VoterLocation, error) {
+	if m := s.geo.Locate(lat, lng); m.Ward != nil {
+		loc, err := s.repo.LocateByWard(ctx, m.Ward.Code)
+		if err != nil {
+			return nil, err
+		}
+		if loc != nil {
+			loc.ResolvedBy = "boundary"
+			return loc, nil
+		}
+	}
+	return s.repo.LocateNearest(ctx, lat, lng)
+}
+
+func validateRepresentativeQuery(q RepresentativeQuery) error {
+	given := 0
+	if q.PollingStation != "" {
+		given++
+	}
+	if q.Ward != "" {
+		given++
+	}
+	if q.Lat != nil || q.Lng != nil {
+		given++
+		if err := requireCoordinates(q.Lat, q.Lng); err != nil {
+			return err
+		}
+	}
+	if given != 1 {
+		return invalid("location", "give exactly one of lat and lng, polling_station or ward")
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"jalada/internal/models"
+	"jalada/internal/repository"
+)
+
+const (
+	// subscriberBuffer is how many updates a slow subscriber may fall
+	// behind before it is dropped. Dropped clients reconnect and replay
+	// from their Last-Event-ID.
+	subscriberBuffer = 64
+	listenRetry      = 5 * time.Second
+	catchUpLimit     = 1000
+)
+
+// ResultStream fans newly committed tallies out to live subscribers. A
+// single LISTEN connection is shared by every subscriber, and each tally
+// is loaded once however many clients are watching.
+type ResultStream struct {
+	repo *repository.ResultsRepo
+
+	mu   sync.Mutex
+	subs map[*subscriber]struct{}
+	// lastSeq is kept per election: seq follows commit order only within
+	// an election, so one cursor across elections could skip tallies.
+	lastSeq map[uuid.UUID]int64
+}
+
+type subscriber struct {
+	filter models.ResultStreamFilter
+	ch     chan models.ResultUpdate
+}
+
+func NewResultStream(repo *repository.ResultsRepo) *ResultStream {
+	return &ResultStream{repo: repo, subs: make(map[*subscriber]struct{}), lastSeq: make(map[uuid.UUID]int64)}
+}
+
+// Start listens for committed tallies until ctx is cancelled, reconnecting
+// if the listener connection fails. Tallies that arrived while the
+// listener was down are published on reconnect. Subscriptions are closed
+// when Start returns so open streams end with the server.
+func (s *ResultStream) Start(ctx context.Context) {
+	defer s.closeAll()
+
+	seqs, err := s.repo.LatestSeqs(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to read latest result seqs")
+	}
+	s.mu.Lock()
+	for id, seq := range seqs {
+		s.lastSeq[id] = seq
+	}
+	s.mu.Unlock()
+
+	for {
+		s.catchUp(ctx)
+		err := s.repo.Listen(ctx, s.publish)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Error().Err(err).Dur("retry", listenRetry).Msg("results listener stopped")
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetry):
+		}
+	}
+}
+
+func (s *ResultStream) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subs {
+		delete(s.subs, sub)
+		close(sub.ch)
+	}
+}
+
+func (s *ResultStream) catchUp(ctx context.Context) {
+	latest, err := s.repo.LatestSeqs(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to catch up on results")
+		return
+	}
+	for id, newest := range latest {
+		s.mu.Lock()
+		seq := s.lastSeq[id]
+		s.mu.Unlock()
+		if newest <= seq {
+			continue
+		}
+
+		updates, err := s.repo.UpdatesSince(ctx, &id, seq, catchUpLimit)
+		if err != nil {
+			log.Error().Err(err).Str("election_id", id.String()).Msg("failed to catch up on results")
+			continue
+		}
+		for _, u := range updates {
+			s.broadcast(u)
+		}
+	}
+}
+
+func (s *ResultStream) publish(ctx context.Context, tallyID uuid.UUID) {
+	u, err := s.repo.GetUpdate(ctx, tallyID)
+	if err != nil {
+		log.Error().Err(err).Str("tally_id", tallyID.String()).Msg("failed to load result update")
+		return
+	}
+	if u != nil {
+		s.broadcast(*u)
+	}
+}
+
+func (s *ResultStream) broadcast(u models.ResultUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if u.Seq > s.lastSeq[u.ElectionID] {
+		s.lastSeq[u.ElectionID] = u.Seq
+	}
+	for sub := range s.subs {
+		if !matchesStream(sub.filter, &u) {
+			continue
+		}
+		select {
+		case sub.ch <- u:
+		default:
+			delete(s.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Subscribe returns a channel of live updates matching f and a function
+// that ends the subscription. The channel is closed if the subscriber
+// falls too far behind.
+func (s *ResultStream) Subscribe(f models.ResultStreamFilter) (<-chan models.ResultUpdate, func()) {
+	sub := &subscriber{filter: f, ch: make(chan models.ResultUpdate, subscriberBuffer)}
+	s.mu.Lock()
+	s.subs[sub] = struct{}{}
+	s.mu.Unlock()
+
+	return sub.ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.subs[sub]; ok {
+			delete(s.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Replay returns the updates matching f that arrived after seq, oldest
+// first. complete is false if more than limit arrived, in which case the
+// caller should start over from a results snapshot; latest is then the
+// newest sequence number to resume from.
+func (s *ResultStream) Replay(ctx context.Context, f models.ResultStreamFilter, seq int64, limit int) (updates []models.ResultUpdate, complete bool, latest int64, err error) {
+	all, err := s.repo.UpdatesSince(ctx, &f.ElectionID, seq, limit+1)
+	if err != nil {
+		return nil, false, 0, err
+	}
+	if len(all) > limit {
+		latest, err := s.repo.LatestSeq(ctx, &f.ElectionID)
+		return nil, false, latest, err
+	}
+	for _, u := range all {
+		if matchesStream(f, &u) {
+			updates = append(updates, u)
+		}
+	}
+	return updates, true, seq, nil
+}
+
+// ValidateStreamFilter checks the seat title in a stream filter.
+func ValidateStreamFilter(f models.ResultStreamFilter) error {
+	if f.Title == "" {
+		return nil
+	}
+	return requireOneOf("position", f.Title, positionTitles)
+}
+
+func matchesStream(f models.ResultStreamFilter, u *models.ResultUpdate) bool {
+	switch {
+	case u.ElectionID != f.ElectionID:
+		return false
+	case f.PositionID != nil && u.PositionID != *f.PositionID:
+		return false
+	case f.Title != "" && u.Title != f.Title:
+		return false
+	case f.County != "" && !codeIs(u.CountyCode, f.County):
+		return false
+	case f.Constituency != "" && !codeIs(u.ConstituencyCode, f.Constituency):
+		return false
+	case f.Ward != "" && !codeIs(u.WardCode, f.Ward):
+		return false
+	}
+	return true
+}
+
+func codeIs(code *string, want string) bool {
+	return code != nil && *code == want
+}
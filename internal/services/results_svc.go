@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"jalada/internal/audit"
+	"jalada/internal/models"
+	"jalada/internal/repository"
+)
+
+// formSeries is the IEBC form number for each seat's results.
+var formSeries = map[string]int{
+	"president": 34,
+	"mp":        35,
+	"mca":       36,
+	"governor":  37,
+	"senator":   38,
+	"woman_rep": 39,
+}
+
+// resultForm returns the IEBC form a tally is filed on: A from the polling
+// station, B from the tallying centre below the seat, and C where the seat
+// itself is declared. MPs and MCAs are declared on Form B.
+func resultForm(title, seatLevel, level string) *string {
+	series, ok := formSeries[title]
+	if !ok {
+		return nil
+	}
+	var letter string
+	switch {
+	case level == "station":
+		letter = "A"
+	case level == seatLevel && (title == "mp" || title == "mca"):
+		letter = "B"
+	case level == seatLevel:
+		letter = "C"
+	case level == "constituency":
+		letter = "B"
+	default:
+		return nil
+	}
+	form := fmt.Sprintf("%d%s", series, letter)
+	return &form
+}
+
+// SubmitTally records a results form for a seat. A resubmission for the
+// same seat and area supersedes the previous one.
+func (s *ElectionService) SubmitTally(ctx context.Context, t *models.ResultTally) error {
+	if err := validateTally(t); err != nil {
+		return err
+	}
+
+	election, err := s.electionRepo.GetByID(ctx, t.ElectionID)
+	if err != nil {
+		return err
+	}
+	if election == nil {
+		return repository.ErrNotFound
+	}
+	position, err := s.resultsRepo.GetPosition(ctx, t.PositionID)
+	if err != nil {
+		return err
+	}
+	if position == nil {
+		return fmt.Errorf("submit tally: %w: position does not exist", repository.ErrInvalidReference)
+	}
+	if isDeputy(position.Title) {
+		return invalid("position_id", "running mates are not on the ballot; submit results for their principal's seat")
+	}
+	if t.Level == "national" && position.Level != "national" {
+		return invalid("level", "national tallies are only filed for national seats")
+	}
+	if t.Form == nil {
+		t.Form = resultForm(position.Title, position.Level, t.Level)
+	}
+
+	t.ReportedBy = audit.Actor(ctx)
+	t.SourceID = nil
+	if id, ok := audit.Source(ctx); ok {
+		t.SourceID = &id
+	}
+	return s.resultsRepo.CreateTally(ctx, position, t)
+}
+
+func validateTally(t *models.ResultTally) error {
+	if t.PositionID == uuid.Nil {
+		return invalid("position_id", "is required")
+	}
+	if err := requireOneOf("level", t.Level, repository.ResultLevels); err != nil {
+		return err
+	}
+	if t.Level == "national" {
+		t.AreaCode = nil
+	} else if t.AreaCode == nil || *t.AreaCode == "" {
+		return invalid("area_code", "is required for %s tallies", t.Level)
+	}
+	if t.RegisteredVoters < 0 {
+		return invalid("registered_voters", "must not be negative")
+	}
+	if t.RejectedVotes < 0 {
+		return invalid("rejected_votes", "must not be negative")
+	}
+	if len(t.Results) == 0 {
+		return invalid("results", "must include at least one candidate")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(t.Results))
+	for _, v := range t.Results {
+		if v.CandidacyID == uuid.Nil {
+			return invalid("results", "candidacy_id is required")
+		}
+		if seen[v.CandidacyID] {
+			return invalid("results", "candidacy %s is listed more than once", v.CandidacyID)
+		}
+		seen[v.CandidacyID] = true
+		if v.Votes < 0 {
+			return invalid("results", "votes must not be negative")
+		}
+	}
+	return nil
+}
+
+// GetAreaResults aggregates results over one reporting area. It returns
+// nil if the area does not exist.
+func (s *ElectionService) GetAreaResults(ctx context.Context, electionID uuid.UUID, f models.ResultFilter) (*models.AreaResults, error) {
+	if err := requireOneOf("level", f.Level, repository.ResultLevels); err != nil {
+		return nil, err
+	}
+	if f.Level != "national" && f.Code == "" {
+		return nil, invalid("code", "is required for %s results", f.Level)
+	}
+	if f.Title != "" {
+		if err := requireOneOf("position", f.Title, positionTitles); err != nil {
+			return nil, err
+		}
+	}
+	res, err := s.resultsRepo.GetAreaResults(ctx, electionID, f)
+	if err != nil || res == nil {
+		return res, err
+	}
+	mates, err := s.candidacyRepo.RunningMates(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range res.Positions {
+		withRunningMates(mates, res.Positions[i].Candidates)
+	}
+	return res, nil
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"jalada/internal/models"
+)
+
+// Seats returns the result of each seat contested in an election, with its
+// winner, runner-up and margin. The presidential seats are decided by
+// Article 138(4) rather than by plurality. WinnerParty keeps only seats won
+// or led by that party, matched on slug or abbreviation.
+func (s *ElectionService) Seats(ctx context.Context, electionID uuid.UUID, f models.SeatFilter) ([]models.SeatResult, error) {
+	if f.Title != "" {
+		if err := requireOneOf("position", f.Title, positionTitles); err != nil {
+			return nil, err
+		}
+	}
+	seats, err := s.resultsRepo.SeatCandidates(ctx, electionID, f)
+	if err != nil {
+		return nil, err
+	}
+	mates, err := s.candidacyRepo.RunningMates(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]models.SeatResult, 0, len(seats))
+	var presidential *models.PresidentialStatus
+	for i := range seats {
+		for j := range seats[i].Candidates {
+			c := &seats[i].Candidates[j]
+			c.RunningMate = mates[c.CandidacyID]
+		}
+		decideSeat(&seats[i])
+		if seats[i].Title == "president" || seats[i].Title == "deputy_president" {
+			if presidential == nil {
+				if presidential, err = s.PresidentialStatus(ctx, electionID); err != nil {
+					return nil, err
+				}
+			}
+			applyPresidential(&seats[i], presidential)
+		}
+		if f.WinnerParty != "" && !partyIs(seats[i].Winner, f.WinnerParty) {
+			continue
+		}
+		out = append(out, seats[i])
+	}
+	sortSeats(out)
+	return out, nil
+}
+
+// Seat returns one seat's result, or nil if no candidacy stands for it in
+// the election.
+func (s *ElectionService) Seat(ctx context.Context, electionID, positionID uuid.UUID) (*models.SeatResult, error) {
+	seats, err := s.Seats(ctx, electionID, models.SeatFilter{PositionID: &positionID})
+	if err != nil || len(seats) == 0 {
+		return nil, err
+	}
+	return &seats[0], nil
+}
+
+// SeatSummary counts seats won and led by each party for every seat title
+// in the election, or only for title.
+func (s *ElectionService) SeatSummary(ctx context.Context, electionID uuid.UUID, f models.SeatFilter) ([]models.SeatSummary, error) {
+	seats, err := s.Seats(ctx, electionID, models.SeatFilter{Title: f.Title, County: f.County})
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[string]*models.SeatSummary)
+	parties := make(map[string]map[string]*models.PartySeats)
+	var titles []string
+	for _, seat := range seats {
+		sum, ok := summaries[seat.Title]
+		if !ok {
+			sum = &models.SeatSummary{Title: seat.Title, Parties: []models.PartySeats{}}
+			summaries[seat.Title] = sum
+			parties[seat.Title] = make(map[string]*models.PartySeats)
+			titles = append(titles, seat.Title)
+		}
+		sum.Seats++
+		if seat.Basis != "none" {
+			sum.Reporting++
+		}
+		if seat.IsFinal {
+			sum.Final++
+		}
+
+		party := func(c *models.SeatCandidate) *models.PartySeats {
+			name := "Independent"
+			if c.Party != nil {
+				name = *c.Party
+			}
+			p, ok := parties[seat.Title][name]
+			if !ok {
+				p = &models.PartySeats{Party: name, PartySlug: c.PartySlug}
+				parties[seat.Title][name] = p
+			}
+			return p
+		}
+		for i := range seat.Candidates {
+			party(&seat.Candidates[i]).Contested++
+		}
+		if seat.Winner != nil {
+			if seat.IsFinal {
+				party(seat.Winner).Won++
+			} else {
+				party(seat.Winner).Leading++
+			}
+		}
+	}
+
+	out := make([]models.SeatSummary, 0, len(titles))
+	for _, title := range titles {
+		sum := summaries[title]
+		for _, p := range parties[title] {
+			sum.Parties = append(sum.Parties, *p)
+		}
+		sort.Slice(sum.Parties, func(i, j int) bool {
+			a, b := sum.Parties[i], sum.Parties[j]
+			if a.Won+a.Leading != b.Won+b.Leading {
+				return a.Won+a.Leading > b.Won+b.Leading
+			}
+			if a.Contested != b.Contested {
+				return a.Contested > b.Contested
+			}
+			return a.Party < b.Party
+		})
+		out = append(out, *sum)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return models.PositionRank[out[i].Title] < models.PositionRank[out[j].Title] })
+	return out, nil
+}
+
+// decideSeat fills in shares, the winner, runner-up and margin. Candidates
+// arrive ordered by votes. A seat without results is still decided when a
+// candidacy is marked elected, as for an unopposed candidate; a seat whose
+// top two are tied has no winner.
+func decideSeat(seat *models.SeatResult) {
+	for i := range seat.Candidates {
+		seat.Candidates[i].Share = percent(seat.Candidates[i].Votes, seat.ValidVotes)
+	}
+	if seat.ValidVotes == 0 {
+		for i := range seat.Candidates {
+			if seat.Candidates[i].Status == "elected" {
+				seat.Winner = &seat.Candidates[i]
+				seat.IsFinal = true
+			}
+		}
+		return
+	}
+
+	if len(seat.Candidates) > 1 && seat.Candidates[0].Votes == seat.Candidates[1].Votes {
+		margin, share := 0, 0.0
+		seat.Margin, seat.MarginShare = &margin, &share
+		return
+	}
+
+	seat.Winner = &seat.Candidates[0]
+	runnerUpVotes := 0
+	if len(seat.Candidates) > 1 {
+		seat.RunnerUp = &seat.Candidates[1]
+		runnerUpVotes = seat.RunnerUp.Votes
+	}
+	margin := seat.Winner.Votes - runnerUpVotes
+	share := percent(margin, seat.ValidVotes)
+	seat.Margin, seat.MarginShare = &margin, &share
+}
+
+// applyPresidential takes a presidential or deputy presidential seat's
+// outcome from the Article 138(4) evaluation: the seat is final only once
+// the status is, and no one wins it when a run-off is due.
+func applyPresidential(seat *models.SeatResult, st *models.PresidentialStatus) {
+	if st == nil || seat.ValidVotes == 0 {
+		return
+	}
+	seat.IsFinal = st.IsFinal
+	if !st.IsFinal {
+		return
+	}
+	if st.Winner == nil {
+		seat.Winner = nil
+		return
+	}
+	if seat.Title == "president" {
+		for i := range seat.Candidates {
+			if seat.Candidates[i].CandidacyID == *st.Winner {
+				seat.Winner = &seat.Candidates[i]
+			}
+		}
+	}
+}
+
+func partyIs(c *models.SeatCandidate, party string) bool {
+	if c == nil {
+		return false
+	}
+	return (c.PartySlug != nil && strings.EqualFold(*c.PartySlug, party)) ||
+		(c.Party != nil && strings.EqualFold(*c.Party, party))
+}
+
+func sortSeats(seats []models.SeatResult) {
+	sort.SliceStable(seats, func(i, j int) bool {
+		a, b := seats[i], seats[j]
+		if models.PositionRank[a.Title] != models.PositionRank[b.Title] {
+			return models.PositionRank[a.Title] < models.PositionRank[b.Title]
+		}
+		var ac, bc string
+		if a.Area != nil {
+			ac = a.Area.Code
+		}
+		if b.Area != nil {
+			bc = b.Area.Code
+		}
+		return ac < bc
+	})
+}
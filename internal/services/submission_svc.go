@@ -0,0 +1,333 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+
+	"jalada/internal/audit"
+	"jalada/internal/models"
+	"jalada/internal/repository"
+)
+
+var (
+	submissionCollections = []string{"politician", "party-memberships", "court-cases", "promises", "achievements", "controversies", "assets", "integrity-flags"}
+	submissionOperations  = []string{"create", "update", "delete"}
+	submissionStatuses    = []string{"submitted", "under_review", "approved", "rejected"}
+	sourceTypes           = []string{"iebc", "eacc", "gazette", "hansard", "news", "social", "court", "other"}
+)
+
+// submissionTarget checks and applies a submission against one dossier
+// collection.
+type submissionTarget interface {
+	check(ctx context.Context, s *models.EditSubmission) error
+	apply(ctx context.Context, s *models.EditSubmission, sourceID uuid.UUID) (uuid.UUID, error)
+}
+
+// dossierTarget adapts the PoliticianService write methods for one record
+// type to submissionTarget.
+type dossierTarget[T any] struct {
+	get      func(ctx context.Context, politicianID, id uuid.UUID) (*T, error)
+	create   func(ctx context.Context, v *T) error
+	update   func(ctx context.Context, v *T) error
+	remove   func(ctx context.Context, politicianID, id uuid.UUID) error
+	validate func(v *T) error
+	// bind sets the keys the submitter may not choose.
+	bind func(v *T, politicianID, id uuid.UUID)
+	id   func(v *T) uuid.UUID
+	// source points at the record's source_id, or is nil when the table
+	// has none.
+	source func(v *T) **uuid.UUID
+}
+
+// build decodes the payload into the record the submission would write:
+// a new record for a create, the current record with the payload applied
+// for an update, and the current record for a delete.
+func (t dossierTarget[T]) build(ctx context.Context, s *models.EditSubmission) (*T, error) {
+	v := new(T)
+	if s.Operation != "create" {
+		existing, err := t.get(ctx, s.PoliticianID, *s.RecordID)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, invalid("record_id", "does not match a record in %s", s.Collection)
+		}
+		v = existing
+	}
+	if s.Operation != "delete" {
+		dec := json.NewDecoder(bytes.NewReader(s.Payload))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(v); err != nil {
+			return nil, invalid("payload", "%s", err.Error())
+		}
+	}
+
+	id := uuid.Nil
+	if s.RecordID != nil {
+		id = *s.RecordID
+	}
+	t.bind(v, s.PoliticianID, id)
+	return v, nil
+}
+
+func (t dossierTarget[T]) check(ctx context.Context, s *models.EditSubmission) error {
+	v, err := t.build(ctx, s)
+	if err != nil || s.Operation == "delete" {
+		return err
+	}
+	return t.validate(v)
+}
+
+func (t dossierTarget[T]) apply(ctx context.Context, s *models.EditSubmission, sourceID uuid.UUID) (uuid.UUID, error) {
+	v, err := t.build(ctx, s)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	switch s.Operation {
+	case "create":
+		if t.source != nil && *t.source(v) == nil {
+			*t.source(v) = &sourceID
+		}
+		if err := t.create(ctx, v); err != nil {
+			return uuid.Nil, err
+		}
+		return t.id(v), nil
+	case "update":
+		return *s.RecordID, t.update(ctx, v)
+	default:
+		return *s.RecordID, t.remove(ctx, s.PoliticianID, *s.RecordID)
+	}
+}
+
+type SubmissionService struct {
+	submissionRepo *repository.SubmissionRepo
+	sourceRepo     *repository.SourceRepo
+	targets        map[string]submissionTarget
+}
+
+func NewSubmissionService(sr *repository.SubmissionRepo, src *repository.SourceRepo, ps *PoliticianService) *SubmissionService {
+	return &SubmissionService{
+		submissionRepo: sr,
+		sourceRepo:     src,
+		targets:        submissionTargets(ps),
+	}
+}
+
+func submissionTargets(ps *PoliticianService) map[string]submissionTarget {
+	return map[string]submissionTarget{
+		"politician": dossierTarget[models.Politician]{
+			get: func(ctx context.Context, _, id uuid.UUID) (*models.Politician, error) {
+				return ps.politicianRepo.GetByID(ctx, id)
+			},
+			update:   ps.UpdatePolitician,
+			validate: validatePolitician,
+			bind:     func(v *models.Politician, pid, _ uuid.UUID) { v.ID = pid },
+			id:       func(v *models.Politician) uuid.UUID { return v.ID },
+		},
+		"party-memberships": dossierTarget[models.PartyMembership]{
+			get:      ps.GetPartyMembership,
+			create:   ps.CreatePartyMembership,
+			update:   ps.UpdatePartyMembership,
+			remove:   ps.DeletePartyMembership,
+			validate: validatePartyMembership,
+			bind:     func(v *models.PartyMembership, pid, id uuid.UUID) { v.PoliticianID, v.ID = pid, id },
+			id:       func(v *models.PartyMembership) uuid.UUID { return v.ID },
+		},
+		"court-cases": dossierTarget[models.CourtCase]{
+			get:      ps.GetCourtCase,
+			create:   ps.CreateCourtCase,
+			update:   ps.UpdateCourtCase,
+			remove:   ps.DeleteCourtCase,
+			validate: validateCourtCase,
+			bind:     func(v *models.CourtCase, pid, id uuid.UUID) { v.PoliticianID, v.ID = pid, id },
+			id:       func(v *models.CourtCase) uuid.UUID { return v.ID },
+			source:   func(v *models.CourtCase) **uuid.UUID { return &v.SourceID },
+		},
+		"promises": dossierTarget[models.Promise]{
+			get:      ps.GetPromise,
+			create:   ps.CreatePromise,
+			update:   ps.UpdatePromise,
+			remove:   ps.DeletePromise,
+			validate: validatePromise,
+			bind:     func(v *models.Promise, pid, id uuid.UUID) { v.PoliticianID, v.ID = pid, id },
+			id:       func(v *models.Promise) uuid.UUID { return v.ID },
+			source:   func(v *models.Promise) **uuid.UUID { return &v.SourceID },
+		},
+		"achievements": dossierTarget[models.Achievement]{
+			get:      ps.GetAchievement,
+			create:   ps.CreateAchievement,
+			update:   ps.UpdateAchievement,
+			remove:   ps.DeleteAchievement,
+			validate: func(v *models.Achievement) error { return requireText("title", v.Title) },
+			bind:     func(v *models.Achievement, pid, id uuid.UUID) { v.PoliticianID, v.ID = pid, id },
+			id:       func(v *models.Achievement) uuid.UUID { return v.ID },
+			source:   func(v *models.Achievement) **uuid.UUID { return &v.SourceID },
+		},
+		"controversies": dossierTarget[models.Controversy]{
+			get:      ps.GetControversy,
+			create:   ps.CreateControversy,
+			update:   ps.UpdateControversy,
+			remove:   ps.DeleteControversy,
+			validate: validateControversy,
+			bind:     func(v *models.Controversy, pid, id uuid.UUID) { v.PoliticianID, v.ID = pid, id },
+			id:       func(v *models.Controversy) uuid.UUID { return v.ID },
+			source:   func(v *models.Controversy) **uuid.UUID { return &v.SourceID },
+		},
+		"assets": dossierTarget[models.AssetDeclaration]{
+			get:      ps.GetAssetDeclaration,
+			create:   ps.CreateAssetDeclaration,
+			update:   ps.UpdateAssetDeclaration,
+			remove:   ps.DeleteAssetDeclaration,
+			validate: validateAssetDeclaration,
+			bind:     func(v *models.AssetDeclaration, pid, id uuid.UUID) { v.PoliticianID, v.ID = pid, id },
+			id:       func(v *models.AssetDeclaration) uuid.UUID { return v.ID },
+			source:   func(v *models.AssetDeclaration) **uuid.UUID { return &v.SourceID },
+		},
+		"integrity-flags": dossierTarget[models.IntegrityFlag]{
+			get:      ps.GetIntegrityFlag,
+			create:   ps.CreateIntegrityFlag,
+			update:   ps.UpdateIntegrityFlag,
+			remove:   ps.DeleteIntegrityFlag,
+			validate: validateIntegrityFlag,
+			bind:     func(v *models.IntegrityFlag, pid, id uuid.UUID) { v.PoliticianID, v.ID = pid, id },
+			id:       func(v *models.IntegrityFlag) uuid.UUID { return v.ID },
+			source:   func(v *models.IntegrityFlag) **uuid.UUID { return &v.SourceID },
+		},
+	}
+}
+
+// Submit validates a proposed edit against the current record and queues
+// it for review. Nothing is written to the dossier until it is approved.
+func (s *SubmissionService) Submit(ctx context.Context, sub *models.EditSubmission) error {
+	if sub.EvidenceType == "" {
+		sub.EvidenceType = "other"
+	}
+	if len(sub.Payload) == 0 || string(sub.Payload) == "null" {
+		sub.Payload = json.RawMessage("{}")
+	}
+	if sub.Collection == "politician" {
+		sub.RecordID = &sub.PoliticianID
+	}
+
+	var recordErr error
+	switch {
+	case sub.Operation != "update" && sub.Collection == "politician":
+		recordErr = invalid("operation", "politicians can only be updated through submissions")
+	case sub.Operation == "create" && sub.RecordID != nil:
+		recordErr = invalid("record_id", "must be empty when creating a record")
+	case sub.Operation != "create" && sub.RecordID == nil:
+		recordErr = invalid("record_id", "is required for %s", sub.Operation)
+	}
+
+	err := firstError(
+		requireOneOf("collection", sub.Collection, submissionCollections),
+		requireOneOf("operation", sub.Operation, submissionOperations),
+		recordErr,
+		requireText("summary", sub.Summary),
+		requireURL("evidence_url", sub.EvidenceURL),
+		requireOneOf("evidence_type", sub.EvidenceType, sourceTypes),
+	)
+	if err != nil {
+		return err
+	}
+	if err := s.targets[sub.Collection].check(ctx, sub); err != nil {
+		return err
+	}
+	return s.submissionRepo.Create(ctx, sub)
+}
+
+func (s *SubmissionService) List(ctx context.Context, f models.SubmissionFilter) ([]models.EditSubmission, int, error) {
+	if f.Status != "" {
+		if err := requireOneOf("status", f.Status, submissionStatuses); err != nil {
+			return nil, 0, err
+		}
+	}
+	return s.submissionRepo.List(ctx, f)
+}
+
+func (s *SubmissionService) Get(ctx context.Context, id uuid.UUID) (*models.EditSubmission, error) {
+	return s.submissionRepo.GetByID(ctx, id)
+}
+
+// StartReview claims a submitted edit for reviewer.
+func (s *SubmissionService) StartReview(ctx context.Context, id uuid.UUID, reviewer string) (*models.EditSubmission, error) {
+	return s.submissionRepo.Transition(ctx, id, func(_ context.Context, sub *models.EditSubmission) error {
+		if sub.Status != "submitted" {
+			return transitionError(sub, "under_review")
+		}
+		sub.Status = "under_review"
+		sub.Reviewer = &reviewer
+		return nil
+	})
+}
+
+// Approve records the evidence in sources and applies the edit through the
+// dossier write path, attributed to reviewer and the evidence source. Both
+// happen in the transaction that approves the submission, so an edit is
+// never live while its submission is still pending.
+func (s *SubmissionService) Approve(ctx context.Context, id uuid.UUID, reviewer string, comment *string) (*models.EditSubmission, error) {
+	return s.submissionRepo.Transition(ctx, id, func(ctx context.Context, sub *models.EditSubmission) error {
+		if sub.Status != "submitted" && sub.Status != "under_review" {
+			return transitionError(sub, "approved")
+		}
+
+		name := sub.EvidenceURL
+		if sub.EvidenceName != nil && *sub.EvidenceName != "" {
+			name = *sub.EvidenceName
+		}
+		sourceID, err := s.sourceRepo.FindOrCreate(ctx, name, sub.EvidenceURL, sub.EvidenceType)
+		if err != nil {
+			return err
+		}
+
+		applyCtx := audit.WithSource(audit.WithActor(ctx, reviewer), sourceID)
+		recordID, err := s.targets[sub.Collection].apply(applyCtx, sub, sourceID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		sub.Status = "approved"
+		sub.Reviewer = &reviewer
+		sub.ReviewComment = comment
+		sub.ReviewedAt = &now
+		sub.SourceID = &sourceID
+		sub.AppliedRecordID = &recordID
+		return nil
+	})
+}
+
+func (s *SubmissionService) Reject(ctx context.Context, id uuid.UUID, reviewer string, comment *string) (*models.EditSubmission, error) {
+	if comment == nil || requireText("comment", *comment) != nil {
+		return nil, invalid("comment", "is required when rejecting a submission")
+	}
+	return s.submissionRepo.Transition(ctx, id, func(_ context.Context, sub *models.EditSubmission) error {
+		if sub.Status != "submitted" && sub.Status != "under_review" {
+			return transitionError(sub, "rejected")
+		}
+		now := time.Now()
+		sub.Status = "rejected"
+		sub.Reviewer = &reviewer
+		sub.ReviewComment = comment
+		sub.ReviewedAt = &now
+		return nil
+	})
+}
+
+func transitionError(sub *models.EditSubmission, to string) error {
+	return fmt.Errorf("submission is %s and cannot move to %s: %w", sub.Status, to, repository.ErrConflict)
+}
+
+func requireURL(field, value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return invalid(field, "must be an http or https URL")
+	}
+	return nil
+}
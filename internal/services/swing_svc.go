@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"jalada/internal/models"
+)
+
+var (
+	swingGroupings = []string{"party", "coalition"}
+	swingLevels    = []string{"constituency", "county"}
+)
+
+// areaTally accumulates one area's figures for one election.
+type areaTally struct {
+	area       models.AreaRef
+	county     *models.AreaRef
+	registered int
+	rejected   int
+	valid      int
+	groups     map[string]*groupVotes
+}
+
+type groupVotes struct {
+	id        *uuid.UUID
+	name      string
+	groupType string
+	votes     int
+}
+
+// Swing compares a seat's results between two elections per constituency
+// or county. It returns nil if either election does not exist.
+func (s *AnalyticsService) Swing(ctx context.Context, f models.SwingFilter) (*models.SwingReport, error) {
+	if f.Title == "" {
+		f.Title = "president"
+	}
+	if f.GroupBy == "" {
+		f.GroupBy = "party"
+	}
+	if f.Level == "" {
+		f.Level = "constituency"
+	}
+	var sameErr error
+	if f.From == f.To {
+		sameErr = invalid("to", "must be a different election from from")
+	}
+	if err := firstError(
+		sameErr,
+		requireOneOf("position", f.Title, positionTitles),
+		requireOneOf("group_by", f.GroupBy, swingGroupings),
+		requireOneOf("level", f.Level, swingLevels),
+	); err != nil {
+		return nil, err
+	}
+
+	from, err := s.electionRepo.GetByID(ctx, f.From)
+	if err != nil || from == nil {
+		return nil, err
+	}
+	to, err := s.electionRepo.GetByID(ctx, f.To)
+	if err != nil || to == nil {
+		return nil, err
+	}
+
+	before, err := s.areaTallies(ctx, f, f.From)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.areaTallies(ctx, f, f.To)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.SwingReport{
+		From: *from, To: *to, Position: f.Title, GroupBy: f.GroupBy, Level: f.Level,
+		Areas: []models.AreaSwing{},
+	}
+	keys := make(map[uuid.UUID]bool)
+	for id := range before {
+		keys[id] = true
+	}
+	for id := range after {
+		keys[id] = true
+	}
+	overallBefore, overallAfter := &areaTally{groups: map[string]*groupVotes{}}, &areaTally{groups: map[string]*groupVotes{}}
+	for id := range keys {
+		report.Areas = append(report.Areas, compareAreas(before[id], after[id]))
+		mergeTally(overallBefore, before[id])
+		mergeTally(overallAfter, after[id])
+	}
+	sort.Slice(report.Areas, func(i, j int) bool { return report.Areas[i].Area.Code < report.Areas[j].Area.Code })
+	if len(keys) > 0 {
+		overall := compareAreas(overallBefore, overallAfter)
+		overall.Area = models.AreaRef{Name: "All areas"}
+		report.Overall = &overall
+	}
+	return report, nil
+}
+
+// areaTallies loads an election's group votes and rolls them up to the
+// report level, keyed by area id.
+func (s *AnalyticsService) areaTallies(ctx context.Context, f models.SwingFilter, electionID uuid.UUID) (map[uuid.UUID]*areaTally, error) {
+	rows, err := s.analyticsRepo.GroupVotes(ctx, electionID, f.Title, f.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	areas := make(map[uuid.UUID]*areaTally)
+	seen := make(map[uuid.UUID]bool)
+	for _, g := range rows {
+		if f.County != "" && g.County.Code != f.County {
+			continue
+		}
+		area, county := g.Constituency, &g.County
+		if f.Level == "county" {
+			area, county = g.County, nil
+		}
+		a, ok := areas[area.ID]
+		if !ok {
+			a = &areaTally{area: area, county: county, groups: make(map[string]*groupVotes)}
+			areas[area.ID] = a
+		}
+		// Registered and rejected counts repeat on every group row of a
+		// constituency; count them once.
+		if !seen[g.Constituency.ID] {
+			seen[g.Constituency.ID] = true
+			a.registered += g.RegisteredVoters
+			a.rejected += g.RejectedVotes
+		}
+		a.valid += g.Votes
+		addGroup(a.groups, g.GroupID, g.GroupName, g.GroupType, g.Votes)
+	}
+	return areas, nil
+}
+
+func groupKey(id *uuid.UUID, groupType string) string {
+	if id == nil {
+		return groupType
+	}
+	return groupType + ":" + id.String()
+}
+
+func addGroup(groups map[string]*groupVotes, id *uuid.UUID, name, groupType string, votes int) {
+	key := groupKey(id, groupType)
+	g, ok := groups[key]
+	if !ok {
+		g = &groupVotes{id: id, name: name, groupType: groupType}
+		groups[key] = g
+	}
+	g.votes += votes
+}
+
+func mergeTally(dst, src *areaTally) {
+	if src == nil {
+		return
+	}
+	dst.registered += src.registered
+	dst.rejected += src.rejected
+	dst.valid += src.valid
+	for _, g := range src.groups {
+		addGroup(dst.groups, g.id, g.name, g.groupType, g.votes)
+	}
+}
+
+// compareAreas compares one area's tallies from two elections; either may
+// be nil.
+func compareAreas(before, after *areaTally) models.AreaSwing {
+	var out models.AreaSwing
+	if after != nil {
+		out.Area, out.County = after.area, after.county
+	} else {
+		out.Area, out.County = before.area, before.county
+	}
+
+	groups := make(map[string]*models.GroupSwing)
+	get := func(key string, g *groupVotes) *models.GroupSwing {
+		if existing, ok := groups[key]; ok {
+			return existing
+		}
+		c := &models.GroupSwing{ID: g.id, Name: g.name, Type: g.groupType}
+		groups[key] = c
+		return c
+	}
+	if before != nil {
+		for key, g := range before.groups {
+			c := get(key, g)
+			c.VotesFrom = g.votes
+			c.ShareFrom = percent(g.votes, before.valid)
+		}
+		out.TurnoutFrom = turnout(before)
+		out.WinnerFrom, out.MarginFrom = leadMargin(before)
+	}
+	if after != nil {
+		for key, g := range after.groups {
+			c := get(key, g)
+			c.VotesTo = g.votes
+			c.ShareTo = percent(g.votes, after.valid)
+		}
+		out.TurnoutTo = turnout(after)
+		out.WinnerTo, out.MarginTo = leadMargin(after)
+	}
+	out.TurnoutChange = change(out.TurnoutFrom, out.TurnoutTo)
+	out.MarginChange = change(out.MarginFrom, out.MarginTo)
+
+	out.Groups = make([]models.GroupSwing, 0, len(groups))
+	for _, g := range groups {
+		g.Swing = roundPoints(g.ShareTo - g.ShareFrom)
+		out.Groups = append(out.Groups, *g)
+	}
+	sort.Slice(out.Groups, func(i, j int) bool {
+		a, b := out.Groups[i], out.Groups[j]
+		if a.ShareTo != b.ShareTo {
+			return a.ShareTo > b.ShareTo
+		}
+		if a.ShareFrom != b.ShareFrom {
+			return a.ShareFrom > b.ShareFrom
+		}
+		return a.Name < b.Name
+	})
+	return out
+}
+
+func turnout(a *areaTally) *float64 {
+	if a.registered == 0 {
+		return nil
+	}
+	t := percent(a.valid+a.rejected, a.registered)
+	return &t
+}
+
+// leadMargin returns the leading group and its lead over the runner-up in
+// percentage points of valid votes.
+func leadMargin(a *areaTally) (*string, *float64) {
+	if a.valid == 0 {
+		return nil, nil
+	}
+	var first, second *groupVotes
+	for _, g := range a.groups {
+		switch {
+		case first == nil || g.votes > first.votes || (g.votes == first.votes && g.name < first.name):
+			first, second = g, first
+		case second == nil || g.votes > second.votes:
+			second = g
+		}
+	}
+	runnerUp := 0
+	if second != nil {
+		runnerUp = second.votes
+	}
+	margin := percent(first.votes-runnerUp, a.valid)
+	return &first.name, &margin
+}
+
+func change(from, to *float64) *float64 {
+	if from == nil || to == nil {
+		return nil
+	}
+	d := roundPoints(*to - *from)
+	return &d
+}
+
+func roundPoints(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"jalada/internal/models"
+	"jalada/internal/repository"
+)
+
+// deputyTitles is the running mate's seat for each seat contested on a
+// joint ticket.
+var deputyTitles = map[string]string{
+	"president": "deputy_president",
+	"governor":  "deputy_governor",
+}
+
+func isDeputy(title string) bool {
+	return title == "deputy_president" || title == "deputy_governor"
+}
+
+// ListTickets returns an election's joint tickets. title is president or
+// governor and county a county code; both are optional.
+func (s *ElectionService) ListTickets(ctx context.Context, electionID uuid.UUID, title, county string) ([]models.JointTicket, error) {
+	if _, ok := deputyTitles[title]; title != "" && !ok {
+		return nil, invalid("position", "must be one of president, governor")
+	}
+	return s.candidacyRepo.ListTickets(ctx, electionID, title, county)
+}
+
+func (s *ElectionService) GetTicket(ctx context.Context, electionID, id uuid.UUID) (*models.JointTicket, error) {
+	return s.candidacyRepo.GetTicket(ctx, electionID, id)
+}
+
+// CreateTicket names a running mate for a presidential or gubernatorial
+// candidacy. The deputy must stand for the matching deputy seat, in the
+// same county for governors, and for the same party.
+func (s *ElectionService) CreateTicket(ctx context.Context, t *models.JointTicket) error {
+	err := firstError(
+		requireID("principal_candidacy_id", t.PrincipalCandidacyID),
+		requireID("deputy_candidacy_id", t.DeputyCandidacyID),
+	)
+	if err != nil {
+		return err
+	}
+
+	principal, err := s.ticketMember(ctx, t.ElectionID, t.PrincipalCandidacyID, "principal_candidacy_id")
+	if err != nil {
+		return err
+	}
+	deputy, err := s.ticketMember(ctx, t.ElectionID, t.DeputyCandidacyID, "deputy_candidacy_id")
+	if err != nil {
+		return err
+	}
+
+	want, ok := deputyTitles[principal.seat.Title]
+	switch {
+	case !ok:
+		return invalid("principal_candidacy_id", "must stand for president or governor")
+	case deputy.seat.Title != want:
+		return invalid("deputy_candidacy_id", "must stand for %s", want)
+	case !sameID(principal.seat.CountyID, deputy.seat.CountyID):
+		return invalid("deputy_candidacy_id", "must stand in the same county as the governor")
+	case principal.PartyID != nil && deputy.PartyID != nil && *principal.PartyID != *deputy.PartyID:
+		return invalid("deputy_candidacy_id", "must stand for the same party as the principal")
+	}
+	return s.candidacyRepo.CreateTicket(ctx, t)
+}
+
+func (s *ElectionService) DeleteTicket(ctx context.Context, electionID, id uuid.UUID) error {
+	return s.candidacyRepo.DeleteTicket(ctx, electionID, id)
+}
+
+type ticketMember struct {
+	*models.CandidacyDetail
+	seat *models.ElectivePosition
+}
+
+func (s *ElectionService) ticketMember(ctx context.Context, electionID, id uuid.UUID, field string) (*ticketMember, error) {
+	c, err := s.candidacyRepo.Get(ctx, electionID, id)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, fmt.Errorf("create joint ticket: %w: %s is not a candidacy in this election", repository.ErrInvalidReference, field)
+	}
+	seat, err := s.resultsRepo.GetPosition(ctx, c.PositionID)
+	if err != nil {
+		return nil, err
+	}
+	return &ticketMember{CandidacyDetail: c, seat: seat}, nil
+}
+
+func sameID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// withRunningMates attaches each candidate's running mate.
+func withRunningMates(mates map[uuid.UUID]*models.RunningMate, cs []models.CandidateResult) {
+	for i := range cs {
+		cs[i].RunningMate = mates[cs[i].CandidacyID]
+	}
+}
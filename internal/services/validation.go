@@ -0,0 +1,124 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Allowed values mirror the CHECK constraints in the migrations.
+var (
+	politicianGenders   = []string{"male", "female", "other"}
+	politicianStatuses  = []string{"active", "deceased", "retired", "inactive"}
+	courtCaseTypes      = []string{"criminal", "civil", "election_petition", "corruption", "economic_crime", "other"}
+	courtCaseStatuses   = []string{"pending", "ongoing", "convicted", "acquitted", "dismissed", "appealed"}
+	promiseStatuses     = []string{"pending", "in_progress", "fulfilled", "broken", "partially_fulfilled"}
+	controversySeverity = []string{"low", "medium", "high", "critical"}
+	integrityFlagTypes  = []string{"chapter6", "eacc_investigation", "lifestyle_audit", "tax_compliance", "other"}
+	integrityStatuses   = []string{"active", "resolved", "dismissed"}
+	positionTitles      = []string{"president", "deputy_president", "governor", "deputy_governor", "senator", "mp", "woman_rep", "mca"}
+	candidacyStatuses   = []string{"declared", "cleared", "disqualified", "withdrew", "elected", "lost"}
+)
+
+// Candidacy lifecycle values mirror 000012_candidacy_lifecycle.up.sql.
+var (
+	candidacyStages = []string{"party_primary", "iebc_clearance", "dispute", "withdrawal", "result"}
+	primaryMethods  = []string{"vote", "consensus", "direct_nomination"}
+	primaryOutcomes = []string{"won", "lost", "stepped_down", "nullified"}
+)
+
+// Opinion poll values mirror 000014_opinion_polls.up.sql.
+var (
+	pollScopes        = []string{"candidate", "party"}
+	pollMethodologies = []string{"face_to_face", "telephone", "online", "mixed"}
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// ValidationError reports a request field that failed validation.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+func invalid(field, format string, args ...interface{}) error {
+	return &ValidationError{Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+func requireText(field, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return invalid(field, "is required")
+	}
+	return nil
+}
+
+func requireID(field string, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return invalid(field, "is required")
+	}
+	return nil
+}
+
+func requireOneOf(field, value string, allowed []string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return invalid(field, "must be one of %s", strings.Join(allowed, ", "))
+}
+
+func allOneOf(field string, values, allowed []string) error {
+	for _, v := range values {
+		if err := requireOneOf(field, v, allowed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func optionalOneOf(field string, value *string, allowed []string) error {
+	if value == nil {
+		return nil
+	}
+	return requireOneOf(field, *value, allowed)
+}
+
+func requireOrder(field string, from, to *time.Time) error {
+	if from != nil && to != nil && to.Before(*from) {
+		return invalid(field, "must not be before the start date")
+	}
+	return nil
+}
+
+// requireCoordinates checks an optional lat/lng pair that must be given
+// together and lie in WGS84 range.
+func requireCoordinates(lat, lng *float64) error {
+	if lat == nil || lng == nil {
+		return invalid("lat", "lat and lng must be given together")
+	}
+	if *lat < -90 || *lat > 90 {
+		return invalid("lat", "must be between -90 and 90")
+	}
+	if *lng < -180 || *lng > 180 {
+		return invalid("lng", "must be between -180 and 180")
+	}
+	return nil
+}
+
+// firstError returns the first non-nil error so validators read as a list.
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+// Package slug makes the URL slugs of politicians, wards and other records.
+package slug
+
+import "strings"
+
+// Make joins parts with spaces and lowercases them, keeping ASCII letters
+// and digits and turning every other run of characters into one dash.
+func Make(parts ...string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.Join(parts, " ")) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			dash = false
+		case b.Len() > 0 && !dash:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}